@@ -0,0 +1,72 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "exports every key of a running DiceDB server to a JSON lines file",
+	Run: func(cmd *cobra.Command, args []string) {
+		config.Load(cmd.Flags())
+		out, _ := cmd.Flags().GetString("out")
+		fireFileCommand("EXPORT", out)
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("out", "dicedb.jsonl", "path of the file to export the keys to")
+	rootCmd.AddCommand(exportCmd)
+}
+
+// fireFileCommand sends a command that takes a file path as its first
+// argument to the server. The path is made absolute so that it is
+// resolved the same way on the server as it is by the caller.
+func fireFileCommand(name, path string, args ...string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	client, err := dicedb.NewClient(dialHost(), config.Config.Port)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	res := client.Fire(&wire.Command{
+		Cmd:  name,
+		Args: append([]string{absPath}, args...),
+	})
+	if res.Status == wire.Status_ERR {
+		fmt.Fprintln(os.Stderr, res.Message)
+		os.Exit(1)
+	}
+	fmt.Println(res.Message)
+}
+
+// dialHost returns the first address the server binds to, in the form
+// the client dials it, an IPv6 address being enclosed in brackets.
+func dialHost() string {
+	hosts := config.Config.Hosts()
+	if len(hosts) == 0 {
+		return "localhost"
+	}
+	if strings.Contains(hosts[0], ":") {
+		return "[" + hosts[0] + "]"
+	}
+	return hosts[0]
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/config"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "imports the keys from a JSON lines file into a running DiceDB server",
+	Run: func(cmd *cobra.Command, args []string) {
+		config.Load(cmd.Flags())
+		in, _ := cmd.Flags().GetString("in")
+		extra := []string{}
+		if replace, _ := cmd.Flags().GetBool("replace"); replace {
+			extra = append(extra, "REPLACE")
+		}
+		fireFileCommand("IMPORT", in, extra...)
+	},
+}
+
+func init() {
+	importCmd.Flags().String("in", "dicedb.jsonl", "path of the file to import the keys from")
+	importCmd.Flags().Bool("replace", false, "overwrite the keys that already exist")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/logger"
+	"github.com/dicedb/dice/server"
+	"github.com/spf13/cobra"
+)
+
+var importRDBCmd = &cobra.Command{
+	Use:   "import-rdb <dump.rdb>",
+	Short: "starts DiceDB with the keys loaded from a Redis RDB file",
+	Long: `starts DiceDB with the keys loaded from a Redis RDB file.
+
+Strings, lists, hashes, sets and sorted sets are loaded into the shards that
+own them, along with their expiry, before the server starts accepting
+connections. Sorted set scores are rounded towards zero as DiceDB stores
+integer scores. Streams are skipped, and a file holding module types is
+refused.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config.Load(cmd.Flags())
+		config.Config.RDBFile = args[0]
+		slog.SetDefault(logger.New())
+		server.Start()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importRDBCmd)
+}
@@ -47,9 +47,18 @@ func init() {
 var Config *DiceDBConfig
 
 type DiceDBConfig struct {
-	Host string `mapstructure:"host" default:"0.0.0.0" description:"the host address to bind to"`
+	Host string `mapstructure:"host" default:"0.0.0.0" description:"the host addresses to bind to, IPv4 or IPv6, separated by commas"`
 	Port int    `mapstructure:"port" default:"7379" description:"the port to bind to"`
 
+	UnixSocket     string `mapstructure:"unix-socket" default:"" description:"path of a unix domain socket to accept connections on, in addition to the host addresses; empty listens on none"`
+	UnixSocketPerm string `mapstructure:"unix-socket-perm" default:"700" description:"the permissions of the unix domain socket, in octal"`
+
+	TLSPort        int    `mapstructure:"tls-port" default:"0" description:"the port to accept TLS connections on, on the host addresses; 0 accepts none"`
+	TLSCertFile    string `mapstructure:"tls-cert-file" default:"" description:"path of the PEM-encoded certificate the server presents to TLS clients, reloaded on SIGHUP"`
+	TLSKeyFile     string `mapstructure:"tls-key-file" default:"" description:"path of the PEM-encoded private key of the TLS certificate, reloaded on SIGHUP"`
+	TLSCACertFile  string `mapstructure:"tls-ca-cert-file" default:"" description:"path of the PEM-encoded CA certificates the TLS client certificates are verified against, reloaded on SIGHUP"`
+	TLSAuthClients string `mapstructure:"tls-auth-clients" default:"no" description:"whether the TLS clients have to present a certificate signed by the CA, values: yes, optional, no"`
+
 	Username string `mapstructure:"username" default:"dicedb" description:"the username to use for authentication"`
 	Password string `mapstructure:"password" default:"" description:"the password to use for authentication"`
 
@@ -59,8 +68,32 @@ type DiceDBConfig struct {
 	MaxClients  int  `mapstructure:"max-clients" default:"20000" description:"the maximum number of clients to accept"`
 	NumShards   int  `mapstructure:"num-shards" default:"-1" description:"number of shards to create. defaults to number of cores"`
 
+	NumIOThreads int `mapstructure:"num-io-threads" default:"-1" description:"number of io-threads executing the commands of the clients. defaults to number of cores"`
+
+	Timeout      int `mapstructure:"timeout" default:"0" description:"the time (in seconds) after which an idle command-mode connection is closed; 0 keeps them open"`
+	TCPKeepAlive int `mapstructure:"tcp-keepalive" default:"300" description:"the time (in seconds) a connection is idle before keepalive probes are sent to the client, which is disconnected if they go unanswered; 0 sends none"`
+
 	Engine string `mapstructure:"engine" default:"ironhawk" description:"the engine to use, values: ironhawk"`
 
+	RDBFile string `mapstructure:"rdb-file" default:"" description:"path of a Redis RDB file to load before accepting connections, unless the keyspace is restored from the WAL"`
+
+	ScriptTimeLimitMs int `mapstructure:"script-time-limit-ms" default:"5000" description:"the maximum time (in milliseconds) a script run by EVAL can execute for"`
+
+	WatchThrottleMs int `mapstructure:"watch-throttle-ms" default:"0" description:"the minimum interval (in milliseconds) between two notifications of a watch subscription, updates in between are coalesced; 0 sends every update"`
+
+	WatchResumeGraceMs int `mapstructure:"watch-resume-grace-ms" default:"30000" description:"the time (in milliseconds) the watch subscriptions of a disconnected client are kept for it to resume them by reconnecting with the same client id; 0 removes them on disconnect"`
+
+	WatchDiffFullFrameInterval int `mapstructure:"watch-diff-full-frame-interval" default:"100" description:"the number of notifications of a watch subscription in the DIFF mode after which the whole result is sent rather than a diff; 0 only sends the whole result on subscription"`
+
+	WatchQueueSize          int    `mapstructure:"watch-queue-size" default:"1024" description:"the maximum number of watch notifications waiting to be sent to a client, beyond which the slow consumer policy applies"`
+	WatchSlowConsumerPolicy string `mapstructure:"watch-slow-consumer-policy" default:"drop-oldest" description:"what to do when the watch notifications of a client exceed the queue size, values: drop-oldest, disconnect"`
+
+	ShutdownTimeoutMs      int    `mapstructure:"shutdown-timeout-ms" default:"10000" description:"the time (in milliseconds) the in-flight commands are given to complete on shutdown, after which the remaining connections are cut off"`
+	ShutdownCheckpointFile string `mapstructure:"shutdown-checkpoint-file" default:"" description:"path of a file the keyspace is exported to on shutdown, in the format of EXPORT; empty writes none"`
+
+	ClientOutputBufferLimitBytes int `mapstructure:"client-output-buffer-limit-bytes" default:"67108864" description:"the maximum size (in bytes) of the results waiting to be sent to a client that does not read them, beyond which the client is disconnected; 0 for no limit"`
+	PubSubOutputBufferLimitBytes int `mapstructure:"pubsub-output-buffer-limit-bytes" default:"33554432" description:"the maximum size (in bytes) of the messages waiting to be sent to a subscriber, beyond which the subscriber is disconnected, 0 for no limit"`
+
 	EnableWAL                   bool   `mapstructure:"enable-wal" default:"false" description:"enable write-ahead logging"`
 	WALVariant                  string `mapstructure:"wal-variant" default:"forge" description:"wal variant to use, values: forge"`
 	WALDir                      string `mapstructure:"wal-dir" default:"logs" description:"the directory to store WAL segments"`
@@ -69,6 +102,20 @@ type DiceDBConfig struct {
 	WALMaxSegmentSizeMB         int    `mapstructure:"wal-max-segment-size-mb" default:"16" description:"the maximum size of a wal segment file in megabytes before rotation"`
 	WALSegmentRotationTimeSec   int    `mapstructure:"wal-max-segment-rotation-time-sec" default:"60" description:"the time interval (in seconds) after which wal a segment is rotated"`
 	WALBufferSyncIntervalMillis int    `mapstructure:"wal-buffer-sync-interval-ms" default:"200" description:"the interval (in milliseconds) at which the wal write buffer is synced to disk"`
+	WALCompression              string `mapstructure:"wal-compression" default:"none" description:"compression to apply to wal entries, values: none, snappy, zstd"`
+	WALCompressionMinSizeBytes  int    `mapstructure:"wal-compression-min-size-bytes" default:"1024" description:"wal entries smaller than this size (in bytes) are written uncompressed"`
+}
+
+// Hosts returns the addresses to bind to, the host option listing
+// them separated by commas.
+func (c *DiceDBConfig) Hosts() []string {
+	var hosts []string
+	for _, host := range strings.Split(c.Host, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
 }
 
 func Load(flags *pflag.FlagSet) {
@@ -11,9 +11,6 @@ const (
 	ShardCronFrequency time.Duration = 1 * time.Second
 	EnableProfile      bool          = false
 
-	KeepAlive int32 = 300
-	Timeout   int32 = 300
-
 	DefaultConnBacklogSize = 128
 
 	MaxRequestSize = 32 * 1024 * 1024 // 32 MB
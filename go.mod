@@ -40,6 +40,7 @@ require (
 	github.com/google/btree v1.1.3
 	github.com/google/go-cmp v0.6.0
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 	github.com/mmcloughlin/geohash v0.10.0
 	github.com/ohler55/ojg v1.25.0
 	github.com/rs/xid v1.6.0
@@ -50,6 +51,7 @@ require (
 	github.com/stretchr/testify v1.10.0
 	github.com/twmb/murmur3 v1.1.8
 	github.com/wangjia184/sortedset v0.0.0-20220209072355-af6d6d227aa7
+	github.com/yuin/gopher-lua v1.1.1
 	golang.org/x/crypto v0.38.0
 	google.golang.org/protobuf v1.36.6
 )
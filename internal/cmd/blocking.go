@@ -0,0 +1,123 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+// errBlocked is returned by the Execute of blocking commands when none of
+// their keys can be served and the client has to wait. It never reaches the
+// client, Cmd.Execute waits and executes the command again instead.
+var errBlocked = errors.ErrGeneral("command is blocked")
+
+// blockedState is the state of a command waiting on its keys.
+type blockedState struct {
+	waiter *dstore.Waiter
+	keys   []string
+	// deadline is the time the command stops waiting at,
+	// the zero value meaning that it waits forever.
+	deadline time.Time
+	// timeoutRes is the result of the command if it stops waiting.
+	timeoutRes *CmdRes
+}
+
+// parseBlockingTimeout parses the timeout of the blocking commands,
+// given in seconds with an optional decimal part.
+func parseBlockingTimeout(v string) (time.Duration, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || f > float64(math.MaxInt64)/float64(time.Second) {
+		return 0, errors.ErrGeneral("timeout is not a float or out of range")
+	}
+	if f < 0 {
+		return 0, errors.ErrGeneral("timeout is negative")
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
+
+// isNextToServe reports whether the command may take a value from the key,
+// which is not the case if other clients waited on the key before it did.
+// Commands that cannot block, like the ones run by EXEC or scripts, are
+// always served right away.
+func (c *Cmd) isNextToServe(s *dstore.Store, key string) bool {
+	if c.Done == nil {
+		return true
+	}
+	var w *dstore.Waiter
+	if c.blocked != nil {
+		w = c.blocked.waiter
+	}
+	return s.IsNextWaiter(key, w)
+}
+
+// isBlockingErr reports whether the error of serving a key fails the blocking
+// command. A key holding a value of the wrong type fails the command before it
+// waits, but is skipped as if it were empty once the command waits on it.
+func (c *Cmd) isBlockingErr(err error) bool {
+	return err != nil && (err != errors.ErrWrongTypeOperation || c.blocked == nil)
+}
+
+// block makes the command wait until one of the keys is pushed into or the
+// timeout expires, a zero timeout meaning that it waits forever. It returns
+// errBlocked for Cmd.Execute to wait. Commands that cannot block return res,
+// the result of the command when the timeout expires, right away.
+func (c *Cmd) block(sm *shardmanager.ShardManager, keys []string, timeout time.Duration, res *CmdRes) (*CmdRes, error) {
+	if c.Done == nil {
+		return res, nil
+	}
+	if c.blocked == nil {
+		c.blocked = &blockedState{
+			waiter:     dstore.NewWaiter(),
+			keys:       keys,
+			timeoutRes: res,
+		}
+		if timeout > 0 {
+			c.blocked.deadline = time.Now().Add(timeout)
+		}
+		for _, key := range keys {
+			sm.GetShardForKey(key).Thread.Store().AddWaiter(key, c.blocked.waiter)
+		}
+	}
+	return nil, errBlocked
+}
+
+// wait waits until one of the keys the command is blocked on is pushed into,
+// in which case the command is executed again, the timeout expires or the
+// client disconnects.
+func (c *Cmd) wait(sm *shardmanager.ShardManager) (*CmdRes, error) {
+	b := c.blocked
+	var timeout <-chan time.Time
+	if !b.deadline.IsZero() {
+		t := time.NewTimer(time.Until(b.deadline))
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	select {
+	case <-b.waiter.Ready():
+		res, err := c.executeLocked(sm)
+		if err != errBlocked {
+			c.unblock(sm)
+		}
+		return res, err
+	case <-timeout:
+	case <-c.Done:
+	}
+	c.unblock(sm)
+	return b.timeoutRes, nil
+}
+
+// unblock removes the command from the waiters of its keys.
+func (c *Cmd) unblock(sm *shardmanager.ShardManager) {
+	for _, key := range c.blocked.keys {
+		sm.GetShardForKey(key).Thread.Store().RemoveWaiter(key, c.blocked.waiter)
+	}
+	c.blocked = nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cBLMOVE = &CommandMeta{
+	Name:      "BLMOVE",
+	Syntax:    "BLMOVE source destination LEFT|RIGHT LEFT|RIGHT timeout",
+	HelpShort: "BLMOVE is the blocking variant of LMOVE",
+	HelpLong: `
+BLMOVE is the blocking variant of LMOVE. If the list stored at source is empty, the
+client waits until an element is pushed into it and then moves the element to the
+destination as LMOVE does.
+
+The timeout is the maximum number of seconds to wait for, with an optional decimal
+part. A timeout of 0 waits forever. Clients waiting on the same key are served in
+the order they started waiting.
+
+The command returns the moved element, or an empty string if the timeout expires.
+When run in a transaction or a script, the command does not wait.
+	`,
+	Examples: `
+localhost:7379> BLMOVE jobs running LEFT RIGHT 0.5
+OK ""
+localhost:7379> RPUSH jobs j1
+OK 1
+localhost:7379> BLMOVE jobs running LEFT RIGHT 0
+OK "j1"
+	`,
+	Eval:              evalBLMOVE,
+	Execute:           executeBLMOVE,
+	KeySpec:           KeySpec{First: 0, Last: 1, Step: 1},
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cBLMOVE)
+}
+
+var (
+	BLMOVEResNilRes = newLPOPRes("")
+)
+
+func evalBLMOVE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 5 {
+		return BLMOVEResNilRes, errors.ErrWrongArgumentCount("BLMOVE")
+	}
+	from, to, _, err := parseBLMOVEArgs(c)
+	if err != nil {
+		return BLMOVEResNilRes, err
+	}
+
+	v, _, err := moveList(s, s, c.C.Args[0], c.C.Args[1], from, to)
+	if err != nil {
+		return BLMOVEResNilRes, err
+	}
+	return newLPOPRes(v), nil
+}
+
+func executeBLMOVE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 5 {
+		return BLMOVEResNilRes, errors.ErrWrongArgumentCount("BLMOVE")
+	}
+	from, to, timeout, err := parseBLMOVEArgs(c)
+	if err != nil {
+		return BLMOVEResNilRes, err
+	}
+
+	key, newKey := c.C.Args[0], c.C.Args[1]
+	src := sm.GetShardForKey(key).Thread.Store()
+	dst := sm.GetShardForKey(newKey).Thread.Store()
+	if c.isNextToServe(src, key) {
+		v, ok, err := moveList(src, dst, key, newKey, from, to)
+		if c.isBlockingErr(err) {
+			return BLMOVEResNilRes, err
+		}
+		if ok {
+			return newLPOPRes(v), nil
+		}
+	}
+	return c.block(sm, []string{key}, timeout, BLMOVEResNilRes)
+}
+
+func parseBLMOVEArgs(c *Cmd) (from, to bool, timeout time.Duration, err error) {
+	if from, err = parseListEnd("BLMOVE", c.C.Args[2]); err != nil {
+		return
+	}
+	if to, err = parseListEnd("BLMOVE", c.C.Args[3]); err != nil {
+		return
+	}
+	timeout, err = parseBlockingTimeout(c.C.Args[4])
+	return
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cBLPOP = &CommandMeta{
+	Name:      "BLPOP",
+	Syntax:    "BLPOP key [key ...] timeout",
+	HelpShort: "BLPOP is the blocking variant of LPOP",
+	HelpLong: `
+BLPOP is the blocking variant of LPOP. It pops the first element of the first
+non-empty list among the keys, checked in the order they are given. If all the
+lists are empty, the client waits until an element is pushed into one of them.
+
+The timeout is the maximum number of seconds to wait for, with an optional decimal
+part. A timeout of 0 waits forever. Clients waiting on the same key are served in
+the order they started waiting.
+
+The command returns the key and the popped element, or an empty list if the
+timeout expires. When run in a transaction or a script, the command does not wait.
+	`,
+	Examples: `
+localhost:7379> BLPOP jobs 0.5
+OK
+localhost:7379> RPUSH jobs j1 j2
+OK 2
+localhost:7379> BLPOP urgent jobs 0
+OK
+0) jobs
+1) j1
+	`,
+	Eval:              evalBLPOP,
+	Execute:           executeBLPOP,
+	KeySpec:           KeySpec{First: 0, Last: -2, Step: 1},
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cBLPOP)
+}
+
+func newBLPOPRes(key, value string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: []string{key, value},
+				},
+			},
+		},
+	}
+}
+
+var (
+	BLPOPResNilRes = newLRANGERes([]string{})
+)
+
+func evalBLPOP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return evalBlockingListPop(c, s, "BLPOP", true)
+}
+
+func executeBLPOP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return executeBlockingListPop(c, sm, "BLPOP", true)
+}
+
+// evalBlockingListPop pops an element from the first non-empty list
+// among the keys of BLPOP or BRPOP, without waiting.
+func evalBlockingListPop(c *Cmd, s *dstore.Store, command string, left bool) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return BLPOPResNilRes, errors.ErrWrongArgumentCount(command)
+	}
+	if _, err := parseBlockingTimeout(c.C.Args[len(c.C.Args)-1]); err != nil {
+		return BLPOPResNilRes, err
+	}
+
+	for _, key := range c.C.Args[:len(c.C.Args)-1] {
+		v, ok, err := popList(s, key, left)
+		if err != nil {
+			return BLPOPResNilRes, err
+		}
+		if ok {
+			return newBLPOPRes(key, v), nil
+		}
+	}
+	return BLPOPResNilRes, nil
+}
+
+// executeBlockingListPop pops an element from the first non-empty list among
+// the keys of BLPOP or BRPOP, waiting for an element if all of them are empty.
+func executeBlockingListPop(c *Cmd, sm *shardmanager.ShardManager, command string, left bool) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return BLPOPResNilRes, errors.ErrWrongArgumentCount(command)
+	}
+	timeout, err := parseBlockingTimeout(c.C.Args[len(c.C.Args)-1])
+	if err != nil {
+		return BLPOPResNilRes, err
+	}
+
+	keys := c.C.Args[:len(c.C.Args)-1]
+	for _, key := range keys {
+		s := sm.GetShardForKey(key).Thread.Store()
+		if !c.isNextToServe(s, key) {
+			continue
+		}
+		v, ok, err := popList(s, key, left)
+		if c.isBlockingErr(err) {
+			return BLPOPResNilRes, err
+		}
+		if ok {
+			return newBLPOPRes(key, v), nil
+		}
+	}
+	return c.block(sm, keys, timeout, BLPOPResNilRes)
+}
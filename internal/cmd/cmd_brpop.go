@@ -0,0 +1,54 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cBRPOP = &CommandMeta{
+	Name:      "BRPOP",
+	Syntax:    "BRPOP key [key ...] timeout",
+	HelpShort: "BRPOP is the blocking variant of RPOP",
+	HelpLong: `
+BRPOP is the blocking variant of RPOP. It pops the last element of the first
+non-empty list among the keys, checked in the order they are given. If all the
+lists are empty, the client waits until an element is pushed into one of them.
+
+The timeout is the maximum number of seconds to wait for, with an optional decimal
+part. A timeout of 0 waits forever. Clients waiting on the same key are served in
+the order they started waiting.
+
+The command returns the key and the popped element, or an empty list if the
+timeout expires. When run in a transaction or a script, the command does not wait.
+	`,
+	Examples: `
+localhost:7379> BRPOP jobs 0.5
+OK
+localhost:7379> RPUSH jobs j1 j2
+OK 2
+localhost:7379> BRPOP urgent jobs 0
+OK
+0) jobs
+1) j2
+	`,
+	Eval:              evalBRPOP,
+	Execute:           executeBRPOP,
+	KeySpec:           KeySpec{First: 0, Last: -2, Step: 1},
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cBRPOP)
+}
+
+func evalBRPOP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return evalBlockingListPop(c, s, "BRPOP", false)
+}
+
+func executeBRPOP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return executeBlockingListPop(c, sm, "BRPOP", false)
+}
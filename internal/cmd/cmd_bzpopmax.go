@@ -0,0 +1,56 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cBZPOPMAX = &CommandMeta{
+	Name:      "BZPOPMAX",
+	Syntax:    "BZPOPMAX key [key ...] timeout",
+	HelpShort: "BZPOPMAX is the blocking variant of ZPOPMAX",
+	HelpLong: `
+BZPOPMAX is the blocking variant of ZPOPMAX. It pops the member with the highest
+score from the first non-empty sorted set among the keys, checked in the order they
+are given. If all the sorted sets are empty, the client waits until a member is
+added to one of them.
+
+The timeout is the maximum number of seconds to wait for, with an optional decimal
+part. A timeout of 0 waits forever. Clients waiting on the same key are served in
+the order they started waiting.
+
+The command returns the key, the popped member and its score, or an empty list if
+the timeout expires. When run in a transaction or a script, the command does not wait.
+	`,
+	Examples: `
+localhost:7379> BZPOPMAX tasks 0.5
+OK
+localhost:7379> ZADD tasks 20 t2 10 t1
+OK 2
+localhost:7379> BZPOPMAX tasks 0
+OK
+0) tasks
+1) t2
+2) 20
+	`,
+	Eval:              evalBZPOPMAX,
+	Execute:           executeBZPOPMAX,
+	KeySpec:           KeySpec{First: 0, Last: -2, Step: 1},
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cBZPOPMAX)
+}
+
+func evalBZPOPMAX(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return evalBlockingZPop(c, s, "BZPOPMAX", false)
+}
+
+func executeBZPOPMAX(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return executeBlockingZPop(c, sm, "BZPOPMAX", false)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cBZPOPMIN = &CommandMeta{
+	Name:      "BZPOPMIN",
+	Syntax:    "BZPOPMIN key [key ...] timeout",
+	HelpShort: "BZPOPMIN is the blocking variant of ZPOPMIN",
+	HelpLong: `
+BZPOPMIN is the blocking variant of ZPOPMIN. It pops the member with the lowest
+score from the first non-empty sorted set among the keys, checked in the order they
+are given. If all the sorted sets are empty, the client waits until a member is
+added to one of them.
+
+The timeout is the maximum number of seconds to wait for, with an optional decimal
+part. A timeout of 0 waits forever. Clients waiting on the same key are served in
+the order they started waiting.
+
+The command returns the key, the popped member and its score, or an empty list if
+the timeout expires. When run in a transaction or a script, the command does not wait.
+	`,
+	Examples: `
+localhost:7379> BZPOPMIN tasks 0.5
+OK
+localhost:7379> ZADD tasks 20 t2 10 t1
+OK 2
+localhost:7379> BZPOPMIN tasks 0
+OK
+0) tasks
+1) t1
+2) 10
+	`,
+	Eval:              evalBZPOPMIN,
+	Execute:           executeBZPOPMIN,
+	KeySpec:           KeySpec{First: 0, Last: -2, Step: 1},
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cBZPOPMIN)
+}
+
+func newBZPOPMINRes(key string, n *wire.ZElement) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: []string{key, n.Member, strconv.FormatInt(n.Score, 10)},
+				},
+			},
+		},
+	}
+}
+
+var (
+	BZPOPMINResNilRes = newLRANGERes([]string{})
+)
+
+func evalBZPOPMIN(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return evalBlockingZPop(c, s, "BZPOPMIN", true)
+}
+
+func executeBZPOPMIN(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return executeBlockingZPop(c, sm, "BZPOPMIN", true)
+}
+
+// popSortedSet removes and returns the member with the lowest score, if lowest
+// is true, or the highest score from the sorted set stored at the key.
+// It returns nil if the key does not exist or the sorted set is empty.
+func popSortedSet(s *dstore.Store, key string, lowest bool) (*wire.ZElement, error) {
+	obj := s.Get(key)
+	if obj == nil {
+		return nil, nil
+	}
+	if obj.Type != object.ObjTypeSortedSet {
+		return nil, errors.ErrWrongTypeOperation
+	}
+
+	ss := obj.Value.(*types.SortedSet)
+	pop := ss.PopMax
+	if lowest {
+		pop = ss.PopMin
+	}
+	n := pop()
+	if n == nil {
+		return nil, nil
+	}
+	s.BumpVersion(obj)
+	return &wire.ZElement{Member: n.Key(), Score: int64(n.Score())}, nil
+}
+
+// evalBlockingZPop pops a member from the first non-empty sorted set
+// among the keys of BZPOPMIN or BZPOPMAX, without waiting.
+func evalBlockingZPop(c *Cmd, s *dstore.Store, command string, lowest bool) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return BZPOPMINResNilRes, errors.ErrWrongArgumentCount(command)
+	}
+	if _, err := parseBlockingTimeout(c.C.Args[len(c.C.Args)-1]); err != nil {
+		return BZPOPMINResNilRes, err
+	}
+
+	for _, key := range c.C.Args[:len(c.C.Args)-1] {
+		n, err := popSortedSet(s, key, lowest)
+		if err != nil {
+			return BZPOPMINResNilRes, err
+		}
+		if n != nil {
+			return newBZPOPMINRes(key, n), nil
+		}
+	}
+	return BZPOPMINResNilRes, nil
+}
+
+// executeBlockingZPop pops a member from the first non-empty sorted set among the
+// keys of BZPOPMIN or BZPOPMAX, waiting for a member if all of them are empty.
+func executeBlockingZPop(c *Cmd, sm *shardmanager.ShardManager, command string, lowest bool) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return BZPOPMINResNilRes, errors.ErrWrongArgumentCount(command)
+	}
+	timeout, err := parseBlockingTimeout(c.C.Args[len(c.C.Args)-1])
+	if err != nil {
+		return BZPOPMINResNilRes, err
+	}
+
+	keys := c.C.Args[:len(c.C.Args)-1]
+	for _, key := range keys {
+		s := sm.GetShardForKey(key).Thread.Store()
+		if !c.isNextToServe(s, key) {
+			continue
+		}
+		n, err := popSortedSet(s, key, lowest)
+		if c.isBlockingErr(err) {
+			return BZPOPMINResNilRes, err
+		}
+		if n != nil {
+			return newBZPOPMINRes(key, n), nil
+		}
+	}
+	return c.block(sm, keys, timeout, BZPOPMINResNilRes)
+}
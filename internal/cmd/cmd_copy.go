@@ -0,0 +1,122 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cCOPY = &CommandMeta{
+	Name:      "COPY",
+	Syntax:    "COPY source destination [REPLACE]",
+	HelpShort: "COPY copies the value stored at the source key to the destination key",
+	HelpLong: `
+COPY copies the value stored at the source key to the destination key, along with its expiry.
+
+By default the value is not copied if the destination key already exists.
+Use the REPLACE option to overwrite the destination key. The keys can belong to
+different shards, in which case the value is copied between the shards atomically.
+
+The command returns true if the value was copied and false otherwise.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> COPY k1 k2
+OK true
+localhost:7379> COPY k1 k2
+OK false
+localhost:7379> COPY k1 k2 REPLACE
+OK true
+	`,
+	Eval:    evalCOPY,
+	Execute: executeCOPY,
+	KeySpec: KeySpec{First: 0, Last: 1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cCOPY)
+}
+
+func newCOPYRes(copied bool) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_EXPIRERes{
+				EXPIRERes: &wire.EXPIRERes{
+					IsChanged: copied,
+				},
+			},
+		},
+	}
+}
+
+var (
+	COPYResNilRes       = newCOPYRes(false)
+	COPYResCopiedRes    = newCOPYRes(true)
+	COPYResNotCopiedRes = newCOPYRes(false)
+)
+
+func evalCOPY(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 2 || len(c.C.Args) > 3 {
+		return COPYResNilRes, errors.ErrWrongArgumentCount("COPY")
+	}
+	return copyKey(c, s, s)
+}
+
+func executeCOPY(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 2 || len(c.C.Args) > 3 {
+		return COPYResNilRes, errors.ErrWrongArgumentCount("COPY")
+	}
+	src := sm.GetShardForKey(c.C.Args[0]).Thread.Store()
+	dst := sm.GetShardForKey(c.C.Args[1]).Thread.Store()
+	return copyKey(c, src, dst)
+}
+
+func copyKey(c *Cmd, src, dst *dstore.Store) (*CmdRes, error) {
+	key, newKey := c.C.Args[0], c.C.Args[1]
+
+	replace := false
+	if len(c.C.Args) == 3 {
+		if strings.ToUpper(c.C.Args[2]) != "REPLACE" {
+			return COPYResNilRes, errors.ErrInvalidSyntax("COPY")
+		}
+		replace = true
+	}
+
+	if key == newKey {
+		return COPYResNilRes, errors.ErrGeneral("source and destination objects are the same")
+	}
+
+	obj := src.Get(key)
+	if obj == nil {
+		return COPYResNotCopiedRes, nil
+	}
+	if !replace && dst.GetNoTouch(newKey) != nil {
+		return COPYResNotCopiedRes, nil
+	}
+
+	// The value is copied by serializing it, which creates a deep copy
+	// that does not share any state with the source object.
+	b, err := DumpObject(obj)
+	if err != nil {
+		return COPYResNilRes, err
+	}
+	newObj, err := RestoreObject(dst, b)
+	if err != nil {
+		return COPYResNilRes, err
+	}
+
+	dst.Put(newKey, newObj)
+	if exp, ok := dstore.GetExpiry(obj, src); ok {
+		dst.SetUnixTimeExpiry(newObj, exp)
+	}
+	return COPYResCopiedRes, nil
+}
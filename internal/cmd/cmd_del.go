@@ -24,6 +24,7 @@ localhost:7379> SET k2 v2
 OK
 localhost:7379> DEL k1 k2 k3
 OK 2`,
+	KeySpec: KeySpec{First: 0, Last: -1, Step: 1},
 	Eval:    evalDEL,
 	Execute: executeDEL,
 }
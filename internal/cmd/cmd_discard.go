@@ -0,0 +1,65 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cDISCARD = &CommandMeta{
+	Name:      "DISCARD",
+	Syntax:    "DISCARD",
+	HelpShort: "DISCARD throws away the commands queued in a transaction",
+	HelpLong: `
+DISCARD throws away all the commands queued after MULTI and ends the transaction.
+
+The command returns an error if it is sent outside of a transaction.
+	`,
+	Examples: `
+localhost:7379> MULTI
+OK
+localhost:7379> SET k1 v1
+QUEUED
+localhost:7379> DISCARD
+OK
+localhost:7379> GET k1
+OK ""
+	`,
+	Eval:    evalDISCARD,
+	Execute: executeDISCARD,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cDISCARD)
+}
+
+func newDISCARDRes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	DISCARDResNilRes = newDISCARDRes()
+	DISCARDResOKRes  = newDISCARDRes()
+)
+
+// evalDISCARD is reached only when the client is not in a transaction,
+// a DISCARD sent within a transaction is handled by the io-thread of the client.
+func evalDISCARD(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 0 {
+		return DISCARDResNilRes, errors.ErrWrongArgumentCount("DISCARD")
+	}
+	return DISCARDResNilRes, errors.ErrGeneral("DISCARD without MULTI")
+}
+
+func executeDISCARD(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return evalDISCARD(c, nil)
+}
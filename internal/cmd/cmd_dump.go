@@ -0,0 +1,341 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc64"
+	"io"
+	"math"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cDUMP = &CommandMeta{
+	Name:      "DUMP",
+	Syntax:    "DUMP key",
+	HelpShort: "DUMP returns a serialized version of the value stored at the key",
+	HelpLong: `
+DUMP returns a serialized version of the value stored at the key, encoded as a base64 string.
+The returned value can be loaded into any DiceDB instance using the RESTORE command.
+
+The serialized value holds the version of the encoding, the type of the value, the value
+and a CRC64 checksum. The expiry of the key is not part of the serialized value.
+
+The command returns an empty string if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> DUMP k1
+OK "CgAAAAACdjHNvlLwmJpbMg=="
+localhost:7379> DUMP k2
+OK ""
+	`,
+	IsReadOnly: true,
+	Eval:       evalDUMP,
+	Execute:    executeDUMP,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cDUMP)
+}
+
+func newDUMPRes(payload string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_GETRes{
+				GETRes: &wire.GETRes{
+					Value: payload,
+				},
+			},
+		},
+	}
+}
+
+var (
+	DUMPResNilRes = newDUMPRes("")
+)
+
+// dumpVersion is the version of the serialization format written by DUMP.
+// Version 9 was used by the DUMP command of the legacy eval package.
+const dumpVersion byte = 10
+
+var dumpCRCTable = crc64.MakeTable(crc64.ECMA)
+
+func evalDUMP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return DUMPResNilRes, errors.ErrWrongArgumentCount("DUMP")
+	}
+
+	obj := s.Get(c.C.Args[0])
+	if obj == nil {
+		return DUMPResNilRes, nil
+	}
+
+	b, err := DumpObject(obj)
+	if err != nil {
+		return DUMPResNilRes, err
+	}
+	return newDUMPRes(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+func executeDUMP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return DUMPResNilRes, errors.ErrWrongArgumentCount("DUMP")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalDUMP(c, shard.Thread.Store())
+}
+
+// DumpObject serializes the value of the object. The serialized value is
+//
+//	version (1 byte) | type (1 byte) | value | crc64 of the preceding bytes (8 bytes)
+//
+// All the integers are big endian and the strings are prefixed with their length
+// as a 4 byte integer.
+func DumpObject(obj *object.Obj) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(dumpVersion)
+	buf.WriteByte(byte(obj.Type))
+
+	switch obj.Type {
+	case object.ObjTypeString:
+		dumpWriteString(&buf, obj.Value.(string))
+	case object.ObjTypeInt:
+		dumpWriteInt(&buf, obj.Value.(int64))
+	case object.ObjTypeFloat:
+		dumpWriteInt(&buf, int64(math.Float64bits(obj.Value.(float64))))
+	case object.ObjTypeByteArray, object.ObjTypeHLL:
+		dumpWriteString(&buf, string(obj.Value.([]byte)))
+	case object.ObjTypeJSON:
+		b, err := json.Marshal(obj.Value)
+		if err != nil {
+			return nil, err
+		}
+		dumpWriteString(&buf, string(b))
+	case object.ObjTypeSet:
+		set := obj.Value.(map[string]struct{})
+		dumpWriteInt(&buf, int64(len(set)))
+		for m := range set {
+			dumpWriteString(&buf, m)
+		}
+	case object.ObjTypeSSMap:
+		m := obj.Value.(SSMap)
+		dumpWriteInt(&buf, int64(len(m)))
+		for k, v := range m {
+			dumpWriteString(&buf, k)
+			dumpWriteString(&buf, v)
+		}
+	case object.ObjTypeSortedSet:
+		nodes := obj.Value.(*types.SortedSet).GetByRankRange(1, -1, false)
+		dumpWriteInt(&buf, int64(len(nodes)))
+		for _, n := range nodes {
+			dumpWriteString(&buf, n.Key())
+			dumpWriteInt(&buf, int64(n.Score()))
+		}
+	case object.ObjTypeDequeue:
+		values := obj.Value.(*types.List).Values()
+		dumpWriteInt(&buf, int64(len(values)))
+		for _, v := range values {
+			dumpWriteString(&buf, v)
+		}
+	case object.ObjTypeStream:
+		b, err := json.Marshal(newExportStream(obj.Value.(*types.Stream)))
+		if err != nil {
+			return nil, err
+		}
+		dumpWriteString(&buf, string(b))
+	default:
+		return nil, errors.ErrUnknownObjectType
+	}
+
+	return binary.BigEndian.AppendUint64(buf.Bytes(), crc64.Checksum(buf.Bytes(), dumpCRCTable)), nil
+}
+
+// RestoreObject deserializes the value serialized by DumpObject
+// into a new object without any expiry.
+func RestoreObject(s *dstore.Store, b []byte) (*object.Obj, error) {
+	errPayload := errors.ErrGeneral("payload version or checksum are wrong")
+	if len(b) < 10 {
+		return nil, errPayload
+	}
+	data, sum := b[:len(b)-8], binary.BigEndian.Uint64(b[len(b)-8:])
+	if data[0] != dumpVersion || crc64.Checksum(data, dumpCRCTable) != sum {
+		return nil, errPayload
+	}
+
+	t := object.ObjectType(data[1])
+	r := bytes.NewReader(data[2:])
+
+	var v interface{}
+	var err error
+	switch t {
+	case object.ObjTypeString:
+		v, err = dumpReadString(r)
+	case object.ObjTypeInt:
+		v, err = dumpReadInt(r)
+	case object.ObjTypeFloat:
+		var i int64
+		i, err = dumpReadInt(r)
+		v = math.Float64frombits(uint64(i))
+	case object.ObjTypeByteArray, object.ObjTypeHLL:
+		var str string
+		str, err = dumpReadString(r)
+		v = []byte(str)
+	case object.ObjTypeJSON:
+		var str string
+		if str, err = dumpReadString(r); err == nil {
+			err = json.Unmarshal([]byte(str), &v)
+		}
+	case object.ObjTypeSet:
+		v, err = dumpReadSet(r)
+	case object.ObjTypeSSMap:
+		v, err = dumpReadSSMap(r)
+	case object.ObjTypeSortedSet:
+		v, err = dumpReadSortedSet(r)
+	case object.ObjTypeDequeue:
+		v, err = dumpReadList(r)
+	case object.ObjTypeStream:
+		var str string
+		if str, err = dumpReadString(r); err == nil {
+			var e exportStream
+			if err = json.Unmarshal([]byte(str), &e); err == nil {
+				v, err = e.stream()
+			}
+		}
+	default:
+		return nil, errors.ErrUnknownObjectType
+	}
+	if err != nil {
+		return nil, errPayload
+	}
+	if r.Len() != 0 {
+		return nil, errPayload
+	}
+	return s.NewObj(v, -1, t), nil
+}
+
+func dumpWriteInt(buf *bytes.Buffer, v int64) {
+	_ = binary.Write(buf, binary.BigEndian, v)
+}
+
+func dumpWriteString(buf *bytes.Buffer, str string) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(str)))
+	buf.WriteString(str)
+}
+
+func dumpReadInt(r *bytes.Reader) (int64, error) {
+	var v int64
+	err := binary.Read(r, binary.BigEndian, &v)
+	return v, err
+}
+
+func dumpReadString(r *bytes.Reader) (string, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return "", err
+	}
+	if int64(n) > int64(r.Len()) {
+		return "", io.ErrUnexpectedEOF
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return string(b), err
+}
+
+// dumpReadLen reads the number of elements of a collection. Every element
+// takes at least 4 bytes, which bounds the length by the remaining bytes.
+func dumpReadLen(r *bytes.Reader) (int, error) {
+	n, err := dumpReadInt(r)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > int64(r.Len()/4) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return int(n), nil
+}
+
+func dumpReadSet(r *bytes.Reader) (map[string]struct{}, error) {
+	n, err := dumpReadLen(r)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]struct{}, n)
+	for i := 0; i < n; i++ {
+		m, err := dumpReadString(r)
+		if err != nil {
+			return nil, err
+		}
+		set[m] = struct{}{}
+	}
+	return set, nil
+}
+
+func dumpReadSSMap(r *bytes.Reader) (SSMap, error) {
+	n, err := dumpReadLen(r)
+	if err != nil {
+		return nil, err
+	}
+	m := make(SSMap, n)
+	for i := 0; i < n; i++ {
+		k, err := dumpReadString(r)
+		if err != nil {
+			return nil, err
+		}
+		if m[k], err = dumpReadString(r); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func dumpReadSortedSet(r *bytes.Reader) (*types.SortedSet, error) {
+	n, err := dumpReadLen(r)
+	if err != nil {
+		return nil, err
+	}
+	ss := types.NewSortedSet()
+	for i := 0; i < n; i++ {
+		member, err := dumpReadString(r)
+		if err != nil {
+			return nil, err
+		}
+		score, err := dumpReadInt(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := ss.ZADD([]int64{score}, []string{member}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ss, nil
+}
+
+func dumpReadList(r *bytes.Reader) (*types.List, error) {
+	n, err := dumpReadLen(r)
+	if err != nil {
+		return nil, err
+	}
+	l := types.NewList()
+	for i := 0; i < n; i++ {
+		v, err := dumpReadString(r)
+		if err != nil {
+			return nil, err
+		}
+		l.RPush(v)
+	}
+	return l, nil
+}
@@ -18,8 +18,9 @@ var cECHO = &CommandMeta{
 	Examples: `
 localhost:7379> ECHO dicedb
 OK dicedb`,
-	Eval:    evalECHO,
-	Execute: executeECHO,
+	IsReadOnly: true,
+	Eval:       evalECHO,
+	Execute:    executeECHO,
 }
 
 func init() {
@@ -0,0 +1,111 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cEVAL = &CommandMeta{
+	Name:      "EVAL",
+	Syntax:    "EVAL script numkeys [key ...] [arg ...]",
+	HelpShort: "EVAL runs a Lua script atomically on the shards owning its keys",
+	HelpLong: `
+EVAL runs a Lua script next to the data. The first numkeys arguments after numkeys are
+the keys the script operates on and are available to the script in the KEYS table. The
+remaining arguments are available in the ARGV table.
+
+The script calls commands with dice.call(command, arg, ...), which raises an error if the
+command fails, or dice.pcall(command, arg, ...), which returns a table with the error in
+the err field instead. The result of a command is converted to a Lua value: responses
+with a single field are converted to the value of the field, and commands without a
+response return their message, like "OK".
+
+While the script runs, no other command can read or modify the keys of the shards owning
+the keys of the script. The script can only access keys owned by those shards, so every
+key the script accesses must be passed in KEYS.
+
+The value returned by the script is converted back as follows:
+
+- number: an integer, the decimal part is dropped
+- string: a string
+- true: the integer 1, false and nil: an empty string
+- table with an err field: an error with the value of the field
+- table with an ok field: a status with the value of the field
+- any other table: the list of its elements as strings
+
+The script is compiled and cached, so it can later be run by its SHA1 with EVALSHA.
+Scripts have access to the base, table, string and math libraries, and are aborted if
+they run for longer than the script time limit of the server. As scripts are replayed
+from the WAL, math.random returns the same sequence on every run of a script unless the
+script seeds it with math.randomseed.
+	`,
+	Examples: `
+localhost:7379> EVAL "return dice.call('SET', KEYS[1], ARGV[1])" 1 k1 v1
+OK "OK"
+localhost:7379> EVAL "return dice.call('INCRBY', KEYS[1], ARGV[1]) * 2" 1 counter 5
+OK 10
+localhost:7379> EVAL "return {KEYS[1], ARGV[1]}" 1 k1 a1
+OK
+0) k1
+1) a1
+localhost:7379> EVAL "return dice.call('GET', 'k2')" 0
+ERR script tried accessing key 'k2' that is not declared in KEYS
+	`,
+	Eval:              evalEVAL,
+	Execute:           executeEVAL,
+	GetKeys:           getKeysScript,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cEVAL)
+}
+
+func newEVALRes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	EVALResNilRes = newEVALRes()
+)
+
+func evalEVAL(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	script, keys, args, err := parseScriptArgs(c)
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	_, proto, err := scripts.load(script)
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	res, err := runScript(c, proto, keys, args, executeOnStore(s, false))
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	return res, nil
+}
+
+func executeEVAL(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	script, keys, args, err := parseScriptArgs(c)
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	_, proto, err := scripts.load(script)
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	res, err := runScript(c, proto, keys, args, executeOnShards(sm, keys, false))
+	if err != nil {
+		return EVALResNilRes, err
+	}
+	return res, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	lua "github.com/yuin/gopher-lua"
+)
+
+var cEVALSHA = &CommandMeta{
+	Name:      "EVALSHA",
+	Syntax:    "EVALSHA sha1 numkeys [key ...] [arg ...]",
+	HelpShort: "EVALSHA runs a cached Lua script by its SHA1",
+	HelpLong: `
+EVALSHA runs the script cached by EVAL or SCRIPT LOAD whose SHA1 is sha1. The keys and
+arguments are passed to the script in the same way as EVAL.
+
+An error is returned if no script with the SHA1 is cached.
+	`,
+	Examples: `
+localhost:7379> SCRIPT LOAD "return ARGV[1]"
+OK "098e0f0d1448c0a81dafe820f66d460eb09263da"
+localhost:7379> EVALSHA 098e0f0d1448c0a81dafe820f66d460eb09263da 0 hello
+OK "hello"
+localhost:7379> EVALSHA ffffffffffffffffffffffffffffffffffffffff 0
+ERR NOSCRIPT no matching script, use SCRIPT LOAD
+	`,
+	Eval:              evalEVALSHA,
+	Execute:           executeEVALSHA,
+	GetKeys:           getKeysScript,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cEVALSHA)
+}
+
+var (
+	EVALSHAResNilRes = newEVALRes()
+)
+
+func loadEVALSHA(c *Cmd) (proto *lua.FunctionProto, keys, args []string, err error) {
+	sha, keys, args, err := parseScriptArgs(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	proto = scripts.get(sha)
+	if proto == nil {
+		return nil, nil, nil, errors.ErrGeneral("NOSCRIPT no matching script, use SCRIPT LOAD")
+	}
+	return proto, keys, args, nil
+}
+
+func evalEVALSHA(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	proto, keys, args, err := loadEVALSHA(c)
+	if err != nil {
+		return EVALSHAResNilRes, err
+	}
+	res, err := runScript(c, proto, keys, args, executeOnStore(s, false))
+	if err != nil {
+		return EVALSHAResNilRes, err
+	}
+	return res, nil
+}
+
+func executeEVALSHA(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	proto, keys, args, err := loadEVALSHA(c)
+	if err != nil {
+		return EVALSHAResNilRes, err
+	}
+	res, err := runScript(c, proto, keys, args, executeOnShards(sm, keys, false))
+	if err != nil {
+		return EVALSHAResNilRes, err
+	}
+	return res, nil
+}
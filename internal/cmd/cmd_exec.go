@@ -0,0 +1,178 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var cEXEC = &CommandMeta{
+	Name:      "EXEC",
+	Syntax:    "EXEC",
+	HelpShort: "EXEC executes the commands queued in a transaction",
+	HelpLong: `
+EXEC executes all the commands queued after MULTI atomically and ends the transaction.
+
+While the queued commands execute, no other command can read or modify the database,
+even when the keys of the transaction belong to different shards. The transaction
+is written to the write-ahead log as a single entry, so it is either replayed as a
+whole or not at all.
+
+A command that fails while executing does not stop the rest of the commands from
+executing. If a command could not be queued, for example because it does not exist,
+the whole transaction is discarded and EXEC returns an error.
+
+The result of every queued command is returned, in order, as a JSON encoded result.
+	`,
+	Examples: `
+localhost:7379> MULTI
+OK
+localhost:7379> SET k1 v1
+QUEUED
+localhost:7379> GET k1
+QUEUED
+localhost:7379> EXEC
+OK [{"message":"OK","SETRes":{}}, {"message":"OK","GETRes":{"value":"v1"}}]
+	`,
+	Eval:          evalEXEC,
+	Execute:       executeEXEC,
+	GetKeys:       getKeysEXEC,
+	LockAllShards: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cEXEC)
+}
+
+func newEXECRes(results []string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: results,
+				},
+			},
+		},
+	}
+}
+
+var (
+	EXECResNilRes = newEXECRes(nil)
+)
+
+// NewEXECCommand returns the EXEC command that executes the queued commands.
+// Each command is encoded in the arguments of EXEC as its name, the number of
+// its arguments followed by the arguments, so that the whole transaction is
+// written to the WAL as a single command.
+func NewEXECCommand(cmds []*wire.Command) *wire.Command {
+	var args []string
+	for _, c := range cmds {
+		args = append(args, c.Cmd, strconv.Itoa(len(c.Args)))
+		args = append(args, c.Args...)
+	}
+	return &wire.Command{Cmd: "EXEC", Args: args}
+}
+
+// decodeEXEC returns the commands encoded in the arguments of EXEC.
+func decodeEXEC(c *Cmd) ([]*Cmd, error) {
+	var cmds []*Cmd
+	args := c.C.Args
+	for len(args) > 0 {
+		if len(args) < 2 {
+			return nil, errors.ErrInvalidSyntax("EXEC")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 || n > len(args)-2 {
+			return nil, errors.ErrInvalidSyntax("EXEC")
+		}
+
+		meta, ok := CommandRegistry.CommandMetas[args[0]]
+		if !ok {
+			return nil, errors.ErrUnknownCmd(args[0])
+		}
+		cmds = append(cmds, &Cmd{
+			C:        &wire.Command{Cmd: args[0], Args: args[2 : 2+n]},
+			IsReplay: c.IsReplay,
+			ClientID: c.ClientID,
+			Mode:     c.Mode,
+			Meta:     meta,
+		})
+		args = args[2+n:]
+	}
+	return cmds, nil
+}
+
+func getKeysEXEC(c *Cmd) []string {
+	cmds, err := decodeEXEC(c)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, sub := range cmds {
+		for _, k := range sub.Keys() {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+func evalEXEC(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return execTransaction(c, func(sub *Cmd) (*CmdRes, error) {
+		return sub.Meta.Eval(sub, s)
+	})
+}
+
+// executeEXEC executes the queued commands. The locks of all the shards are
+// held by the caller, so the commands are executed without acquiring them again.
+func executeEXEC(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return execTransaction(c, func(sub *Cmd) (*CmdRes, error) {
+		return sub.Meta.Execute(sub, sm)
+	})
+}
+
+func execTransaction(c *Cmd, execute func(sub *Cmd) (*CmdRes, error)) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return EXECResNilRes, errors.ErrGeneral("EXEC without MULTI")
+	}
+
+	cmds, err := decodeEXEC(c)
+	if err != nil {
+		return EXECResNilRes, err
+	}
+
+	results := make([]string, len(cmds))
+	executed := make([]*wire.Command, len(cmds))
+	for i, sub := range cmds {
+		rs := &wire.Result{}
+		res, err := execute(sub)
+		if err != nil {
+			rs.Status, rs.Message = wire.Status_ERR, err.Error()
+		} else {
+			rs = res.Rs
+		}
+
+		b, err := protojson.Marshal(rs)
+		if err != nil {
+			return EXECResNilRes, err
+		}
+		results[i] = string(b)
+		executed[i] = sub.C
+	}
+
+	// Commands may rewrite their arguments while executing so that they have
+	// the same effect when replayed, which the WAL entry of EXEC has to reflect.
+	c.C.Args = NewEXECCommand(executed).Args
+	return newEXECRes(results), nil
+}
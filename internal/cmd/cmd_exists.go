@@ -24,8 +24,10 @@ OK
 localhost:7379> EXISTS k1 k2 k3
 OK 2
 	`,
-	Eval:    evalEXISTS,
-	Execute: executeEXISTS,
+	IsReadOnly: true,
+	KeySpec:    KeySpec{First: 0, Last: -1, Step: 1},
+	Eval:       evalEXISTS,
+	Execute:    executeEXISTS,
 }
 
 func init() {
@@ -28,8 +28,9 @@ OK true
 localhost:7379> EXPIRETIME k1
 OK 1744451192
 	`,
-	Eval:    evalEXPIRETIME,
-	Execute: executeEXPIRETIME,
+	IsReadOnly: true,
+	Eval:       evalEXPIRETIME,
+	Execute:    executeEXPIRETIME,
 }
 
 func init() {
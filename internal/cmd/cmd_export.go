@@ -0,0 +1,327 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cEXPORT = &CommandMeta{
+	Name:      "EXPORT",
+	Syntax:    "EXPORT path",
+	HelpShort: "EXPORT writes every key in the database to a portable JSON lines file",
+	HelpLong: `
+EXPORT writes every key in the database to a portable JSON lines file at path on the server.
+
+Each line of the file holds one key as a JSON object with the following fields
+
+- key: the name of the key
+- type: the type of the value (string, int, float, ssmap, sortedset, ...)
+- value: the value encoded as JSON
+- expires_at: the absolute expiry of the key in milliseconds since epoch, omitted if the key does not expire
+
+The keys are written shard by shard. The file is written to a temporary location and
+renamed to path once all the keys are written, so a partially written file is never observed.
+The exported file can be loaded into any DiceDB instance using the IMPORT command.
+
+EXPORT does not modify the data, hence it does not write the file again when the
+WAL is replayed on restart.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> HSET k2 f1 v1
+OK 1
+localhost:7379> EXPORT /tmp/dicedb.jsonl
+OK
+	`,
+	Eval:    evalEXPORT,
+	Execute: executeEXPORT,
+	// The command operates on every key, not on the path of the file.
+	GetKeys:       func(c *Cmd) []string { return nil },
+	LockAllShards: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cEXPORT)
+}
+
+func newEXPORTRes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	EXPORTResNilRes = newEXPORTRes()
+	EXPORTResOKRes  = newEXPORTRes()
+)
+
+// ExportRecord is the portable representation of a key
+// used by the EXPORT and IMPORT commands.
+type ExportRecord struct {
+	Key       string          `json:"key"`
+	Type      string          `json:"type"`
+	Value     json.RawMessage `json:"value"`
+	ExpiresAt int64           `json:"expires_at,omitempty"`
+}
+
+// exportZElement is the portable representation of a sorted set member.
+type exportZElement struct {
+	Member string `json:"member"`
+	Score  int64  `json:"score"`
+}
+
+// exportStream is the portable representation of a stream. Every entry
+// holds its ID followed by its fields and values.
+type exportStream struct {
+	LastID  string              `json:"last_id"`
+	Entries [][]string          `json:"entries"`
+	Groups  []exportStreamGroup `json:"groups,omitempty"`
+}
+
+type exportStreamGroup struct {
+	Name      string                 `json:"name"`
+	LastID    string                 `json:"last_id"`
+	Consumers []exportStreamConsumer `json:"consumers,omitempty"`
+	Pending   []exportPendingEntry   `json:"pending,omitempty"`
+}
+
+type exportStreamConsumer struct {
+	Name     string `json:"name"`
+	SeenTime int64  `json:"seen_time"`
+}
+
+type exportPendingEntry struct {
+	ID            string `json:"id"`
+	Consumer      string `json:"consumer"`
+	DeliveryTime  int64  `json:"delivery_time"`
+	DeliveryCount int64  `json:"delivery_count"`
+}
+
+func newExportStream(stream *types.Stream) *exportStream {
+	e := &exportStream{LastID: stream.LastID.String(), Entries: [][]string{}}
+	for _, entry := range stream.Entries() {
+		e.Entries = append(e.Entries, append([]string{entry.ID.String()}, entry.Fields...))
+	}
+	for _, g := range stream.Groups() {
+		eg := exportStreamGroup{Name: g.Name, LastID: g.LastID.String()}
+		for _, c := range g.Consumers() {
+			eg.Consumers = append(eg.Consumers, exportStreamConsumer{Name: c.Name, SeenTime: c.SeenTime})
+		}
+		for _, pe := range g.PendingRange(types.StreamID{}, types.MaxStreamID, 0) {
+			eg.Pending = append(eg.Pending, exportPendingEntry{
+				ID:            pe.ID.String(),
+				Consumer:      pe.Consumer,
+				DeliveryTime:  pe.DeliveryTime,
+				DeliveryCount: pe.DeliveryCount,
+			})
+		}
+		e.Groups = append(e.Groups, eg)
+	}
+	return e
+}
+
+// stream creates the stream represented by e.
+func (e *exportStream) stream() (*types.Stream, error) {
+	stream := types.NewStream()
+	for _, entry := range e.Entries {
+		if len(entry) == 0 || len(entry)%2 == 0 {
+			return nil, types.ErrInvalidStreamID
+		}
+		id, err := types.ParseStreamID(entry[0], 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Add(id, entry[1:]); err != nil {
+			return nil, err
+		}
+	}
+	lastID, err := types.ParseStreamID(e.LastID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if lastID.Compare(stream.LastID) < 0 {
+		return nil, types.ErrStreamIDTooSmall
+	}
+	stream.LastID = lastID
+
+	for _, eg := range e.Groups {
+		groupLastID, err := types.ParseStreamID(eg.LastID, 0)
+		if err != nil {
+			return nil, err
+		}
+		g, _ := stream.CreateGroup(eg.Name, groupLastID)
+		for _, c := range eg.Consumers {
+			g.CreateConsumer(c.Name, c.SeenTime)
+		}
+		for _, pe := range eg.Pending {
+			id, err := types.ParseStreamID(pe.ID, 0)
+			if err != nil {
+				return nil, err
+			}
+			g.CreateConsumer(pe.Consumer, pe.DeliveryTime)
+			g.AddPending(&types.PendingEntry{
+				ID:            id,
+				Consumer:      pe.Consumer,
+				DeliveryTime:  pe.DeliveryTime,
+				DeliveryCount: pe.DeliveryCount,
+			})
+		}
+	}
+	return stream, nil
+}
+
+func evalEXPORT(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return EXPORTResNilRes, errors.ErrWrongArgumentCount("EXPORT")
+	}
+	if c.IsReplay {
+		return EXPORTResOKRes, nil
+	}
+	if err := exportToFile(c.C.Args[0], s); err != nil {
+		return EXPORTResNilRes, err
+	}
+	return EXPORTResOKRes, nil
+}
+
+func executeEXPORT(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return EXPORTResNilRes, errors.ErrWrongArgumentCount("EXPORT")
+	}
+	// The file was written when the command was executed, rewriting it
+	// with the keyspace replayed so far would overwrite a newer export.
+	if c.IsReplay {
+		return EXPORTResOKRes, nil
+	}
+
+	stores := make([]*dstore.Store, 0, len(sm.Shards()))
+	for _, shard := range sm.Shards() {
+		stores = append(stores, shard.Thread.Store())
+	}
+	if err := exportToFile(c.C.Args[0], stores...); err != nil {
+		return EXPORTResNilRes, err
+	}
+	return EXPORTResOKRes, nil
+}
+
+// exportToFile writes the keys of all the stores to a temporary file
+// in the same directory as path and then atomically renames it to path.
+func exportToFile(path string, stores ...*dstore.Store) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	bw := bufio.NewWriter(f)
+	for _, s := range stores {
+		if _, err := ExportStore(bw, s); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// ExportStore writes one ExportRecord per line to w for every
+// non-expired key in the store and returns the number of keys written.
+func ExportStore(w io.Writer, s *dstore.Store) (int64, error) {
+	var count int64
+	var err error
+
+	enc := json.NewEncoder(w)
+	now := time.Now().UnixMilli()
+	s.GetStore().All(func(k string, obj *object.Obj) bool {
+		exp, hasExpiry := dstore.GetExpiry(obj, s)
+		if hasExpiry && exp <= now {
+			return true
+		}
+
+		var r *ExportRecord
+		if r, err = NewExportRecord(k, obj); err != nil {
+			return false
+		}
+		if hasExpiry {
+			r.ExpiresAt = exp
+		}
+		if err = enc.Encode(r); err != nil {
+			return false
+		}
+		count++
+		return true
+	})
+	return count, err
+}
+
+// NewExportRecord creates the portable representation of the object
+// stored against the key. The expiry of the key is not populated.
+func NewExportRecord(key string, obj *object.Obj) (*ExportRecord, error) {
+	var v interface{}
+	switch obj.Type {
+	case object.ObjTypeString, object.ObjTypeInt, object.ObjTypeFloat,
+		object.ObjTypeJSON, object.ObjTypeSSMap:
+		v = obj.Value
+	case object.ObjTypeByteArray, object.ObjTypeHLL:
+		// []byte is encoded as a base64 string
+		v = obj.Value.([]byte)
+	case object.ObjTypeSet:
+		members := make([]string, 0, len(obj.Value.(map[string]struct{})))
+		for m := range obj.Value.(map[string]struct{}) {
+			members = append(members, m)
+		}
+		v = members
+	case object.ObjTypeSortedSet:
+		ss := obj.Value.(*types.SortedSet)
+		nodes := ss.GetByRankRange(1, -1, false)
+		elements := make([]exportZElement, len(nodes))
+		for i, n := range nodes {
+			elements[i] = exportZElement{Member: n.Key(), Score: int64(n.Score())}
+		}
+		v = elements
+	case object.ObjTypeDequeue:
+		v = obj.Value.(*types.List).Values()
+	case object.ObjTypeStream:
+		v = newExportStream(obj.Value.(*types.Stream))
+	default:
+		return nil, errors.ErrUnknownObjectType
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportRecord{
+		Key:   key,
+		Type:  obj.Type.String(),
+		Value: b,
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cFCALL = &CommandMeta{
+	Name:      "FCALL",
+	Syntax:    "FCALL function numkeys [key ...] [arg ...]",
+	HelpShort: "FCALL calls a function loaded by FUNCTION LOAD",
+	HelpLong: `
+FCALL calls the function loaded by FUNCTION LOAD. The first numkeys arguments after numkeys
+are the keys the function operates on and the remaining arguments are passed to it as its
+arguments. The keys and the arguments are passed to the function as two tables.
+
+The function runs atomically on the shards owning its keys and its value is returned in the
+same way as the value of the scripts run by EVAL.
+	`,
+	Examples: `
+localhost:7379> FUNCTION LOAD "#!lua name=counters\n dice.register_function('incr2', function(keys) return dice.call('INCRBY', keys[1], 2) end)"
+OK "counters"
+localhost:7379> FCALL incr2 1 c1
+OK 2
+localhost:7379> FCALL nofunc 0
+ERR function not found
+	`,
+	Eval:              evalFCALL,
+	Execute:           executeFCALL,
+	GetKeys:           getKeysScript,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cFCALL)
+}
+
+var (
+	FCALLResNilRes = newEVALRes()
+)
+
+// loadFCALL returns the function called by FCALL or FCALL_RO with its keys and
+// arguments. FCALL_RO can only call the functions flagged with no-writes.
+func loadFCALL(c *Cmd, readOnly bool) (fn *luaFunction, keys, args []string, err error) {
+	name, keys, args, err := parseScriptArgs(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fn = functions.get(name)
+	if fn == nil {
+		return nil, nil, nil, errors.ErrGeneral("function not found")
+	}
+	if readOnly && !fn.noWrites {
+		return nil, nil, nil, errors.ErrGeneral("can not call a function without the no-writes flag using FCALL_RO")
+	}
+	return fn, keys, args, nil
+}
+
+func evalFCALL(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	fn, keys, args, err := loadFCALL(c, false)
+	if err != nil {
+		return FCALLResNilRes, err
+	}
+	res, err := runFunction(c, fn, keys, args, executeOnStore(s, fn.noWrites))
+	if err != nil {
+		return FCALLResNilRes, err
+	}
+	return res, nil
+}
+
+func executeFCALL(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	fn, keys, args, err := loadFCALL(c, false)
+	if err != nil {
+		return FCALLResNilRes, err
+	}
+	res, err := runFunction(c, fn, keys, args, executeOnShards(sm, keys, fn.noWrites))
+	if err != nil {
+		return FCALLResNilRes, err
+	}
+	return res, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cFCALLRO = &CommandMeta{
+	Name:      "FCALL_RO",
+	Syntax:    "FCALL_RO function numkeys [key ...] [arg ...]",
+	HelpShort: "FCALL_RO calls a read-only function loaded by FUNCTION LOAD",
+	HelpLong: `
+FCALL_RO calls a function flagged with no-writes in the same way as FCALL. An error is returned
+if the function is not flagged with no-writes.
+
+As the function can not modify the data, it does not block other read-only commands on the
+shards owning its keys while it runs, and can be served by read replicas.
+	`,
+	Examples: `
+localhost:7379> FUNCTION LOAD "#!lua name=readers\n dice.register_function{function_name='get2', callback=function(keys) return {dice.call('GET', keys[1]), dice.call('GET', keys[2])} end, flags={'no-writes'}}"
+OK "readers"
+localhost:7379> FCALL_RO get2 2 k1 k2
+OK
+0) v1
+1) v2
+	`,
+	IsReadOnly: true,
+	Eval:       evalFCALLRO,
+	Execute:    executeFCALLRO,
+	GetKeys:    getKeysScript,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cFCALLRO)
+}
+
+var (
+	FCALLROResNilRes = newEVALRes()
+)
+
+func evalFCALLRO(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	fn, keys, args, err := loadFCALL(c, true)
+	if err != nil {
+		return FCALLROResNilRes, err
+	}
+	res, err := runFunction(c, fn, keys, args, executeOnStore(s, true))
+	if err != nil {
+		return FCALLROResNilRes, err
+	}
+	return res, nil
+}
+
+func executeFCALLRO(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	fn, keys, args, err := loadFCALL(c, true)
+	if err != nil {
+		return FCALLROResNilRes, err
+	}
+	res, err := runFunction(c, fn, keys, args, executeOnShards(sm, keys, true))
+	if err != nil {
+		return FCALLROResNilRes, err
+	}
+	return res, nil
+}
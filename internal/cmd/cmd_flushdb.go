@@ -29,8 +29,9 @@ OK ""
 localhost:7379> GET k2
 OK ""
 	`,
-	Eval:    evalFLUSHDB,
-	Execute: executeFLUSHDB,
+	Eval:          evalFLUSHDB,
+	Execute:       executeFLUSHDB,
+	LockAllShards: true,
 }
 
 func init() {
@@ -0,0 +1,256 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/regex"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cFUNCTION = &CommandMeta{
+	Name: "FUNCTION",
+	Syntax: "FUNCTION LOAD [REPLACE] code | FUNCTION LIST [LIBRARYNAME pattern] [WITHCODE] | FUNCTION DELETE library " +
+		"| FUNCTION DUMP | FUNCTION RESTORE payload [FLUSH | APPEND | REPLACE] | FUNCTION FLUSH",
+	HelpShort: "FUNCTION manages the libraries of functions called by FCALL",
+	HelpLong: `
+FUNCTION manages the libraries of named functions called by FCALL and FCALL_RO.
+
+A library is Lua code whose first line is the shebang '#!lua name=<library name>'. The code
+registers its functions with dice.register_function(name, callback) or, to pass flags, with
+dice.register_function{function_name=name, callback=callback, flags={'no-writes'}}. The
+callback is called with the keys and the arguments passed to FCALL as two tables, and can
+call commands in the same way as the scripts run by EVAL. Functions flagged with no-writes
+can not call commands modifying the data, and are the only functions FCALL_RO can call.
+
+The libraries are written to the write-ahead log through the FUNCTION commands that modify
+them, so they are restored when the server restarts. The supported subcommands are:
+
+- LOAD [REPLACE] code: load the library and return its name. An error is returned if the library
+  exists, unless REPLACE is passed, or if one of its functions exists in another library.
+- LIST [LIBRARYNAME pattern] [WITHCODE]: return the libraries whose name matches the glob-style
+  pattern, each encoded as JSON with its name, functions, flags and, with WITHCODE, its code.
+- DELETE library: delete the library and all its functions.
+- DUMP: return a serialized, base64 encoded payload of all the libraries.
+- RESTORE payload [FLUSH | APPEND | REPLACE]: load the libraries from the payload returned by DUMP.
+  APPEND, the default, returns an error if a library exists, REPLACE replaces the existing libraries
+  and FLUSH deletes all the existing libraries first.
+- FLUSH: delete all the libraries.
+	`,
+	Examples: `
+localhost:7379> FUNCTION LOAD "#!lua name=mylib\n dice.register_function('hello', function(keys, args) return 'hello ' .. args[1] end)"
+OK "mylib"
+localhost:7379> FCALL hello 0 world
+OK "hello world"
+localhost:7379> FUNCTION LIST
+OK
+0) {"library_name":"mylib","functions":[{"name":"hello","flags":[]}]}
+localhost:7379> FUNCTION DELETE mylib
+OK
+	`,
+	Eval:    evalFUNCTION,
+	Execute: executeFUNCTION,
+	// The arguments of FUNCTION are not keys.
+	GetKeys: func(*Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cFUNCTION)
+}
+
+func newFUNCTIONValueRes(value string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_GETRes{
+				GETRes: &wire.GETRes{
+					Value: value,
+				},
+			},
+		},
+	}
+}
+
+func newFUNCTIONListRes(libs []string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: libs,
+				},
+			},
+		},
+	}
+}
+
+var (
+	FUNCTIONResNilRes = newEVALRes()
+	FUNCTIONResOKRes  = newEVALRes()
+)
+
+// functionInfo and libraryInfo are the JSON encoding of the libraries returned by FUNCTION LIST.
+type functionInfo struct {
+	Name  string   `json:"name"`
+	Flags []string `json:"flags"`
+}
+
+type libraryInfo struct {
+	LibraryName string         `json:"library_name"`
+	Functions   []functionInfo `json:"functions"`
+	LibraryCode string         `json:"library_code,omitempty"`
+}
+
+func evalFUNCTION(c *Cmd, _ *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION")
+	}
+
+	args := c.C.Args[1:]
+	switch strings.ToUpper(c.C.Args[0]) {
+	case "LOAD":
+		return evalFUNCTIONLoad(c, args)
+	case "LIST":
+		return evalFUNCTIONList(args)
+	case "DELETE":
+		if len(args) != 1 {
+			return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION DELETE")
+		}
+		if !functions.delete(args[0]) {
+			return FUNCTIONResNilRes, errors.ErrGeneral("library not found")
+		}
+		return FUNCTIONResOKRes, nil
+	case "DUMP":
+		if len(args) != 0 {
+			return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION DUMP")
+		}
+		return newFUNCTIONValueRes(base64.StdEncoding.EncodeToString(dumpFunctions(functions.list()))), nil
+	case "RESTORE":
+		return evalFUNCTIONRestore(c, args)
+	case "FLUSH":
+		if len(args) != 0 {
+			return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION FLUSH")
+		}
+		functions.flush()
+		return FUNCTIONResOKRes, nil
+	}
+	return FUNCTIONResNilRes, errors.ErrInvalidSyntax("FUNCTION")
+}
+
+func evalFUNCTIONLoad(c *Cmd, args []string) (*CmdRes, error) {
+	replace := false
+	if len(args) == 2 && strings.EqualFold(args[0], "REPLACE") {
+		replace, args = true, args[1:]
+	}
+	if len(args) != 1 {
+		return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION LOAD")
+	}
+
+	lib, err := compileLibrary(c, args[0])
+	if err != nil {
+		return FUNCTIONResNilRes, err
+	}
+	if err := functions.add([]*library{lib}, replace); err != nil {
+		return FUNCTIONResNilRes, err
+	}
+	return newFUNCTIONValueRes(lib.name), nil
+}
+
+func evalFUNCTIONList(args []string) (*CmdRes, error) {
+	pattern, withCode := "*", false
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHCODE":
+			withCode = true
+		case "LIBRARYNAME":
+			if i+1 == len(args) {
+				return FUNCTIONResNilRes, errors.ErrInvalidSyntax("FUNCTION LIST")
+			}
+			pattern = args[i+1]
+			i++
+		default:
+			return FUNCTIONResNilRes, errors.ErrInvalidSyntax("FUNCTION LIST")
+		}
+	}
+
+	var libs []string
+	for _, lib := range functions.list() {
+		if !regex.WildCardMatch(pattern, lib.name) {
+			continue
+		}
+		info := libraryInfo{LibraryName: lib.name, Functions: []functionInfo{}}
+		if withCode {
+			info.LibraryCode = lib.code
+		}
+		for _, fn := range lib.functions {
+			flags := []string{}
+			if fn.noWrites {
+				flags = append(flags, functionFlagNoWrites)
+			}
+			info.Functions = append(info.Functions, functionInfo{Name: fn.name, Flags: flags})
+		}
+		sort.Slice(info.Functions, func(i, j int) bool {
+			return info.Functions[i].Name < info.Functions[j].Name
+		})
+
+		b, err := json.Marshal(info)
+		if err != nil {
+			return FUNCTIONResNilRes, err
+		}
+		libs = append(libs, string(b))
+	}
+	return newFUNCTIONListRes(libs), nil
+}
+
+func evalFUNCTIONRestore(c *Cmd, args []string) (*CmdRes, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return FUNCTIONResNilRes, errors.ErrWrongArgumentCount("FUNCTION RESTORE")
+	}
+	policy := "APPEND"
+	if len(args) == 2 {
+		policy = strings.ToUpper(args[1])
+	}
+	if policy != "APPEND" && policy != "REPLACE" && policy != "FLUSH" {
+		return FUNCTIONResNilRes, errors.ErrInvalidSyntax("FUNCTION RESTORE")
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		return FUNCTIONResNilRes, errors.ErrGeneral("payload version or checksum are wrong")
+	}
+	codes, err := restoreFunctions(payload)
+	if err != nil {
+		return FUNCTIONResNilRes, err
+	}
+
+	libs := make([]*library, len(codes))
+	for i, code := range codes {
+		if libs[i], err = compileLibrary(c, code); err != nil {
+			return FUNCTIONResNilRes, err
+		}
+	}
+
+	if policy == "FLUSH" {
+		err = functions.reset(libs)
+	} else {
+		err = functions.add(libs, policy == "REPLACE")
+	}
+	if err != nil {
+		return FUNCTIONResNilRes, err
+	}
+	return FUNCTIONResOKRes, nil
+}
+
+func executeFUNCTION(c *Cmd, _ *shardmanager.ShardManager) (*CmdRes, error) {
+	return evalFUNCTION(c, nil)
+}
@@ -30,6 +30,7 @@ OK "v1"
 localhost:7379> GET k2
 OK ""
 	`,
+	IsReadOnly:  true,
 	Eval:        evalGET,
 	Execute:     executeGET,
 	IsWatchable: true,
@@ -20,6 +20,20 @@ will receive the output of the GET command (not just the notification) whenever
 the key is updated.
 
 You can update the key in any other client. The GET.WATCH client will receive the updated value.
+
+Like every .WATCH command, GET.WATCH accepts a trailing THROTTLE interval option, given as a
+duration (like 100ms) or as a number of milliseconds. At most one notification is then sent per
+interval, and the updates received within the interval are coalesced into the latest one. The
+default interval is set by the watch-throttle-ms configuration, 0 sending every update.
+
+Every notification carries the sequence number of the subscription in the protobuf field 1000
+of the result, which is not part of the wire.Result message and is skipped by the clients that
+do not read it. It is incremented on every update of the key. A client missed updates, for
+instance coalesced ones, when the sequence number is greater than the previous one plus one.
+
+The subscriptions of a client that disconnects are kept for the watch-resume-grace-ms
+configuration. A client reconnecting its watch connection with the same client id within that
+period resumes them, and receives the latest state of each of its subscriptions.
 	`,
 	Examples: `
 client1:7379> SET k1 v1
@@ -28,10 +28,19 @@ The execution_mode is the mode of the connection, it can be one of the following
 
 If you use DiceDB SDK or CLI then this HANDSHAKE command is automatically sent when the connection is established
 or when you establish a subscription.
+
+The server assigns every connection an id of its own, returned in the message of the response
+as "OK conn_id=<id>". The client_id is shared by the command and watch connections of a client,
+whereas the connection id identifies the connection.
+
+A connection completes the HANDSHAKE once: sending it again with the same client_id and
+execution_mode is a no-op, while sending it with others is rejected. A client_id has at most one
+watch connection at a time, hence a watch-mode HANDSHAKE is rejected while another connection is in
+the watch mode with the same client_id. The .WATCH commands, like SUBSCRIBE, require a HANDSHAKE.
 	`,
 	Examples: `
 localhost:7379> HANDSHAKE 4c9d0411-6b28-4ee5-b78a-e7e258afa52f command
-OK
+OK conn_id=1
 	`,
 	Eval:    evalHANDSHAKE,
 	Execute: executeHANDSHAKE,
@@ -62,6 +71,12 @@ func evalHANDSHAKE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 	if len(c.C.Args) != 2 {
 		return HANDSHAKEResNilRes, errors.ErrWrongArgumentCount("HANDSHAKE")
 	}
+	if c.C.Args[0] == "" {
+		return HANDSHAKEResNilRes, errors.ErrGeneral("client_id must not be empty")
+	}
+	if c.C.Args[1] != "command" && c.C.Args[1] != "watch" {
+		return HANDSHAKEResNilRes, errors.ErrGeneral("invalid execution_mode '" + c.C.Args[1] + "', must be command or watch")
+	}
 	c.ClientID = c.C.Args[0]
 	c.Mode = c.C.Args[1]
 	return HANDSHAKEResOKRes, nil
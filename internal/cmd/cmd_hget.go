@@ -29,6 +29,7 @@ OK ""
 localhost:7379> HGET k1 f2
 OK ""
 	`,
+	IsReadOnly:  true,
 	Eval:        evalHGET,
 	Execute:     executeHGET,
 	IsWatchable: true,
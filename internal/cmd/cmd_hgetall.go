@@ -31,6 +31,7 @@ OK
 localhost:7379> HGETALL k2
 OK
 	`,
+	IsReadOnly:  true,
 	Eval:        evalHGETALL,
 	Execute:     executeHGETALL,
 	IsWatchable: true,
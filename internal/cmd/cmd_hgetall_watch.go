@@ -12,7 +12,7 @@ import (
 
 var cHGETALLWATCH = &CommandMeta{
 	Name:      "HGETALL.WATCH",
-	Syntax:    "HGETALL.WATCH key",
+	Syntax:    "HGETALL.WATCH key [DIFF]",
 	HelpShort: "HGETALL.WATCH creates a query subscription over the HGETALL command",
 	HelpLong: `
 HGETALL.WATCH creates a query subscription over the HGETALL command. The client invoking the command
@@ -20,6 +20,13 @@ will receive the output of the HGETALL command (not just the notification) whene
 the key is updated.
 
 You can update the key in any other client. The HGETALL.WATCH client will receive the updated value.
+
+HGETALL.WATCH accepts a trailing DIFF option, with which the notifications carry only the fields changed
+since the previous notification rather than the whole result. The first notification, and every
+watch-diff-full-frame-interval notification, carries the whole result. A diff has the message
+"OK diff" and lists one triple per changed field: "+", the field and its value when it was set,
+or "-", the field and an empty string when it was removed. A client whose sequence number skips
+should wait for the next whole result. DIFF cannot be combined with a THROTTLE interval.
 	`,
 	Examples: `
 client1:7379> HSET k f1 v1
@@ -4,10 +4,13 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/dicedb/dice/internal/errors"
 	"github.com/dicedb/dice/internal/object"
 	"github.com/dicedb/dice/internal/shardmanager"
 	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
 	"github.com/dicedb/dicedb-go/wire"
 )
 
@@ -15,11 +18,19 @@ type SSMap map[string]string
 
 var cHSET = &CommandMeta{
 	Name:      "HSET",
-	Syntax:    "HSET key field value [field value ...]",
+	Syntax:    "HSET key [IFVERSION version] [IFEQ value] field value [field value ...]",
 	HelpShort: "HSET sets field value in the string-string map stored at key",
 	HelpLong: `
 HSET sets the field and value for the key in the string-string map.
 
+The options, if any, must be passed before the field-value pairs:
+
+- IFVERSION version: only set the fields if the current version of the key, as returned by VERSION, is version
+- IFEQ value: only set the field if its current value is value. Only a single field can be set with IFEQ.
+
+If the IFVERSION or IFEQ condition does not hold, no field is set and
+the "precondition failed" error is returned.
+
 The command returns the number of fields that were added.
 	`,
 	Examples: `
@@ -27,9 +38,16 @@ localhost:7379> HSET k1 f1 v1
 OK 1
 localhost:7379> HSET k1 f1 v1 f2 v2 f3 v3
 OK 2
+localhost:7379> HSET k1 IFEQ v1 f1 v4
+OK 0
+localhost:7379> HSET k1 IFEQ v1 f1 v5
+ERR precondition failed
 	`,
 	Eval:    evalHSET,
 	Execute: executeHSET,
+	// The IFVERSION and IFEQ conditions are checked and the value written
+	// while no other command modifies the key.
+	LockKeysExclusive: true,
 }
 
 func init() {
@@ -56,6 +74,7 @@ var (
 
 func evalHSET(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 	key := c.C.Args[0]
+	params, kvs := parseHSETParams(c.C.Args[1:])
 
 	var m SSMap
 	var countFieldsAdded int64
@@ -72,11 +91,22 @@ func evalHSET(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 
 	// kvs is the list of key-value pairs to set in the SSMap
 	// key and value are alternating elements in the list
-	kvs := c.C.Args[1:]
-	if (len(kvs) & 1) == 1 {
+	if len(kvs) == 0 || (len(kvs)&1) == 1 {
 		return HSETResNilRes, errors.ErrWrongArgumentCount("HSET")
 	}
 
+	if err := checkIfVersion("HSET", obj, params); err != nil {
+		return HSETResNilRes, err
+	}
+	if v, ok := params[types.IFEQ]; ok {
+		if len(kvs) != 2 {
+			return HSETResNilRes, errors.ErrInvalidSyntax("HSET")
+		}
+		if current, ok := m[kvs[0]]; !ok || current != v {
+			return HSETResNilRes, errors.ErrPreconditionFailed
+		}
+	}
+
 	for i := 0; i < len(kvs); i += 2 {
 		k, v := kvs[i], kvs[i+1]
 		if _, ok := m[k]; !ok {
@@ -91,6 +121,22 @@ func evalHSET(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 	return newHSETRes(countFieldsAdded), nil
 }
 
+// parseHSETParams parses the options passed before the field-value pairs.
+// An option is only parsed if it is followed by at least one pair so that
+// the fields named IFVERSION and IFEQ can still be set.
+func parseHSETParams(args []string) (params map[types.Param]string, kvs []string) {
+	params = map[types.Param]string{}
+	for len(args) > 2 {
+		arg := types.Param(strings.ToUpper(args[0]))
+		if arg != types.IFVERSION && arg != types.IFEQ {
+			break
+		}
+		params[arg] = args[1]
+		args = args[2:]
+	}
+	return params, args
+}
+
 func executeHSET(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
 	if len(c.C.Args) < 3 {
 		return HSETResNilRes, errors.ErrWrongArgumentCount("HSET")
@@ -0,0 +1,223 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cIMPORT = &CommandMeta{
+	Name:      "IMPORT",
+	Syntax:    "IMPORT path [REPLACE]",
+	HelpShort: "IMPORT loads the keys from a file created by the EXPORT command",
+	HelpLong: `
+IMPORT loads the keys from a JSON lines file, created by the EXPORT command, present at path on the server.
+
+Every key is routed to the shard that owns it. The absolute expiry of the key is preserved
+and the keys that have already expired are skipped.
+
+By default the keys that already exist in the database are left untouched. Use the REPLACE
+option to overwrite them with the values from the file.
+
+When the WAL is enabled, every imported key is logged to it as a RESTORE command,
+so that the keys are restored on replay whether or not the file is still present,
+and IMPORT itself is not executed again.
+	`,
+	Examples: `
+localhost:7379> IMPORT /tmp/dicedb.jsonl
+OK
+localhost:7379> IMPORT /tmp/dicedb.jsonl REPLACE
+OK
+	`,
+	Eval:    evalIMPORT,
+	Execute: executeIMPORT,
+	// The command operates on every key, not on the path of the file.
+	GetKeys:       func(c *Cmd) []string { return nil },
+	LockAllShards: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cIMPORT)
+}
+
+func newIMPORTRes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	IMPORTResNilRes = newIMPORTRes()
+	IMPORTResOKRes  = newIMPORTRes()
+)
+
+func evalIMPORT(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return importFromFile(c, func(string) *dstore.Store { return s })
+}
+
+func executeIMPORT(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return importFromFile(c, func(key string) *dstore.Store {
+		return sm.GetShardForKey(key).Thread.Store()
+	})
+}
+
+func importFromFile(c *Cmd, storeForKey func(key string) *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 1 || len(c.C.Args) > 2 {
+		return IMPORTResNilRes, errors.ErrWrongArgumentCount("IMPORT")
+	}
+
+	replace := false
+	if len(c.C.Args) == 2 {
+		if strings.ToUpper(c.C.Args[1]) != "REPLACE" {
+			return IMPORTResNilRes, errors.ErrInvalidSyntax("IMPORT")
+		}
+		replace = true
+	}
+
+	// The imported keys are restored by the RESTORE commands logged
+	// before the IMPORT, rather than from the file, which may have changed.
+	if c.IsReplay {
+		return IMPORTResOKRes, nil
+	}
+
+	f, err := os.Open(c.C.Args[0])
+	if err != nil {
+		return IMPORTResNilRes, err
+	}
+	defer f.Close()
+
+	if _, err := ImportRecords(bufio.NewReader(f), storeForKey, replace); err != nil {
+		return IMPORTResNilRes, err
+	}
+	return IMPORTResOKRes, nil
+}
+
+// ImportRecords reads ExportRecords from r and puts them in the store
+// returned by storeForKey, logging them to the WAL. Existing keys are
+// overwritten only if replace is true. It returns the number of keys imported.
+func ImportRecords(r io.Reader, storeForKey func(key string) *dstore.Store, replace bool) (int64, error) {
+	var count int64
+
+	dec := json.NewDecoder(r)
+	now := time.Now().UnixMilli()
+	for {
+		var rec ExportRecord
+		if err := dec.Decode(&rec); err != nil {
+			if err == io.EOF {
+				return count, nil
+			}
+			return count, fmt.Errorf("invalid record after %d keys: %w", count, err)
+		}
+
+		if rec.ExpiresAt > 0 && rec.ExpiresAt <= now {
+			continue
+		}
+
+		s := storeForKey(rec.Key)
+		if !replace && s.GetNoTouch(rec.Key) != nil {
+			continue
+		}
+
+		obj, err := NewObjFromExportRecord(s, &rec)
+		if err != nil {
+			return count, fmt.Errorf("invalid value for key %s: %w", rec.Key, err)
+		}
+
+		s.Put(rec.Key, obj)
+		if rec.ExpiresAt > 0 {
+			s.SetUnixTimeExpiry(obj, rec.ExpiresAt)
+		}
+		if err := LogRestore(rec.Key, obj, rec.ExpiresAt); err != nil {
+			return count, fmt.Errorf("failed to log key %s to the WAL: %w", rec.Key, err)
+		}
+		count++
+	}
+}
+
+// NewObjFromExportRecord creates the object represented by the record.
+// The expiry of the record is not applied to the object.
+func NewObjFromExportRecord(s *dstore.Store, rec *ExportRecord) (*object.Obj, error) {
+	t, ok := object.ParseObjectType(rec.Type)
+	if !ok {
+		return nil, errors.ErrUnknownObjectType
+	}
+
+	var v interface{}
+	var err error
+	switch t {
+	case object.ObjTypeString:
+		var str string
+		err = json.Unmarshal(rec.Value, &str)
+		v = str
+	case object.ObjTypeInt:
+		var i int64
+		err = json.Unmarshal(rec.Value, &i)
+		v = i
+	case object.ObjTypeFloat:
+		var f float64
+		err = json.Unmarshal(rec.Value, &f)
+		v = f
+	case object.ObjTypeJSON:
+		err = json.Unmarshal(rec.Value, &v)
+	case object.ObjTypeByteArray, object.ObjTypeHLL:
+		var b []byte
+		err = json.Unmarshal(rec.Value, &b)
+		v = b
+	case object.ObjTypeSet:
+		var members []string
+		err = json.Unmarshal(rec.Value, &members)
+		set := make(map[string]struct{}, len(members))
+		for _, m := range members {
+			set[m] = struct{}{}
+		}
+		v = set
+	case object.ObjTypeSSMap:
+		m := SSMap{}
+		err = json.Unmarshal(rec.Value, &m)
+		v = m
+	case object.ObjTypeSortedSet:
+		var elements []exportZElement
+		err = json.Unmarshal(rec.Value, &elements)
+		ss := types.NewSortedSet()
+		for _, e := range elements {
+			if _, err = ss.ZADD([]int64{e.Score}, []string{e.Member}, nil); err != nil {
+				break
+			}
+		}
+		v = ss
+	case object.ObjTypeDequeue:
+		var values []string
+		err = json.Unmarshal(rec.Value, &values)
+		l := types.NewList()
+		l.RPush(values...)
+		v = l
+	case object.ObjTypeStream:
+		var e exportStream
+		if err = json.Unmarshal(rec.Value, &e); err == nil {
+			v, err = e.stream()
+		}
+	default:
+		return nil, errors.ErrUnknownObjectType
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.NewObj(v, -1, t), nil
+}
@@ -104,6 +104,7 @@ func doIncr(c *Cmd, s *dstore.Store, delta int64) (oldValue, newValue int64, err
 
 	newValue = oldValue + delta
 	obj.Value = newValue
+	s.BumpVersion(obj)
 
 	return oldValue, newValue, nil
 }
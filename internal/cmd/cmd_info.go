@@ -0,0 +1,83 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cINFO = &CommandMeta{
+	Name:      "INFO",
+	Syntax:    "INFO [section]",
+	HelpShort: "INFO returns information about the server",
+	HelpLong: `
+INFO returns information about the server, as a flat list of name and value pairs.
+The only section supported is clients, which is returned when no section is given:
+
+- connected_clients: the number of connections, in command and watch modes
+- watch_clients: the number of watch-mode connections
+- maxclients: the maximum number of connections, beyond which new ones are rejected
+- rejected_connections: the number of connections rejected for exceeding maxclients
+- timeout: the time (in seconds) after which an idle command-mode connection is closed,
+  0 meaning that they are kept open
+- timed_out_clients: the number of connections closed for being idle for longer than the timeout
+- tcp_keepalive: the time (in seconds) a connection is idle before keepalive probes are sent
+  to the client, 0 meaning that none are sent
+- keepalive_disconnected_clients: the number of connections closed for not answering the
+  keepalive probes
+	`,
+	Examples: `
+localhost:7379> INFO clients
+OK
+0) connected_clients
+1) 3
+2) watch_clients
+3) 1
+4) maxclients
+5) 20000
+6) rejected_connections
+7) 0
+8) timeout
+9) 300
+10) timed_out_clients
+11) 2
+12) tcp_keepalive
+13) 300
+14) keepalive_disconnected_clients
+15) 0
+	`,
+	IsReadOnly: true,
+	Eval:       evalINFO,
+	Execute:    executeINFO,
+	GetKeys:    func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cINFO)
+}
+
+var (
+	INFOResNilRes = newLRANGERes([]string{})
+)
+
+// Note: The information is returned by the iothread, which
+// holds the connections of the clients.
+func evalINFO(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) > 1 {
+		return INFOResNilRes, errors.ErrWrongArgumentCount("INFO")
+	}
+	if len(c.C.Args) == 1 && strings.ToLower(c.C.Args[0]) != "clients" {
+		return INFOResNilRes, errors.ErrGeneral("unsupported INFO section '" + c.C.Args[0] + "'")
+	}
+	return INFOResNilRes, nil
+}
+
+func executeINFO(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalINFO(c, shard.Thread.Store())
+}
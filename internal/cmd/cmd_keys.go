@@ -43,8 +43,12 @@ OK
 1) k2
 2) k33
 	`,
-	Eval:    evalKEYS,
-	Execute: executeKEYS,
+	IsReadOnly: true,
+	Eval:       evalKEYS,
+	Execute:    executeKEYS,
+	// The pattern is not a key, the keys of every shard are read.
+	GetKeys:       func(c *Cmd) []string { return nil },
+	LockAllShards: true,
 }
 
 func init() {
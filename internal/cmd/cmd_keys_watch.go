@@ -0,0 +1,80 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cKEYSWATCH = &CommandMeta{
+	Name:      "KEYS.WATCH",
+	Syntax:    "KEYS.WATCH pattern",
+	HelpShort: "KEYS.WATCH creates a subscription over the keys matching the pattern",
+	HelpLong: `
+KEYS.WATCH creates a subscription over the keys matching the pattern, which supports the
+same wildcards as KEYS. The command returns the keys currently matching the pattern.
+
+Whenever a key matching the pattern is created, updated or deleted, the client receives
+on its watch connection, that is the connection established with "HANDSHAKE client_id watch",
+a list of two elements: the key and the command that modified it. Keys deleted because
+they expired are not notified.
+
+Use the fingerprint of the response to UNWATCH the subscription.
+	`,
+	Examples: `
+client1:7379> SET cart:1 book
+OK
+client1:7379> KEYS.WATCH cart:*
+OK [fingerprint=9108523473453217153]
+0) cart:1
+
+
+client2:7379> DEL cart:1
+OK 1
+
+
+client1:7379> ...
+OK [fingerprint=9108523473453217153]
+0) cart:1
+1) DEL
+	`,
+	IsReadOnly:    true,
+	Eval:          evalKEYSWATCH,
+	Execute:       executeKEYSWATCH,
+	GetKeys:       func(c *Cmd) []string { return nil },
+	LockAllShards: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cKEYSWATCH)
+}
+
+var (
+	KEYSWATCHResNilRes = newKEYSRes([]string{})
+)
+
+func evalKEYSWATCH(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	r, err := evalKEYS(c, s)
+	if err != nil {
+		return KEYSWATCHResNilRes, err
+	}
+
+	r.Rs.Fingerprint64 = c.Fingerprint()
+	return r, nil
+}
+
+func executeKEYSWATCH(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return KEYSWATCHResNilRes, errors.ErrWrongArgumentCount("KEYS.WATCH")
+	}
+	r, err := executeKEYS(c, sm)
+	if err != nil {
+		return KEYSWATCHResNilRes, err
+	}
+
+	r.Rs.Fingerprint64 = c.Fingerprint()
+	return r, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cLMOVE = &CommandMeta{
+	Name:      "LMOVE",
+	Syntax:    "LMOVE source destination LEFT|RIGHT LEFT|RIGHT",
+	HelpShort: "LMOVE pops an element from the source list and pushes it to the destination list",
+	HelpLong: `
+LMOVE atomically pops the first (LEFT) or last (RIGHT) element of the list stored at
+source and pushes it to the head (LEFT) or tail (RIGHT) of the list stored at destination.
+
+The destination list is created if it does not exist. The source and destination can
+be the same list, in which case LMOVE l l LEFT RIGHT rotates the list. The keys can
+belong to different shards.
+
+The command returns the moved element, or an empty string if the source does not exist.
+Use BLMOVE to wait for an element to be pushed into an empty source list.
+	`,
+	Examples: `
+localhost:7379> RPUSH jobs j1 j2
+OK 2
+localhost:7379> LMOVE jobs running LEFT RIGHT
+OK "j1"
+localhost:7379> LRANGE running 0 -1
+OK
+0) j1
+	`,
+	Eval:    evalLMOVE,
+	Execute: executeLMOVE,
+	KeySpec: KeySpec{First: 0, Last: 1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cLMOVE)
+}
+
+var (
+	LMOVEResNilRes = newLPOPRes("")
+)
+
+func evalLMOVE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 4 {
+		return LMOVEResNilRes, errors.ErrWrongArgumentCount("LMOVE")
+	}
+	return lmove(c, s, s)
+}
+
+func executeLMOVE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 4 {
+		return LMOVEResNilRes, errors.ErrWrongArgumentCount("LMOVE")
+	}
+	src := sm.GetShardForKey(c.C.Args[0]).Thread.Store()
+	dst := sm.GetShardForKey(c.C.Args[1]).Thread.Store()
+	return lmove(c, src, dst)
+}
+
+func lmove(c *Cmd, src, dst *dstore.Store) (*CmdRes, error) {
+	from, err := parseListEnd("LMOVE", c.C.Args[2])
+	if err != nil {
+		return LMOVEResNilRes, err
+	}
+	to, err := parseListEnd("LMOVE", c.C.Args[3])
+	if err != nil {
+		return LMOVEResNilRes, err
+	}
+
+	v, _, err := moveList(src, dst, c.C.Args[0], c.C.Args[1], from, to)
+	if err != nil {
+		return LMOVEResNilRes, err
+	}
+	return newLPOPRes(v), nil
+}
+
+// parseListEnd parses the LEFT and RIGHT arguments of LMOVE and BLMOVE,
+// returning true for LEFT.
+func parseListEnd(command, v string) (bool, error) {
+	switch strings.ToUpper(v) {
+	case "LEFT":
+		return true, nil
+	case "RIGHT":
+		return false, nil
+	}
+	return false, errors.ErrInvalidSyntax(command)
+}
+
+// moveList pops an element from the list stored at key in the store src and
+// pushes it to the list stored at newKey in the store dst. The bool return
+// value is false if the key does not exist.
+func moveList(src, dst *dstore.Store, key, newKey string, fromLeft, toLeft bool) (string, bool, error) {
+	// The destination is checked first so that nothing is popped
+	// if it cannot be pushed to the destination.
+	if _, err := getList(dst, newKey); err != nil {
+		return "", false, err
+	}
+
+	v, ok, err := popList(src, key, fromLeft)
+	if err != nil || !ok {
+		return "", false, err
+	}
+	if _, err := pushList(dst, newKey, []string{v}, toLeft); err != nil {
+		return "", false, err
+	}
+	return v, true, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cLPOP = &CommandMeta{
+	Name:      "LPOP",
+	Syntax:    "LPOP key",
+	HelpShort: "LPOP removes and returns the first element of the list stored at key",
+	HelpLong: `
+LPOP removes and returns the first element of the list stored at key.
+
+The key is deleted once the last element of the list is popped. The command
+returns an empty string if the key does not exist. Use BLPOP to wait for an
+element to be pushed into an empty list.
+	`,
+	Examples: `
+localhost:7379> RPUSH l a b
+OK 2
+localhost:7379> LPOP l
+OK "a"
+localhost:7379> LPOP l
+OK "b"
+localhost:7379> LPOP l
+OK ""
+	`,
+	Eval:              evalLPOP,
+	Execute:           executeLPOP,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cLPOP)
+}
+
+func newLPOPRes(value string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_GETRes{
+				GETRes: &wire.GETRes{
+					Value: value,
+				},
+			},
+		},
+	}
+}
+
+var (
+	LPOPResNilRes = newLPOPRes("")
+)
+
+func evalLPOP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return LPOPResNilRes, errors.ErrWrongArgumentCount("LPOP")
+	}
+
+	v, _, err := popList(s, c.C.Args[0], true)
+	if err != nil {
+		return LPOPResNilRes, err
+	}
+	return newLPOPRes(v), nil
+}
+
+func executeLPOP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return LPOPResNilRes, errors.ErrWrongArgumentCount("LPOP")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalLPOP(c, shard.Thread.Store())
+}
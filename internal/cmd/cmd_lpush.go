@@ -0,0 +1,156 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cLPUSH = &CommandMeta{
+	Name:      "LPUSH",
+	Syntax:    "LPUSH key element [element ...]",
+	HelpShort: "LPUSH inserts the elements at the head of the list stored at key",
+	HelpLong: `
+LPUSH inserts the elements at the head of the list stored at key. The list
+is created if the key does not exist.
+
+The elements are inserted one after the other, so the last element ends
+up as the first element of the list.
+
+The command returns the length of the list after the push. Clients blocked
+on the key by BLPOP, BRPOP or BLMOVE are woken up by the push.
+	`,
+	Examples: `
+localhost:7379> LPUSH l a b c
+OK 3
+localhost:7379> LRANGE l 0 -1
+OK
+0) c
+1) b
+2) a
+	`,
+	Eval:              evalLPUSH,
+	Execute:           executeLPUSH,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cLPUSH)
+}
+
+func newLPUSHRes(length int64) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_INCRRes{
+				INCRRes: &wire.INCRRes{
+					Value: length,
+				},
+			},
+		},
+	}
+}
+
+var (
+	LPUSHResNilRes = newLPUSHRes(0)
+)
+
+func evalLPUSH(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return LPUSHResNilRes, errors.ErrWrongArgumentCount("LPUSH")
+	}
+
+	length, err := pushList(s, c.C.Args[0], c.C.Args[1:], true)
+	if err != nil {
+		return LPUSHResNilRes, err
+	}
+	return newLPUSHRes(int64(length)), nil
+}
+
+func executeLPUSH(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return LPUSHResNilRes, errors.ErrWrongArgumentCount("LPUSH")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalLPUSH(c, shard.Thread.Store())
+}
+
+// getList returns the list stored at the key, or nil if the key does not exist.
+func getList(s *dstore.Store, key string) (*object.Obj, error) {
+	obj := s.Get(key)
+	if obj == nil {
+		return nil, nil
+	}
+	if obj.Type != object.ObjTypeDequeue {
+		return nil, errors.ErrWrongTypeOperation
+	}
+	return obj, nil
+}
+
+// pushList pushes the values to the head, if left is true, or to the tail of
+// the list stored at the key and returns the length of the list. The list is
+// created if the key does not exist.
+func pushList(s *dstore.Store, key string, values []string, left bool) (int, error) {
+	obj, err := getList(s, key)
+	if err != nil {
+		return 0, err
+	}
+
+	created := obj == nil
+	if created {
+		obj = s.NewObj(types.NewList(), -1, object.ObjTypeDequeue)
+	}
+
+	l := obj.Value.(*types.List)
+	if left {
+		l.LPush(values...)
+	} else {
+		l.RPush(values...)
+	}
+
+	// Storing the new list wakes up the clients blocked on the key,
+	// which has to be done explicitly when the list is modified in place.
+	if created {
+		s.Put(key, obj)
+	} else {
+		s.BumpVersion(obj)
+		s.SignalKeyReady(key)
+	}
+	return l.Len(), nil
+}
+
+// popList removes and returns the first element, if left is true, or the last
+// element of the list stored at the key. The key is deleted once the list is
+// empty. The bool return value is false if the key does not exist.
+func popList(s *dstore.Store, key string, left bool) (string, bool, error) {
+	obj, err := getList(s, key)
+	if err != nil || obj == nil {
+		return "", false, err
+	}
+
+	l := obj.Value.(*types.List)
+	var v string
+	var ok bool
+	if left {
+		v, ok = l.LPop()
+	} else {
+		v, ok = l.RPop()
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	if l.Len() == 0 {
+		s.Del(key)
+	} else {
+		s.BumpVersion(obj)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cLRANGE = &CommandMeta{
+	Name:      "LRANGE",
+	Syntax:    "LRANGE key start stop",
+	HelpShort: "LRANGE returns the elements of the list stored at key between start and stop",
+	HelpLong: `
+LRANGE returns the elements of the list stored at key between the start and stop indexes.
+
+The indexes are 0-based and both inclusive. Negative indexes are relative to the end of
+the list, -1 being the last element, so LRANGE key 0 -1 returns the whole list.
+
+The command returns an empty list if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> RPUSH l a b c d
+OK 4
+localhost:7379> LRANGE l 0 -1
+OK
+0) a
+1) b
+2) c
+3) d
+localhost:7379> LRANGE l 1 2
+OK
+0) b
+1) c
+localhost:7379> LRANGE l -2 -1
+OK
+0) c
+1) d
+	`,
+	IsReadOnly: true,
+	Eval:       evalLRANGE,
+	Execute:    executeLRANGE,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cLRANGE)
+}
+
+func newLRANGERes(values []string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: values,
+				},
+			},
+		},
+	}
+}
+
+var (
+	LRANGEResNilRes = newLRANGERes([]string{})
+)
+
+func evalLRANGE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 3 {
+		return LRANGEResNilRes, errors.ErrWrongArgumentCount("LRANGE")
+	}
+
+	start, err := strconv.Atoi(c.C.Args[1])
+	if err != nil {
+		return LRANGEResNilRes, errors.ErrIntegerOutOfRange
+	}
+	stop, err := strconv.Atoi(c.C.Args[2])
+	if err != nil {
+		return LRANGEResNilRes, errors.ErrIntegerOutOfRange
+	}
+
+	obj, err := getList(s, c.C.Args[0])
+	if err != nil || obj == nil {
+		return LRANGEResNilRes, err
+	}
+	return newLRANGERes(obj.Value.(*types.List).Range(start, stop)), nil
+}
+
+func executeLRANGE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 3 {
+		return LRANGEResNilRes, errors.ErrWrongArgumentCount("LRANGE")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalLRANGE(c, shard.Thread.Store())
+}
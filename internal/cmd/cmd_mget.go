@@ -0,0 +1,80 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cMGET = &CommandMeta{
+	Name:      "MGET",
+	Syntax:    "MGET key [key ...]",
+	HelpShort: "MGET returns the values of the keys",
+	HelpLong: `
+MGET returns the values of the keys, in the order of the keys. The keys can belong to
+different shards, in which case their values are read atomically.
+
+The value of a key that does not exist, or does not hold a string, is returned as an empty string.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> SET k2 v2
+OK
+localhost:7379> MGET k1 k2 k3
+OK
+0) v1
+1) v2
+2) ""
+	`,
+	IsReadOnly:  true,
+	IsWatchable: true,
+	Eval:        evalMGET,
+	Execute:     executeMGET,
+	KeySpec:     KeySpec{First: 0, Last: -1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cMGET)
+}
+
+var (
+	MGETResNilRes = newLRANGERes([]string{})
+)
+
+// mget returns the values of the keys, read from the stores owning them.
+func mget(keys []string, store func(key string) *dstore.Store) *CmdRes {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = mgetValue(store(key).Get(key))
+	}
+	return newLRANGERes(values)
+}
+
+func mgetValue(obj *object.Obj) string {
+	value, err := getWireValueFromObj(obj)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+func evalMGET(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 1 {
+		return MGETResNilRes, errors.ErrWrongArgumentCount("MGET")
+	}
+	return mget(c.C.Args, func(string) *dstore.Store { return s }), nil
+}
+
+func executeMGET(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 1 {
+		return MGETResNilRes, errors.ErrWrongArgumentCount("MGET")
+	}
+	return mget(c.C.Args, func(key string) *dstore.Store {
+		return sm.GetShardForKey(key).Thread.Store()
+	}), nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cMGETWATCH = &CommandMeta{
+	Name:      "MGET.WATCH",
+	Syntax:    "MGET.WATCH key [key ...]",
+	HelpShort: "MGET.WATCH creates a query subscription over the MGET command",
+	HelpLong: `
+MGET.WATCH creates a query subscription over the MGET command. The client invoking the command
+will receive the output of the MGET command (not just the notification) whenever the value of
+any of the keys is updated. An update changing several of the keys is notified once per key.
+	`,
+	Examples: `
+client1:7379> MGET.WATCH k1 k2
+entered the watch mode for MGET.WATCH k1 k2
+
+
+client2:7379> SET k2 v2
+OK
+
+
+client1:7379> ...
+entered the watch mode for MGET.WATCH k1 k2
+OK [fingerprint=1217604345]
+0) ""
+1) v2
+	`,
+	IsReadOnly: true,
+	Eval:       evalMGETWATCH,
+	Execute:    executeMGETWATCH,
+	KeySpec:    KeySpec{First: 0, Last: -1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cMGETWATCH)
+}
+
+var (
+	MGETWATCHResNilRes = newLRANGERes([]string{})
+)
+
+func evalMGETWATCH(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 1 {
+		return MGETWATCHResNilRes, errors.ErrWrongArgumentCount("MGET.WATCH")
+	}
+	r := mget(c.C.Args, func(string) *dstore.Store { return s })
+	r.Rs.Fingerprint64 = c.Fingerprint()
+	return r, nil
+}
+
+func executeMGETWATCH(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 1 {
+		return MGETWATCHResNilRes, errors.ErrWrongArgumentCount("MGET.WATCH")
+	}
+	r, _ := executeMGET(c, sm)
+	r.Rs.Fingerprint64 = c.Fingerprint()
+	return r, nil
+}
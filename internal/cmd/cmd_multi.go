@@ -0,0 +1,69 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cMULTI = &CommandMeta{
+	Name:      "MULTI",
+	Syntax:    "MULTI",
+	HelpShort: "MULTI marks the start of a transaction",
+	HelpLong: `
+MULTI marks the start of a transaction.
+
+The commands sent after MULTI are not executed right away, they are queued and
+replied with QUEUED. The queued commands are executed atomically when EXEC is
+sent, or thrown away when DISCARD is sent.
+
+Transactions cannot be nested and .WATCH commands cannot be queued.
+	`,
+	Examples: `
+localhost:7379> MULTI
+OK
+localhost:7379> SET k1 v1
+QUEUED
+localhost:7379> INCR k2
+QUEUED
+localhost:7379> EXEC
+OK
+	`,
+	Eval:    evalMULTI,
+	Execute: executeMULTI,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cMULTI)
+}
+
+func newMULTIRes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	MULTIResNilRes = newMULTIRes()
+	MULTIResOKRes  = newMULTIRes()
+)
+
+// evalMULTI only validates the command, the commands that follow
+// are queued by the io-thread of the client.
+func evalMULTI(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 0 {
+		return MULTIResNilRes, errors.ErrWrongArgumentCount("MULTI")
+	}
+	return MULTIResOKRes, nil
+}
+
+func executeMULTI(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	return evalMULTI(c, nil)
+}
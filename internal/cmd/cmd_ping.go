@@ -23,8 +23,9 @@ OK "PONG"
 localhost:7379> PING dicedb
 OK "PONG dicedb"
 	`,
-	Eval:    evalPING,
-	Execute: executePING,
+	IsReadOnly: true,
+	Eval:       evalPING,
+	Execute:    executePING,
 }
 
 func init() {
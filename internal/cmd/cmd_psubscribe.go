@@ -0,0 +1,65 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cPSUBSCRIBE = &CommandMeta{
+	Name:      "PSUBSCRIBE",
+	Syntax:    "PSUBSCRIBE pattern [pattern ...]",
+	HelpShort: "PSUBSCRIBE subscribes the client to the channels matching the patterns",
+	HelpLong: `
+PSUBSCRIBE subscribes the client to the channels matching the glob-style patterns.
+The messages published to a matching channel are delivered on the watch connection
+of the client as a list of four elements: "pmessage", the pattern, the channel and
+the message.
+
+The patterns support the following special characters:
+- *: matches any sequence of characters
+- ?: matches any single character
+
+The command returns the number of channels and patterns the client is subscribed to.
+	`,
+	Examples: `
+localhost:7379> PSUBSCRIBE news.*
+OK 1
+
+
+localhost:7379> ...
+OK
+0) pmessage
+1) news.*
+2) news.tech
+3) hello
+	`,
+	Eval:    evalPSUBSCRIBE,
+	Execute: executePSUBSCRIBE,
+	GetKeys: func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cPSUBSCRIBE)
+}
+
+var (
+	PSUBSCRIBEResNilRes = newPUBLISHRes(0)
+)
+
+// Note: We do not do anything here, because the PSUBSCRIBE command
+// is handled by the iothread.
+func evalPSUBSCRIBE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return PSUBSCRIBEResNilRes, errors.ErrWrongArgumentCount("PSUBSCRIBE")
+	}
+	return PSUBSCRIBEResNilRes, nil
+}
+
+func executePSUBSCRIBE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalPSUBSCRIBE(c, shard.Thread.Store())
+}
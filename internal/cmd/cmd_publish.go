@@ -0,0 +1,74 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cPUBLISH = &CommandMeta{
+	Name:      "PUBLISH",
+	Syntax:    "PUBLISH channel message",
+	HelpShort: "PUBLISH sends the message to the clients subscribed to the channel",
+	HelpLong: `
+PUBLISH sends the message to the clients subscribed to the channel with SUBSCRIBE,
+and to the clients subscribed to a pattern matching the channel with PSUBSCRIBE.
+
+The message is delivered on the watch connection of the subscribed clients, that is
+the connection established with "HANDSHAKE client_id watch". Messages are not stored,
+clients that are not connected when the message is published never receive it.
+
+The command returns the number of clients the message was delivered to, a client
+subscribed to both the channel and a matching pattern being counted twice.
+	`,
+	Examples: `
+localhost:7379> PUBLISH news hello
+OK 2
+	`,
+	Eval:    evalPUBLISH,
+	Execute: executePUBLISH,
+	GetKeys: func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cPUBLISH)
+}
+
+func newPUBLISHRes(count int64) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_INCRRes{
+				INCRRes: &wire.INCRRes{
+					Value: count,
+				},
+			},
+		},
+	}
+}
+
+var (
+	PUBLISHResNilRes = newPUBLISHRes(0)
+)
+
+// Note: The message is delivered by the iothread, which
+// holds the subscriptions of the clients.
+func evalPUBLISH(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return PUBLISHResNilRes, errors.ErrWrongArgumentCount("PUBLISH")
+	}
+	return PUBLISHResNilRes, nil
+}
+
+func executePUBLISH(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return PUBLISHResNilRes, errors.ErrWrongArgumentCount("PUBLISH")
+	}
+	shard := sm.GetShardForKey("-")
+	return evalPUBLISH(c, shard.Thread.Store())
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cPUBSUB = &CommandMeta{
+	Name:      "PUBSUB",
+	Syntax:    "PUBSUB CHANNELS [pattern] | PUBSUB NUMSUB [channel ...]",
+	HelpShort: "PUBSUB inspects the state of the Pub/Sub subsystem",
+	HelpLong: `
+PUBSUB inspects the state of the Pub/Sub subsystem.
+
+- CHANNELS [pattern]: returns the channels having at least one subscriber, sorted by name.
+  If the pattern is given, only the channels matching the glob-style pattern are returned.
+  The clients subscribed through PSUBSCRIBE are not taken into account.
+- NUMSUB [channel ...]: returns the channels along with their number of subscribers,
+  as a flat list of channel and count pairs. The clients subscribed through PSUBSCRIBE
+  are not taken into account.
+	`,
+	Examples: `
+localhost:7379> SUBSCRIBE news sports
+OK 2
+localhost:7379> PUBSUB CHANNELS
+OK
+0) news
+1) sports
+localhost:7379> PUBSUB NUMSUB news weather
+OK
+0) news
+1) 1
+2) weather
+3) 0
+	`,
+	Eval:    evalPUBSUB,
+	Execute: executePUBSUB,
+	GetKeys: func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cPUBSUB)
+}
+
+var (
+	PUBSUBResNilRes = newLRANGERes([]string{})
+)
+
+// Note: The subcommands are answered by the iothread, which
+// holds the subscriptions of the clients.
+func evalPUBSUB(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return PUBSUBResNilRes, errors.ErrWrongArgumentCount("PUBSUB")
+	}
+
+	switch strings.ToUpper(c.C.Args[0]) {
+	case "CHANNELS":
+		if len(c.C.Args) > 2 {
+			return PUBSUBResNilRes, errors.ErrWrongArgumentCount("PUBSUB")
+		}
+	case "NUMSUB":
+	default:
+		return PUBSUBResNilRes, errors.ErrInvalidSyntax("PUBSUB")
+	}
+	return PUBSUBResNilRes, nil
+}
+
+func executePUBSUB(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalPUBSUB(c, shard.Thread.Store())
+}
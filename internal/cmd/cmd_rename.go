@@ -0,0 +1,102 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cRENAME = &CommandMeta{
+	Name:      "RENAME",
+	Syntax:    "RENAME key newkey",
+	HelpShort: "RENAME renames the key to newkey",
+	HelpLong: `
+RENAME renames the key to newkey, along with its expiry.
+
+If newkey already exists it is overwritten. The keys can belong to different shards,
+in which case the value is moved between the shards atomically.
+
+The command returns an error if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> RENAME k1 k2
+OK
+localhost:7379> GET k2
+OK "v1"
+localhost:7379> RENAME k1 k3
+ERR no such key
+	`,
+	Eval:    evalRENAME,
+	Execute: executeRENAME,
+	KeySpec: KeySpec{First: 0, Last: 1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cRENAME)
+}
+
+func newRENAMERes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	RENAMEResNilRes = newRENAMERes()
+	RENAMEResOKRes  = newRENAMERes()
+)
+
+func evalRENAME(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return RENAMEResNilRes, errors.ErrWrongArgumentCount("RENAME")
+	}
+	if _, err := moveKey(s, s, c.C.Args[0], c.C.Args[1], false); err != nil {
+		return RENAMEResNilRes, err
+	}
+	return RENAMEResOKRes, nil
+}
+
+func executeRENAME(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return RENAMEResNilRes, errors.ErrWrongArgumentCount("RENAME")
+	}
+	src := sm.GetShardForKey(c.C.Args[0]).Thread.Store()
+	dst := sm.GetShardForKey(c.C.Args[1]).Thread.Store()
+	if _, err := moveKey(src, dst, c.C.Args[0], c.C.Args[1], false); err != nil {
+		return RENAMEResNilRes, err
+	}
+	return RENAMEResOKRes, nil
+}
+
+// moveKey moves the object stored at key in the store src to newKey in
+// the store dst, along with its expiry. If nx is true, the object is moved
+// only if newKey does not exist. It returns whether the object was moved.
+func moveKey(src, dst *dstore.Store, key, newKey string, nx bool) (bool, error) {
+	obj := src.Get(key)
+	if obj == nil {
+		return false, errors.ErrKeyNotFound
+	}
+	if key == newKey {
+		return !nx, nil
+	}
+	if nx && dst.GetNoTouch(newKey) != nil {
+		return false, nil
+	}
+
+	exp, hasExpiry := dstore.GetExpiry(obj, src)
+	src.Del(key, dstore.WithDelCmd(dstore.Rename))
+	dst.Put(newKey, obj, dstore.WithPutCmd(dstore.Rename))
+	if hasExpiry {
+		dst.SetUnixTimeExpiry(obj, exp)
+	}
+	return true, nil
+}
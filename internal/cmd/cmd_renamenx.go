@@ -0,0 +1,90 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cRENAMENX = &CommandMeta{
+	Name:      "RENAMENX",
+	Syntax:    "RENAMENX key newkey",
+	HelpShort: "RENAMENX renames the key to newkey only if newkey does not exist",
+	HelpLong: `
+RENAMENX renames the key to newkey, along with its expiry, only if newkey does not exist.
+
+The keys can belong to different shards, in which case the value is moved
+between the shards atomically.
+
+The command returns true if the key was renamed and false otherwise.
+It returns an error if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> SET k2 v2
+OK
+localhost:7379> RENAMENX k1 k2
+OK false
+localhost:7379> RENAMENX k1 k3
+OK true
+	`,
+	Eval:    evalRENAMENX,
+	Execute: executeRENAMENX,
+	KeySpec: KeySpec{First: 0, Last: 1, Step: 1},
+}
+
+func init() {
+	CommandRegistry.AddCommand(cRENAMENX)
+}
+
+func newRENAMENXRes(renamed bool) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_EXPIRERes{
+				EXPIRERes: &wire.EXPIRERes{
+					IsChanged: renamed,
+				},
+			},
+		},
+	}
+}
+
+var (
+	RENAMENXResNilRes        = newRENAMENXRes(false)
+	RENAMENXResRenamedRes    = newRENAMENXRes(true)
+	RENAMENXResNotRenamedRes = newRENAMENXRes(false)
+)
+
+func evalRENAMENX(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return RENAMENXResNilRes, errors.ErrWrongArgumentCount("RENAMENX")
+	}
+	return renameNX(s, s, c.C.Args[0], c.C.Args[1])
+}
+
+func executeRENAMENX(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 2 {
+		return RENAMENXResNilRes, errors.ErrWrongArgumentCount("RENAMENX")
+	}
+	src := sm.GetShardForKey(c.C.Args[0]).Thread.Store()
+	dst := sm.GetShardForKey(c.C.Args[1]).Thread.Store()
+	return renameNX(src, dst, c.C.Args[0], c.C.Args[1])
+}
+
+func renameNX(src, dst *dstore.Store, key, newKey string) (*CmdRes, error) {
+	renamed, err := moveKey(src, dst, key, newKey, true)
+	if err != nil {
+		return RENAMENXResNilRes, err
+	}
+	if !renamed {
+		return RENAMENXResNotRenamedRes, nil
+	}
+	return RENAMENXResRenamedRes, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/wal"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cRESTORE = &CommandMeta{
+	Name:      "RESTORE",
+	Syntax:    "RESTORE key ttl payload [REPLACE] [ABSTTL]",
+	HelpShort: "RESTORE creates a key from the serialized value returned by DUMP",
+	HelpLong: `
+RESTORE creates a key from the serialized value returned by the DUMP command.
+
+The ttl is in milliseconds. If ttl is 0 the key is created without any expiry.
+
+The command supports the following options:
+
+- REPLACE: overwrite the key if it already exists, otherwise an error is returned
+- ABSTTL: ttl is the absolute unix time in milliseconds at which the key expires
+
+An error is returned if the version or the checksum of the payload do not match.
+	`,
+	Examples: `
+localhost:7379> SET k1 v1
+OK
+localhost:7379> DUMP k1
+OK "CgAAAAACdjHNvlLwmJpbMg=="
+localhost:7379> RESTORE k2 0 CgAAAAACdjHNvlLwmJpbMg==
+OK
+localhost:7379> GET k2
+OK "v1"
+localhost:7379> RESTORE k2 0 CgAAAAACdjHNvlLwmJpbMg==
+ERR key exists
+	`,
+	Eval:    evalRESTORE,
+	Execute: executeRESTORE,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cRESTORE)
+}
+
+func newRESTORERes() *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+}
+
+var (
+	RESTOREResNilRes = newRESTORERes()
+	RESTOREResOKRes  = newRESTORERes()
+)
+
+func evalRESTORE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return RESTOREResNilRes, errors.ErrWrongArgumentCount("RESTORE")
+	}
+
+	key := c.C.Args[0]
+	ttl, err := strconv.ParseInt(c.C.Args[1], 10, 64)
+	if err != nil || ttl < 0 {
+		return RESTOREResNilRes, errors.ErrInvalidExpireTime("RESTORE")
+	}
+
+	var replace, absTTL bool
+	for _, arg := range c.C.Args[3:] {
+		switch strings.ToUpper(arg) {
+		case "REPLACE":
+			replace = true
+		case "ABSTTL":
+			absTTL = true
+		default:
+			return RESTOREResNilRes, errors.ErrInvalidSyntax("RESTORE")
+		}
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(c.C.Args[2])
+	if err != nil {
+		return RESTOREResNilRes, errors.ErrGeneral("payload version or checksum are wrong")
+	}
+
+	if !replace && s.GetNoTouch(key) != nil {
+		return RESTOREResNilRes, errors.ErrKeyExists
+	}
+
+	obj, err := RestoreObject(s, payload)
+	if err != nil {
+		return RESTOREResNilRes, err
+	}
+
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = ttl
+		if !absTTL {
+			expiresAt += time.Now().UnixMilli()
+		}
+		// A key with an absolute expiry in the past is not created,
+		// but an existing key is still replaced by it.
+		if expiresAt <= time.Now().UnixMilli() {
+			s.Del(key)
+			return RESTOREResOKRes, nil
+		}
+	}
+
+	s.Put(key, obj)
+	if expiresAt > 0 {
+		s.SetUnixTimeExpiry(obj, expiresAt)
+	}
+	return RESTOREResOKRes, nil
+}
+
+func executeRESTORE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return RESTOREResNilRes, errors.ErrWrongArgumentCount("RESTORE")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalRESTORE(c, shard.Thread.Store())
+}
+
+// LogRestore logs the RESTORE command creating the object stored against the
+// key, with its absolute expiry expiresAt if not 0, to the WAL if it is
+// enabled. The keys written other than by a command, such as the imported
+// ones, are logged this way so that replaying the WAL restores them.
+func LogRestore(key string, obj *object.Obj, expiresAt int64) error {
+	if wal.DefaultWAL == nil {
+		return nil
+	}
+	payload, err := DumpObject(obj)
+	if err != nil {
+		return err
+	}
+	return wal.DefaultWAL.LogCommand(&wire.Command{
+		Cmd:  "RESTORE",
+		Args: []string{key, strconv.FormatInt(expiresAt, 10), base64.StdEncoding.EncodeToString(payload), "REPLACE", "ABSTTL"},
+	})
+}
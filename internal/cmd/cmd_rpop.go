@@ -0,0 +1,64 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cRPOP = &CommandMeta{
+	Name:      "RPOP",
+	Syntax:    "RPOP key",
+	HelpShort: "RPOP removes and returns the last element of the list stored at key",
+	HelpLong: `
+RPOP removes and returns the last element of the list stored at key.
+
+The key is deleted once the last element of the list is popped. The command
+returns an empty string if the key does not exist. Use BRPOP to wait for an
+element to be pushed into an empty list.
+	`,
+	Examples: `
+localhost:7379> RPUSH l a b
+OK 2
+localhost:7379> RPOP l
+OK "b"
+localhost:7379> RPOP l
+OK "a"
+localhost:7379> RPOP l
+OK ""
+	`,
+	Eval:              evalRPOP,
+	Execute:           executeRPOP,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cRPOP)
+}
+
+var (
+	RPOPResNilRes = newLPOPRes("")
+)
+
+func evalRPOP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return RPOPResNilRes, errors.ErrWrongArgumentCount("RPOP")
+	}
+
+	v, _, err := popList(s, c.C.Args[0], false)
+	if err != nil {
+		return RPOPResNilRes, err
+	}
+	return newLPOPRes(v), nil
+}
+
+func executeRPOP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return RPOPResNilRes, errors.ErrWrongArgumentCount("RPOP")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalRPOP(c, shard.Thread.Store())
+}
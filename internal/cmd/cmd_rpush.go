@@ -0,0 +1,63 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cRPUSH = &CommandMeta{
+	Name:      "RPUSH",
+	Syntax:    "RPUSH key element [element ...]",
+	HelpShort: "RPUSH inserts the elements at the tail of the list stored at key",
+	HelpLong: `
+RPUSH inserts the elements at the tail of the list stored at key. The list
+is created if the key does not exist.
+
+The command returns the length of the list after the push. Clients blocked
+on the key by BLPOP, BRPOP or BLMOVE are woken up by the push.
+	`,
+	Examples: `
+localhost:7379> RPUSH l a b c
+OK 3
+localhost:7379> LRANGE l 0 -1
+OK
+0) a
+1) b
+2) c
+	`,
+	Eval:              evalRPUSH,
+	Execute:           executeRPUSH,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cRPUSH)
+}
+
+var (
+	RPUSHResNilRes = newLPUSHRes(0)
+)
+
+func evalRPUSH(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return RPUSHResNilRes, errors.ErrWrongArgumentCount("RPUSH")
+	}
+
+	length, err := pushList(s, c.C.Args[0], c.C.Args[1:], false)
+	if err != nil {
+		return RPUSHResNilRes, err
+	}
+	return newLPUSHRes(int64(length)), nil
+}
+
+func executeRPUSH(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return RPUSHResNilRes, errors.ErrWrongArgumentCount("RPUSH")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalRPUSH(c, shard.Thread.Store())
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cSCRIPT = &CommandMeta{
+	Name:      "SCRIPT",
+	Syntax:    "SCRIPT LOAD script | SCRIPT EXISTS sha1 [sha1 ...] | SCRIPT FLUSH",
+	HelpShort: "SCRIPT manages the cache of the scripts run by EVAL and EVALSHA",
+	HelpLong: `
+SCRIPT manages the cache of the scripts run by EVAL and EVALSHA. It supports the
+following subcommands:
+
+- LOAD script: compile and cache the script without running it and return its SHA1
+- EXISTS sha1 [sha1 ...]: return 1 for each SHA1 whose script is cached and 0 otherwise
+- FLUSH: remove all the scripts from the cache
+	`,
+	Examples: `
+localhost:7379> SCRIPT LOAD "return ARGV[1]"
+OK "098e0f0d1448c0a81dafe820f66d460eb09263da"
+localhost:7379> SCRIPT EXISTS 098e0f0d1448c0a81dafe820f66d460eb09263da ffffffffffffffffffffffffffffffffffffffff
+OK
+0) 1
+1) 0
+localhost:7379> SCRIPT FLUSH
+OK
+	`,
+	Eval:    evalSCRIPT,
+	Execute: executeSCRIPT,
+	// The arguments of SCRIPT are not keys.
+	GetKeys: func(*Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cSCRIPT)
+}
+
+func newSCRIPTLoadRes(sha string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_GETRes{
+				GETRes: &wire.GETRes{
+					Value: sha,
+				},
+			},
+		},
+	}
+}
+
+func newSCRIPTExistsRes(exists []string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: exists,
+				},
+			},
+		},
+	}
+}
+
+var (
+	SCRIPTResNilRes = newEVALRes()
+	SCRIPTResOKRes  = newEVALRes()
+)
+
+func evalSCRIPT(c *Cmd, _ *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return SCRIPTResNilRes, errors.ErrWrongArgumentCount("SCRIPT")
+	}
+
+	args := c.C.Args[1:]
+	switch strings.ToUpper(c.C.Args[0]) {
+	case "LOAD":
+		if len(args) != 1 {
+			return SCRIPTResNilRes, errors.ErrWrongArgumentCount("SCRIPT LOAD")
+		}
+		sha, _, err := scripts.load(args[0])
+		if err != nil {
+			return SCRIPTResNilRes, err
+		}
+		return newSCRIPTLoadRes(sha), nil
+	case "EXISTS":
+		if len(args) == 0 {
+			return SCRIPTResNilRes, errors.ErrWrongArgumentCount("SCRIPT EXISTS")
+		}
+		exists := make([]string, len(args))
+		for i, sha := range args {
+			exists[i] = "0"
+			if scripts.get(sha) != nil {
+				exists[i] = "1"
+			}
+		}
+		return newSCRIPTExistsRes(exists), nil
+	case "FLUSH":
+		if len(args) != 0 {
+			return SCRIPTResNilRes, errors.ErrWrongArgumentCount("SCRIPT FLUSH")
+		}
+		scripts.flush()
+		return SCRIPTResOKRes, nil
+	}
+	return SCRIPTResNilRes, errors.ErrInvalidSyntax("SCRIPT")
+}
+
+func executeSCRIPT(c *Cmd, _ *shardmanager.ShardManager) (*CmdRes, error) {
+	return evalSCRIPT(c, nil)
+}
@@ -24,7 +24,7 @@ const (
 // This should involve checking of the old value and the new value.
 var cSET = &CommandMeta{
 	Name:      "SET",
-	Syntax:    "SET key value [EX seconds | PX milliseconds] [EXAT timestamp | PXAT timestamp] [XX | NX] [KEEPTTL]",
+	Syntax:    "SET key value [EX seconds | PX milliseconds] [EXAT timestamp | PXAT timestamp] [XX | NX] [KEEPTTL] [IFVERSION version] [IFEQ value]",
 	HelpShort: "SET puts or updates an existing value for a key",
 	HelpLong: `
 SET puts or updates an existing value for a key.
@@ -38,6 +38,11 @@ SET stores the value as its native type - be it int or string. SET supports the
 - XX: only set the key if it already exists
 - NX: only set the key if it does not already exist
 - KEEPTTL: keep the existing TTL of the key even if some expiration param like EX, etc is provided
+- IFVERSION version: only set the key if its current version, as returned by VERSION, is version
+- IFEQ value: only set the key if its current value is value
+
+If the IFVERSION or IFEQ condition does not hold, the key is not set and
+the "precondition failed" error is returned.
 
 Returns "OK" if the SET operation was successful.
 	`,
@@ -58,9 +63,16 @@ localhost:7379> SET k 43 NX
 OK
 localhost:7379> SET k 43 KEEPTTL
 OK
+localhost:7379> SET k 44 IFEQ 43
+OK
+localhost:7379> SET k 45 IFEQ 43
+ERR precondition failed
 	`,
 	Eval:    evalSET,
 	Execute: executeSET,
+	// The IFVERSION and IFEQ conditions are checked and the value written
+	// while no other command modifies the key.
+	LockKeysExclusive: true,
 }
 
 func init() {
@@ -90,7 +102,11 @@ func parseParams(args []string) (params map[types.Param]string, nonParams []stri
 	for i := 0; i < len(args); i++ {
 		arg := types.Param(strings.ToUpper(args[i]))
 		switch arg {
-		case types.EX, types.PX, types.EXAT, types.PXAT:
+		case types.EX, types.PX, types.EXAT, types.PXAT, types.IFVERSION, types.IFEQ:
+			if i+1 == len(args) {
+				nonParams = append(nonParams, args[i])
+				continue
+			}
 			params[arg] = args[i+1]
 			i++
 		case types.XX, types.NX, types.KEEPTTL, types.LT, types.GT, types.CH, types.INCR:
@@ -188,6 +204,13 @@ func evalSET(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 
 	existingObj := s.Get(key)
 
+	if err := checkIfVersion("SET", existingObj, params); err != nil {
+		return SETResNilRes, err
+	}
+	if v, ok := params[types.IFEQ]; ok && (existingObj == nil || !valueEquals(existingObj, v)) {
+		return SETResNilRes, errors.ErrPreconditionFailed
+	}
+
 	// TODO: Add check for the type before doing the operation
 	// The scope of this is not clear and hence need some thought
 	// on how the database should react when a SET is called on
@@ -0,0 +1,63 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cSUBSCRIBE = &CommandMeta{
+	Name:      "SUBSCRIBE",
+	Syntax:    "SUBSCRIBE channel [channel ...]",
+	HelpShort: "SUBSCRIBE subscribes the client to the channels",
+	HelpLong: `
+SUBSCRIBE subscribes the client to the channels. The messages published to the
+channels with PUBLISH are delivered on the watch connection of the client, that is
+the connection established with "HANDSHAKE client_id watch", as a list of three
+elements: "message", the channel and the message.
+
+The subscriptions belong to the client_id of the connection and are removed when
+the connection the command was sent on is closed.
+
+The command returns the number of channels and patterns the client is subscribed to.
+	`,
+	Examples: `
+localhost:7379> SUBSCRIBE news sports
+OK 2
+
+
+localhost:7379> ...
+OK
+0) message
+1) news
+2) hello
+	`,
+	Eval:    evalSUBSCRIBE,
+	Execute: executeSUBSCRIBE,
+	GetKeys: func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cSUBSCRIBE)
+}
+
+var (
+	SUBSCRIBEResNilRes = newPUBLISHRes(0)
+)
+
+// Note: We do not do anything here, because the SUBSCRIBE command
+// is handled by the iothread.
+func evalSUBSCRIBE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) == 0 {
+		return SUBSCRIBEResNilRes, errors.ErrWrongArgumentCount("SUBSCRIBE")
+	}
+	return SUBSCRIBEResNilRes, nil
+}
+
+func executeSUBSCRIBE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalSUBSCRIBE(c, shard.Thread.Store())
+}
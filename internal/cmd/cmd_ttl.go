@@ -34,8 +34,9 @@ OK 8
 localhost:7379> TTL kn
 OK -2
 	`,
-	Eval:    evalTTL,
-	Execute: executeTTL,
+	IsReadOnly: true,
+	Eval:       evalTTL,
+	Execute:    executeTTL,
 }
 
 func init() {
@@ -30,8 +30,9 @@ OK int
 localhost:7379> TYPE kn
 OK none
 	`,
-	Eval:    evalTYPE,
-	Execute: executeTYPE,
+	IsReadOnly: true,
+	Eval:       evalTYPE,
+	Execute:    executeTYPE,
 }
 
 func init() {
@@ -0,0 +1,52 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cUNSUBSCRIBE = &CommandMeta{
+	Name:      "UNSUBSCRIBE",
+	Syntax:    "UNSUBSCRIBE [channel ...]",
+	HelpShort: "UNSUBSCRIBE unsubscribes the client from the channels",
+	HelpLong: `
+UNSUBSCRIBE unsubscribes the client from the channels, or from all the channels
+it is subscribed to if no channel is given. The pattern subscriptions are removed
+with PUNSUBSCRIBE.
+
+The command returns the number of channels and patterns the client is still subscribed to.
+	`,
+	Examples: `
+localhost:7379> SUBSCRIBE news sports
+OK 2
+localhost:7379> UNSUBSCRIBE news
+OK 1
+localhost:7379> UNSUBSCRIBE
+OK 0
+	`,
+	Eval:    evalUNSUBSCRIBE,
+	Execute: executeUNSUBSCRIBE,
+	GetKeys: func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cUNSUBSCRIBE)
+}
+
+var (
+	UNSUBSCRIBEResNilRes = newPUBLISHRes(0)
+)
+
+// Note: We do not do anything here, because the UNSUBSCRIBE command
+// is handled by the iothread.
+func evalUNSUBSCRIBE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return UNSUBSCRIBEResNilRes, nil
+}
+
+func executeUNSUBSCRIBE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalUNSUBSCRIBE(c, shard.Thread.Store())
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cVERSION = &CommandMeta{
+	Name:      "VERSION",
+	Syntax:    "VERSION key",
+	HelpShort: "VERSION returns the version of the value stored at the key",
+	HelpLong: `
+VERSION returns the version of the value stored at the key.
+
+The version changes every time the value of the key is modified and never
+goes back to an older version, even if the key is deleted and created again.
+The version can be passed to the IFVERSION option of SET, HSET and ZADD
+to modify the key only if no one else modified it in the meantime.
+
+The command returns 0 if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> SET k 43
+OK
+localhost:7379> VERSION k
+OK 1
+localhost:7379> SET k 44 IFVERSION 1
+OK
+localhost:7379> SET k 45 IFVERSION 1
+ERR precondition failed
+localhost:7379> VERSION k
+OK 2
+localhost:7379> VERSION kn
+OK 0
+	`,
+	IsReadOnly: true,
+	Eval:       evalVERSION,
+	Execute:    executeVERSION,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cVERSION)
+}
+
+func newVERSIONRes(version uint64) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_INCRRes{
+				INCRRes: &wire.INCRRes{
+					Value: int64(version),
+				},
+			},
+		},
+	}
+}
+
+var (
+	VERSIONResNilRes = newVERSIONRes(0)
+)
+
+func evalVERSION(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return VERSIONResNilRes, errors.ErrWrongArgumentCount("VERSION")
+	}
+
+	obj := s.Get(c.C.Args[0])
+	if obj == nil {
+		return VERSIONResNilRes, nil
+	}
+	return newVERSIONRes(obj.Version), nil
+}
+
+func executeVERSION(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return VERSIONResNilRes, errors.ErrWrongArgumentCount("VERSION")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalVERSION(c, shard.Thread.Store())
+}
+
+// checkIfVersion returns ErrPreconditionFailed if the IFVERSION option is
+// passed and it does not match the version of obj. A key that does not
+// exist has the version 0.
+func checkIfVersion(command string, obj *object.Obj, params map[types.Param]string) error {
+	v, ok := params[types.IFVERSION]
+	if !ok {
+		return nil
+	}
+	version, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return errors.ErrInvalidValue(command, "IFVERSION")
+	}
+
+	var current uint64
+	if obj != nil {
+		current = obj.Version
+	}
+	if current != version {
+		return errors.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// valueEquals reports whether the string, int or float value of
+// obj is equal to value.
+func valueEquals(obj *object.Obj, value string) bool {
+	switch v := obj.Value.(type) {
+	case string:
+		return v == value
+	case int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && i == v
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		return err == nil && f == v
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cWATCHLIST = &CommandMeta{
+	Name:      "WATCH.LIST",
+	Syntax:    "WATCH.LIST",
+	HelpShort: "WATCH.LIST returns the live watch subscriptions",
+	HelpLong: `
+WATCH.LIST returns the live watch subscriptions of the server, sorted by command. Each
+subscription is returned as three consecutive values: the fingerprint of the subscription,
+the command watched, and the number of clients subscribed to it.
+	`,
+	Examples: `
+localhost:7379> WATCH.LIST
+OK
+0) 2356444921
+1) GET.WATCH k1
+2) 2
+3) 1453218805
+4) KEYS.WATCH user:*
+5) 1
+	`,
+	IsReadOnly: true,
+	Eval:       evalWATCHLIST,
+	Execute:    executeWATCHLIST,
+	GetKeys:    func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cWATCHLIST)
+}
+
+var (
+	WATCHLISTResNilRes = newLRANGERes([]string{})
+)
+
+// Note: The subscriptions are returned by the iothread, which
+// holds the watch subscriptions.
+func evalWATCHLIST(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 0 {
+		return WATCHLISTResNilRes, errors.ErrWrongArgumentCount("WATCH.LIST")
+	}
+	return WATCHLISTResNilRes, nil
+}
+
+func executeWATCHLIST(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalWATCHLIST(c, shard.Thread.Store())
+}
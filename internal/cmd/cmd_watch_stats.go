@@ -0,0 +1,88 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cWATCHSTATS = &CommandMeta{
+	Name:      "WATCH.STATS",
+	Syntax:    "WATCH.STATS",
+	HelpShort: "WATCH.STATS returns statistics about the watch subscriptions",
+	HelpLong: `
+WATCH.STATS returns statistics about the watch subscriptions of the server, as a flat
+list of name and value pairs:
+
+- subscriptions: the number of subscriptions of the clients
+- throttled_subscriptions: the number of subscriptions created with a THROTTLE interval
+- fingerprints: the number of distinct commands watched
+- watched_keys: the number of keys watched
+- watching_clients: the number of clients having subscriptions
+- index_memory_bytes: an estimate of the memory held by the indexes of the subscriptions
+- notifications_sent: the number of notifications sent to the clients
+- notifications_coalesced: the number of updates of throttled subscriptions that were
+  replaced by a later update before being sent
+- notifications_dropped: the number of notifications dropped from the queue of a client not
+  keeping up with them, with the drop-oldest watch-slow-consumer-policy
+- slow_watchers_disconnected: the number of clients disconnected for not keeping up with their
+  notifications, with the disconnect watch-slow-consumer-policy
+- pubsub_messages_dropped: the number of published messages that could not be sent to a
+  subscriber, for it having no watch connection or its watch connection failing
+	`,
+	Examples: `
+localhost:7379> WATCH.STATS
+OK
+0) subscriptions
+1) 2
+2) throttled_subscriptions
+3) 1
+4) fingerprints
+5) 1
+6) watched_keys
+7) 1
+8) watching_clients
+9) 2
+10) index_memory_bytes
+11) 718
+12) notifications_sent
+13) 120
+14) notifications_coalesced
+15) 4980
+16) notifications_dropped
+17) 0
+18) slow_watchers_disconnected
+19) 0
+20) pubsub_messages_dropped
+21) 0
+	`,
+	IsReadOnly: true,
+	Eval:       evalWATCHSTATS,
+	Execute:    executeWATCHSTATS,
+	GetKeys:    func(c *Cmd) []string { return nil },
+}
+
+func init() {
+	CommandRegistry.AddCommand(cWATCHSTATS)
+}
+
+var (
+	WATCHSTATSResNilRes = newLRANGERes([]string{})
+)
+
+// Note: The statistics are returned by the iothread, which
+// holds the watch subscriptions.
+func evalWATCHSTATS(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 0 {
+		return WATCHSTATSResNilRes, errors.ErrWrongArgumentCount("WATCH.STATS")
+	}
+	return WATCHSTATSResNilRes, nil
+}
+
+func executeWATCHSTATS(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	shard := sm.GetShardForKey("-")
+	return evalWATCHSTATS(c, shard.Thread.Store())
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXACK = &CommandMeta{
+	Name:      "XACK",
+	Syntax:    "XACK key group id [id ...]",
+	HelpShort: "XACK acknowledges the processing of entries delivered to a consumer group",
+	HelpLong: `
+XACK acknowledges the processing of the entries with the IDs, which were delivered to
+a consumer of the consumer group by XREADGROUP. The entries are removed from the pending
+entries list of the group and are not delivered again.
+
+The command returns the number of acknowledged entries, not counting the entries that
+were not pending. It returns 0 if the key or the group does not exist.
+	`,
+	Examples: `
+localhost:7379> XREADGROUP GROUP billing worker-1 STREAMS orders >
+OK
+0) ["orders","1-0","item","book"]
+localhost:7379> XACK orders billing 1-0
+OK 1
+localhost:7379> XACK orders billing 1-0
+OK 0
+	`,
+	Eval:              evalXACK,
+	Execute:           executeXACK,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXACK)
+}
+
+var (
+	XACKResNilRes = newXGROUPRes(0)
+)
+
+func evalXACK(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return XACKResNilRes, errors.ErrWrongArgumentCount("XACK")
+	}
+
+	ids := make([]types.StreamID, 0, len(c.C.Args)-2)
+	for _, v := range c.C.Args[2:] {
+		id, err := types.ParseStreamID(v, 0)
+		if err != nil {
+			return XACKResNilRes, errors.ErrGeneral(err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	obj, g, err := getStreamGroup(s, c.C.Args[0], c.C.Args[1])
+	if err != nil {
+		if err == errors.ErrWrongTypeOperation {
+			return XACKResNilRes, err
+		}
+		return XACKResNilRes, nil
+	}
+
+	acked := 0
+	for _, id := range ids {
+		if g.Ack(id) {
+			acked++
+		}
+	}
+	if acked > 0 {
+		s.BumpVersion(obj)
+	}
+	return newXGROUPRes(int64(acked)), nil
+}
+
+func executeXACK(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return XACKResNilRes, errors.ErrWrongArgumentCount("XACK")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXACK(c, shard.Thread.Store())
+}
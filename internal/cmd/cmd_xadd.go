@@ -0,0 +1,255 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cXADD = &CommandMeta{
+	Name:      "XADD",
+	Syntax:    "XADD key [NOMKSTREAM] [MAXLEN|MINID [=|~] threshold [LIMIT count]] *|id field value [field value ...]",
+	HelpShort: "XADD appends an entry to the stream stored at key",
+	HelpLong: `
+XADD appends an entry made of the fields and values to the stream stored at key.
+The stream is created if the key does not exist, unless NOMKSTREAM is given.
+
+Every entry is identified by an ID made of a time in milliseconds and a sequence
+number, as ms-seq. With *, the ID is generated from the current time. With ms-*,
+the sequence number is generated for the given time. The ID must be greater than
+the ID of every entry ever added to the stream.
+
+The stream can be trimmed once the entry is added
+
+- MAXLEN threshold: removes the oldest entries until the stream holds at most threshold entries
+- MINID threshold: removes the entries whose ID is lower than threshold
+- LIMIT count: removes at most count entries, only allowed along with ~
+
+The entries are always trimmed exactly, ~ is accepted for compatibility.
+
+The command returns the ID of the added entry, or an empty string if the key does
+not exist and NOMKSTREAM is given. Clients blocked on the key by XREAD or XREADGROUP
+are woken up.
+	`,
+	Examples: `
+localhost:7379> XADD orders * item book qty 1
+OK 1718000000000-0
+localhost:7379> XADD orders 1718000000000-* item pen qty 2
+OK 1718000000000-1
+localhost:7379> XADD orders MAXLEN 1 * item ink qty 3
+OK 1718000000001-0
+localhost:7379> XLEN orders
+OK 1
+	`,
+	Eval:              evalXADD,
+	Execute:           executeXADD,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXADD)
+}
+
+func newXADDRes(id string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_GETRes{
+				GETRes: &wire.GETRes{
+					Value: id,
+				},
+			},
+		},
+	}
+}
+
+var (
+	XADDResNilRes = newXADDRes("")
+)
+
+// streamTrim holds the trimming options of XADD.
+type streamTrim struct {
+	maxLen *int
+	minID  *types.StreamID
+	limit  int
+}
+
+func evalXADD(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 4 {
+		return XADDResNilRes, errors.ErrWrongArgumentCount("XADD")
+	}
+	key := c.C.Args[0]
+
+	noMkStream := false
+	var trim streamTrim
+	i := 1
+	for ; i < len(c.C.Args); i++ {
+		switch strings.ToUpper(c.C.Args[i]) {
+		case "NOMKSTREAM":
+			noMkStream = true
+			continue
+		case "MAXLEN", "MINID":
+			n, err := parseStreamTrim(c.C.Args[i:], &trim)
+			if err != nil {
+				return XADDResNilRes, err
+			}
+			i += n - 1
+			continue
+		}
+		break
+	}
+
+	fields := c.C.Args[min(i+1, len(c.C.Args)):]
+	if i >= len(c.C.Args) || len(fields) == 0 || len(fields)%2 != 0 {
+		return XADDResNilRes, errors.ErrWrongArgumentCount("XADD")
+	}
+
+	obj, err := getStream(s, key)
+	if err != nil {
+		return XADDResNilRes, err
+	}
+	if obj == nil && noMkStream {
+		return XADDResNilRes, nil
+	}
+
+	created := obj == nil
+	if created {
+		obj = s.NewObj(types.NewStream(), -1, object.ObjTypeStream)
+	}
+	stream := obj.Value.(*types.Stream)
+
+	id, err := parseXADDID(stream, c.C.Args[i])
+	if err != nil {
+		return XADDResNilRes, err
+	}
+	if err := stream.Add(id, fields); err != nil {
+		return XADDResNilRes, errors.ErrGeneral(err.Error())
+	}
+	trimStream(stream, &trim)
+
+	// The generated ID is written back to the command, so that the entry is
+	// added with the same ID when the command is replayed from the WAL.
+	c.C.Args[i] = id.String()
+
+	if created {
+		s.Put(key, obj)
+	} else {
+		s.BumpVersion(obj)
+	}
+	s.BroadcastKeyReady(key)
+	return newXADDRes(id.String()), nil
+}
+
+func executeXADD(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 4 {
+		return XADDResNilRes, errors.ErrWrongArgumentCount("XADD")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXADD(c, shard.Thread.Store())
+}
+
+// getStream returns the stream stored at the key, or nil if the key does not exist.
+func getStream(s *dstore.Store, key string) (*object.Obj, error) {
+	obj := s.Get(key)
+	if obj == nil {
+		return nil, nil
+	}
+	if obj.Type != object.ObjTypeStream {
+		return nil, errors.ErrWrongTypeOperation
+	}
+	return obj, nil
+}
+
+// parseXADDID parses the ID of the entry added by XADD, generating
+// it if given as * or its sequence number if given as ms-*.
+func parseXADDID(stream *types.Stream, v string) (types.StreamID, error) {
+	if v == "*" {
+		id, ok := stream.NextID(time.Now().UnixMilli())
+		if !ok {
+			return id, errors.ErrGeneral("the stream has exhausted the last possible ID, unable to add more items")
+		}
+		return id, nil
+	}
+
+	if ms, ok := strings.CutSuffix(v, "-*"); ok {
+		id, err := types.ParseStreamID(ms, 0)
+		if err != nil {
+			return id, errors.ErrGeneral(err.Error())
+		}
+		if id.Ms == stream.LastID.Ms {
+			if id, ok = stream.LastID.Next(); !ok || id.Ms != stream.LastID.Ms {
+				return id, errors.ErrGeneral(types.ErrStreamIDTooSmall.Error())
+			}
+		}
+		return id, nil
+	}
+
+	id, err := types.ParseStreamID(v, 0)
+	if err != nil {
+		return id, errors.ErrGeneral(err.Error())
+	}
+	return id, nil
+}
+
+// parseStreamTrim parses the trimming options starting at MAXLEN or MINID
+// and returns the number of arguments they span.
+func parseStreamTrim(args []string, trim *streamTrim) (int, error) {
+	n := 1
+	approx := false
+	if len(args) > n && (args[n] == "=" || args[n] == "~") {
+		approx = args[n] == "~"
+		n++
+	}
+	if len(args) <= n {
+		return 0, errors.ErrInvalidSyntax("XADD")
+	}
+
+	if strings.ToUpper(args[0]) == "MAXLEN" {
+		maxLen, err := strconv.Atoi(args[n])
+		if err != nil || maxLen < 0 {
+			return 0, errors.ErrIntegerOutOfRange
+		}
+		trim.maxLen = &maxLen
+	} else {
+		minID, err := types.ParseStreamID(args[n], 0)
+		if err != nil {
+			return 0, errors.ErrGeneral(err.Error())
+		}
+		trim.minID = &minID
+	}
+	n++
+
+	if len(args) > n+1 && strings.ToUpper(args[n]) == "LIMIT" {
+		if !approx {
+			return 0, errors.ErrGeneral("LIMIT cannot be used without the special ~ option")
+		}
+		limit, err := strconv.Atoi(args[n+1])
+		if err != nil || limit < 0 {
+			return 0, errors.ErrIntegerOutOfRange
+		}
+		trim.limit = limit
+		n += 2
+	}
+	return n, nil
+}
+
+// trimStream removes the entries of the stream as per the trimming options.
+func trimStream(stream *types.Stream, trim *streamTrim) {
+	if trim.maxLen != nil {
+		stream.TrimMaxLen(*trim.maxLen, trim.limit)
+	}
+	if trim.minID != nil {
+		stream.TrimMinID(*trim.minID, trim.limit)
+	}
+}
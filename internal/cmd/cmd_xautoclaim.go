@@ -0,0 +1,136 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXAUTOCLAIM = &CommandMeta{
+	Name:      "XAUTOCLAIM",
+	Syntax:    "XAUTOCLAIM key group consumer min-idle-time start [COUNT count] [JUSTID]",
+	HelpShort: "XAUTOCLAIM transfers the ownership of the idle pending entries of a consumer group to a consumer",
+	HelpLong: `
+XAUTOCLAIM scans the pending entries list of the consumer group from the start ID and
+transfers the ownership of the entries not delivered for at least min-idle-time
+milliseconds to the consumer, as XCLAIM does. The consumer is created if it does not
+exist yet.
+
+At most count pending entries are scanned, 100 by default. Pending entries removed from
+the stream are removed from the pending entries list instead of being claimed.
+
+The command returns the ID to start the next scan from, 0-0 once the whole list is
+scanned, followed by the claimed entries. Every entry is returned as a JSON array
+holding its ID followed by its fields and values, or as its ID with JUSTID, which
+also does not increment the delivery count of the entries.
+	`,
+	Examples: `
+localhost:7379> XAUTOCLAIM orders billing worker-2 60000 0 COUNT 10
+OK
+0) 0-0
+1) ["1-0","item","book"]
+	`,
+	Eval:              evalXAUTOCLAIM,
+	Execute:           executeXAUTOCLAIM,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXAUTOCLAIM)
+}
+
+var (
+	XAUTOCLAIMResNilRes = newXREADRes([]string{})
+)
+
+func evalXAUTOCLAIM(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	args := c.C.Args
+	if len(args) < 5 {
+		return XAUTOCLAIMResNilRes, errors.ErrWrongArgumentCount("XAUTOCLAIM")
+	}
+
+	now := time.Now().UnixMilli()
+	o := &streamClaim{consumer: args[2], deliveryTime: now}
+	var err error
+	if o.minIdle, err = strconv.ParseInt(args[3], 10, 64); err != nil {
+		return XAUTOCLAIMResNilRes, errors.ErrIntegerOutOfRange
+	}
+	start, err := parseStreamRangeID(args[4], true)
+	if err != nil {
+		return XAUTOCLAIMResNilRes, err
+	}
+
+	count := 100
+	for i := 5; i < len(args); i++ {
+		switch opt := strings.ToUpper(args[i]); {
+		case opt == "JUSTID":
+			o.justID = true
+		case opt == "COUNT" && i+1 < len(args):
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count <= 0 {
+				return XAUTOCLAIMResNilRes, errors.ErrIntegerOutOfRange
+			}
+			i++
+		default:
+			return XAUTOCLAIMResNilRes, errors.ErrInvalidSyntax("XAUTOCLAIM")
+		}
+	}
+
+	obj, g, err := getStreamGroup(s, args[0], args[1])
+	if err != nil {
+		return XAUTOCLAIMResNilRes, err
+	}
+	stream := obj.Value.(*types.Stream)
+
+	scanned := g.PendingRange(start, types.MaxStreamID, count+1)
+	cursor := types.StreamID{}
+	if len(scanned) > count {
+		cursor = scanned[count].ID
+		scanned = scanned[:count]
+	}
+
+	res := []string{cursor.String()}
+	var processed []string
+	for _, pe := range scanned {
+		entry, ok := o.claim(stream, g, pe.ID, now)
+		if !ok {
+			continue
+		}
+		processed = append(processed, pe.ID.String())
+		if entry != "" {
+			res = append(res, entry)
+		}
+	}
+	s.BumpVersion(obj)
+
+	// The entries claimed depend on the time the command is executed at, hence
+	// the command is written to the WAL as the XCLAIM of the processed IDs at
+	// the same delivery time.
+	if len(processed) == 0 {
+		c.C.Args[3] = neverIdle
+	} else {
+		c.C.Cmd = "XCLAIM"
+		c.C.Args = append(append([]string{args[0], args[1], args[2], "0"}, processed...),
+			"TIME", strconv.FormatInt(o.deliveryTime, 10))
+		if o.justID {
+			c.C.Args = append(c.C.Args, "JUSTID")
+		}
+	}
+
+	return newXREADRes(res), nil
+}
+
+func executeXAUTOCLAIM(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 5 {
+		return XAUTOCLAIMResNilRes, errors.ErrWrongArgumentCount("XAUTOCLAIM")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXAUTOCLAIM(c, shard.Thread.Store())
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXCLAIM = &CommandMeta{
+	Name: "XCLAIM",
+	Syntax: "XCLAIM key group consumer min-idle-time id [id ...] [IDLE ms] [TIME unix-time-milliseconds] " +
+		"[RETRYCOUNT count] [JUSTID] [LASTID lastid]",
+	HelpShort: "XCLAIM transfers the ownership of pending entries of a consumer group to a consumer",
+	HelpLong: `
+XCLAIM transfers the ownership of the pending entries with the IDs to the consumer, if
+they were not delivered for at least min-idle-time milliseconds. It is used to process
+the entries of a consumer that failed. The consumer is created if it does not exist yet.
+
+The claimed entries are considered delivered to the consumer now, and their delivery
+count is incremented. Pending entries removed from the stream are removed from the
+pending entries list instead of being claimed.
+
+- IDLE ms: sets the time the entries were delivered at to ms milliseconds ago
+- TIME unix-time-milliseconds: sets the time the entries were delivered at
+- RETRYCOUNT count: sets the delivery count of the entries
+- JUSTID: returns only the IDs of the claimed entries, and does not increment their delivery count
+- LASTID lastid: sets the ID of the last entry delivered to the group, if greater than the current one
+
+Every claimed entry is returned as a JSON array holding its ID followed by its fields and
+values, or as its ID with JUSTID.
+	`,
+	Examples: `
+localhost:7379> XCLAIM orders billing worker-2 60000 1-0 2-0
+OK
+0) ["1-0","item","book"]
+localhost:7379> XCLAIM orders billing worker-2 0 2-0 JUSTID
+OK
+0) 2-0
+	`,
+	Eval:              evalXCLAIM,
+	Execute:           executeXCLAIM,
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXCLAIM)
+}
+
+var (
+	XCLAIMResNilRes = newXREADRes([]string{})
+)
+
+// streamClaim holds the options of XCLAIM and XAUTOCLAIM.
+type streamClaim struct {
+	consumer string
+	minIdle  int64
+	// deliveryTime is the time the claimed entries are delivered at.
+	deliveryTime int64
+	retryCount   *int64
+	justID       bool
+}
+
+// claim transfers the ownership of the pending entry to the consumer. It
+// returns the encoded entry, or its ID with JUSTID, and whether the pending
+// entry was claimed or removed, as it was removed from the stream.
+func (o *streamClaim) claim(stream *types.Stream, g *types.StreamGroup, id types.StreamID, now int64) (string, bool) {
+	pe, processed := g.Claim(stream, id, o.consumer, o.minIdle, now)
+	if pe == nil {
+		return "", processed
+	}
+
+	pe.DeliveryTime = o.deliveryTime
+	switch {
+	case o.retryCount != nil:
+		pe.DeliveryCount = *o.retryCount
+	case !o.justID:
+		pe.DeliveryCount++
+	}
+	if o.justID {
+		return id.String(), true
+	}
+	return encodeStreamEntry(stream.Get(id)), true
+}
+
+// neverIdle is the min-idle-time that no pending entry reaches. It is written
+// to the WAL in place of the min-idle-time of the claiming commands that did
+// not claim anything, so that they do not claim anything when replayed.
+var neverIdle = strconv.FormatInt(math.MaxInt64, 10)
+
+func evalXCLAIM(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	args := c.C.Args
+	if len(args) < 5 {
+		return XCLAIMResNilRes, errors.ErrWrongArgumentCount("XCLAIM")
+	}
+
+	now := time.Now().UnixMilli()
+	o := &streamClaim{consumer: args[2], deliveryTime: now}
+	var err error
+	if o.minIdle, err = strconv.ParseInt(args[3], 10, 64); err != nil {
+		return XCLAIMResNilRes, errors.ErrIntegerOutOfRange
+	}
+
+	i := 4
+	var ids []types.StreamID
+	for ; i < len(args); i++ {
+		id, err := types.ParseStreamID(args[i], 0)
+		if err != nil {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return XCLAIMResNilRes, errors.ErrGeneral(types.ErrInvalidStreamID.Error())
+	}
+
+	var lastID *types.StreamID
+	for ; i < len(args); i++ {
+		opt := strings.ToUpper(args[i])
+		switch {
+		case opt == "JUSTID":
+			o.justID = true
+			continue
+		case i+1 >= len(args):
+			return XCLAIMResNilRes, errors.ErrInvalidSyntax("XCLAIM")
+		case opt == "LASTID":
+			id, err := types.ParseStreamID(args[i+1], 0)
+			if err != nil {
+				return XCLAIMResNilRes, errors.ErrGeneral(err.Error())
+			}
+			lastID = &id
+		case opt == "IDLE", opt == "TIME", opt == "RETRYCOUNT":
+			v, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return XCLAIMResNilRes, errors.ErrIntegerOutOfRange
+			}
+			switch opt {
+			case "IDLE":
+				o.deliveryTime = now - v
+			case "TIME":
+				o.deliveryTime = v
+			default:
+				o.retryCount = &v
+			}
+		default:
+			return XCLAIMResNilRes, errors.ErrInvalidSyntax("XCLAIM")
+		}
+		i++
+	}
+
+	obj, g, err := getStreamGroup(s, args[0], args[1])
+	if err != nil {
+		return XCLAIMResNilRes, err
+	}
+	stream := obj.Value.(*types.Stream)
+	if lastID != nil && lastID.Compare(g.LastID) > 0 {
+		g.LastID = *lastID
+	}
+
+	res := []string{}
+	var processed []string
+	for _, id := range ids {
+		entry, ok := o.claim(stream, g, id, now)
+		if !ok {
+			continue
+		}
+		processed = append(processed, id.String())
+		if entry != "" {
+			res = append(res, entry)
+		}
+	}
+	s.BumpVersion(obj)
+
+	// The entries claimed depend on the time the command is executed at, hence
+	// the command is written to the WAL as claiming exactly the processed IDs
+	// at the same delivery time.
+	if len(processed) == 0 {
+		c.C.Args[3] = neverIdle
+	} else {
+		rewritten := append(append([]string{}, args[:3]...), "0")
+		rewritten = append(rewritten, processed...)
+		rewritten = append(rewritten, args[4+len(ids):]...)
+		c.C.Args = append(rewritten, "TIME", strconv.FormatInt(o.deliveryTime, 10))
+	}
+
+	return newXREADRes(res), nil
+}
+
+func executeXCLAIM(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 5 {
+		return XCLAIMResNilRes, errors.ErrWrongArgumentCount("XCLAIM")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXCLAIM(c, shard.Thread.Store())
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cXGROUP = &CommandMeta{
+	Name: "XGROUP",
+	Syntax: "XGROUP CREATE key group id|$ [MKSTREAM] | XGROUP SETID key group id|$ | " +
+		"XGROUP DESTROY key group | XGROUP CREATECONSUMER key group consumer | " +
+		"XGROUP DELCONSUMER key group consumer",
+	HelpShort: "XGROUP manages the consumer groups of the stream stored at key",
+	HelpLong: `
+XGROUP manages the consumer groups of the stream stored at key.
+
+- CREATE key group id|$ [MKSTREAM]: creates the consumer group, which delivers the
+  entries whose ID is greater than id, or the entries added from now on with $.
+  The stream is created if it does not exist and MKSTREAM is given.
+- SETID key group id|$: sets the ID of the last entry delivered to the group.
+- DESTROY key group: destroys the consumer group along with its pending entries,
+  returns 1 if the group existed and 0 otherwise.
+- CREATECONSUMER key group consumer: creates the consumer in the group, returns 1
+  if the consumer was created and 0 if it already existed.
+- DELCONSUMER key group consumer: deletes the consumer along with its pending
+  entries, returns the number of pending entries it had.
+	`,
+	Examples: `
+localhost:7379> XGROUP CREATE orders billing $ MKSTREAM
+OK
+localhost:7379> XGROUP CREATECONSUMER orders billing worker-1
+OK 1
+localhost:7379> XGROUP DELCONSUMER orders billing worker-1
+OK 0
+localhost:7379> XGROUP DESTROY orders billing
+OK 1
+	`,
+	Eval:              evalXGROUP,
+	Execute:           executeXGROUP,
+	KeySpec:           KeySpec{First: 1, Last: 1, Step: 1},
+	LockKeysExclusive: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXGROUP)
+}
+
+func newXGROUPRes(n int64) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_INCRRes{
+				INCRRes: &wire.INCRRes{
+					Value: n,
+				},
+			},
+		},
+	}
+}
+
+var (
+	XGROUPResNilRes = newXGROUPRes(0)
+	XGROUPResOKRes  = &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+		},
+	}
+)
+
+var errStreamKeyRequired = errors.ErrGeneral(
+	"the XGROUP subcommand requires the key to exist, use MKSTREAM to create the stream")
+
+func evalXGROUP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+	}
+
+	sub, key, group := strings.ToUpper(c.C.Args[0]), c.C.Args[1], c.C.Args[2]
+	switch sub {
+	case "CREATE":
+		if len(c.C.Args) != 4 && len(c.C.Args) != 5 {
+			return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+		}
+		mkStream := len(c.C.Args) == 5
+		if mkStream && strings.ToUpper(c.C.Args[4]) != "MKSTREAM" {
+			return XGROUPResNilRes, errors.ErrInvalidSyntax("XGROUP")
+		}
+		return createStreamGroup(s, key, group, c.C.Args[3], mkStream)
+	case "SETID":
+		if len(c.C.Args) != 4 {
+			return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+		}
+		obj, g, err := getStreamGroup(s, key, group)
+		if err != nil {
+			return XGROUPResNilRes, err
+		}
+		if g.LastID, err = parseStreamGroupID(obj.Value.(*types.Stream), c.C.Args[3]); err != nil {
+			return XGROUPResNilRes, err
+		}
+		s.BumpVersion(obj)
+		return XGROUPResOKRes, nil
+	case "DESTROY":
+		if len(c.C.Args) != 3 {
+			return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+		}
+		obj, err := getStream(s, key)
+		if err != nil {
+			return XGROUPResNilRes, err
+		}
+		if obj == nil {
+			return XGROUPResNilRes, errStreamKeyRequired
+		}
+		if !obj.Value.(*types.Stream).DestroyGroup(group) {
+			return XGROUPResNilRes, nil
+		}
+		s.BumpVersion(obj)
+		// The consumers blocked on the group are woken up to fail.
+		s.BroadcastKeyReady(key)
+		return newXGROUPRes(1), nil
+	case "CREATECONSUMER", "DELCONSUMER":
+		if len(c.C.Args) != 4 {
+			return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+		}
+		obj, g, err := getStreamGroup(s, key, group)
+		if err != nil {
+			return XGROUPResNilRes, err
+		}
+		s.BumpVersion(obj)
+		if sub == "DELCONSUMER" {
+			return newXGROUPRes(int64(g.DeleteConsumer(c.C.Args[3]))), nil
+		}
+		if _, created := g.CreateConsumer(c.C.Args[3], time.Now().UnixMilli()); created {
+			return newXGROUPRes(1), nil
+		}
+		return XGROUPResNilRes, nil
+	}
+	return XGROUPResNilRes, errors.ErrInvalidSyntax("XGROUP")
+}
+
+func executeXGROUP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 3 {
+		return XGROUPResNilRes, errors.ErrWrongArgumentCount("XGROUP")
+	}
+	shard := sm.GetShardForKey(c.C.Args[1])
+	return evalXGROUP(c, shard.Thread.Store())
+}
+
+// createStreamGroup creates the consumer group of XGROUP CREATE.
+func createStreamGroup(s *dstore.Store, key, group, id string, mkStream bool) (*CmdRes, error) {
+	obj, err := getStream(s, key)
+	if err != nil {
+		return XGROUPResNilRes, err
+	}
+	if obj == nil && !mkStream {
+		return XGROUPResNilRes, errStreamKeyRequired
+	}
+
+	created := obj == nil
+	if created {
+		obj = s.NewObj(types.NewStream(), -1, object.ObjTypeStream)
+	}
+	stream := obj.Value.(*types.Stream)
+
+	lastID, err := parseStreamGroupID(stream, id)
+	if err != nil {
+		return XGROUPResNilRes, err
+	}
+	if _, ok := stream.CreateGroup(group, lastID); !ok {
+		return XGROUPResNilRes, errors.ErrGeneral("consumer group name already exists")
+	}
+
+	if created {
+		s.Put(key, obj)
+	} else {
+		s.BumpVersion(obj)
+	}
+	return XGROUPResOKRes, nil
+}
+
+// parseStreamGroupID parses the ID of the last entry delivered to a
+// consumer group, $ standing for the last ID of the stream.
+func parseStreamGroupID(stream *types.Stream, v string) (types.StreamID, error) {
+	if v == "$" {
+		return stream.LastID, nil
+	}
+	id, err := types.ParseStreamID(v, 0)
+	if err != nil {
+		return id, errors.ErrGeneral(err.Error())
+	}
+	return id, nil
+}
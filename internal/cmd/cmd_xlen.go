@@ -0,0 +1,61 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXLEN = &CommandMeta{
+	Name:      "XLEN",
+	Syntax:    "XLEN key",
+	HelpShort: "XLEN returns the number of entries of the stream stored at key",
+	HelpLong: `
+XLEN returns the number of entries of the stream stored at key.
+
+The command returns 0 if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> XADD orders * item book
+OK 1718000000000-0
+localhost:7379> XLEN orders
+OK 1
+localhost:7379> XLEN kn
+OK 0
+	`,
+	IsReadOnly: true,
+	Eval:       evalXLEN,
+	Execute:    executeXLEN,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXLEN)
+}
+
+var (
+	XLENResNilRes = newLPUSHRes(0)
+)
+
+func evalXLEN(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return XLENResNilRes, errors.ErrWrongArgumentCount("XLEN")
+	}
+
+	obj, err := getStream(s, c.C.Args[0])
+	if err != nil || obj == nil {
+		return XLENResNilRes, err
+	}
+	return newLPUSHRes(int64(obj.Value.(*types.Stream).Len())), nil
+}
+
+func executeXLEN(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 1 {
+		return XLENResNilRes, errors.ErrWrongArgumentCount("XLEN")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXLEN(c, shard.Thread.Store())
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXPENDING = &CommandMeta{
+	Name:      "XPENDING",
+	Syntax:    "XPENDING key group [[IDLE min-idle-time] start end count [consumer]]",
+	HelpShort: "XPENDING returns the entries delivered to a consumer group and not acknowledged yet",
+	HelpLong: `
+XPENDING returns the pending entries list of the consumer group, which holds the entries
+delivered to its consumers and not acknowledged yet.
+
+Without a range, the command returns a summary of the pending entries: their number, the
+lowest and the greatest pending IDs, and the number of pending entries of every consumer
+having some, as consumer and count pairs. Only the number is returned if there are no
+pending entries.
+
+With a range, the command returns at most count pending entries whose ID is between start
+and end, given as for XRANGE. Every entry is returned as a JSON array holding its ID, its
+consumer, the number of milliseconds since it was last delivered and the number of times
+it was delivered.
+
+- IDLE min-idle-time: returns only the entries not delivered for at least min-idle-time milliseconds
+- consumer: returns only the entries pending for the consumer
+	`,
+	Examples: `
+localhost:7379> XPENDING orders billing
+OK
+0) 2
+1) 1-0
+2) 2-0
+3) worker-1
+4) 2
+localhost:7379> XPENDING orders billing - + 10 worker-1
+OK
+0) ["1-0","worker-1","15000","1"]
+1) ["2-0","worker-1","15000","1"]
+	`,
+	IsReadOnly: true,
+	Eval:       evalXPENDING,
+	Execute:    executeXPENDING,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXPENDING)
+}
+
+var (
+	XPENDINGResNilRes = newXREADRes([]string{})
+)
+
+func evalXPENDING(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	args := c.C.Args
+	if len(args) < 2 {
+		return XPENDINGResNilRes, errors.ErrWrongArgumentCount("XPENDING")
+	}
+
+	var minIdle int64
+	extended := args[2:]
+	if len(extended) > 0 && strings.ToUpper(extended[0]) == "IDLE" {
+		if len(extended) < 2 {
+			return XPENDINGResNilRes, errors.ErrInvalidSyntax("XPENDING")
+		}
+		var err error
+		if minIdle, err = strconv.ParseInt(extended[1], 10, 64); err != nil {
+			return XPENDINGResNilRes, errors.ErrIntegerOutOfRange
+		}
+		extended = extended[2:]
+		if len(extended) == 0 {
+			return XPENDINGResNilRes, errors.ErrInvalidSyntax("XPENDING")
+		}
+	}
+	if len(extended) != 0 && len(extended) != 3 && len(extended) != 4 {
+		return XPENDINGResNilRes, errors.ErrInvalidSyntax("XPENDING")
+	}
+
+	var start, end types.StreamID
+	var count int
+	if len(extended) > 0 {
+		var err error
+		if start, err = parseStreamRangeID(extended[0], true); err != nil {
+			return XPENDINGResNilRes, err
+		}
+		if end, err = parseStreamRangeID(extended[1], false); err != nil {
+			return XPENDINGResNilRes, err
+		}
+		if count, err = strconv.Atoi(extended[2]); err != nil {
+			return XPENDINGResNilRes, errors.ErrIntegerOutOfRange
+		}
+	}
+
+	_, g, err := getStreamGroup(s, args[0], args[1])
+	if err != nil {
+		return XPENDINGResNilRes, err
+	}
+
+	if len(extended) == 0 {
+		return newXREADRes(pendingSummary(g)), nil
+	}
+	if count <= 0 {
+		return XPENDINGResNilRes, nil
+	}
+
+	consumer := ""
+	if len(extended) == 4 {
+		consumer = extended[3]
+	}
+	now := time.Now().UnixMilli()
+	res := []string{}
+	for _, pe := range g.PendingRange(start, end, 0) {
+		idle := now - pe.DeliveryTime
+		if (consumer != "" && pe.Consumer != consumer) || idle < minIdle {
+			continue
+		}
+		b, _ := json.Marshal([]string{pe.ID.String(), pe.Consumer,
+			strconv.FormatInt(idle, 10), strconv.FormatInt(pe.DeliveryCount, 10)})
+		res = append(res, string(b))
+		if len(res) == count {
+			break
+		}
+	}
+	return newXREADRes(res), nil
+}
+
+func executeXPENDING(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) < 2 {
+		return XPENDINGResNilRes, errors.ErrWrongArgumentCount("XPENDING")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXPENDING(c, shard.Thread.Store())
+}
+
+// pendingSummary returns the number of pending entries of the group, the lowest
+// and greatest pending IDs and the number of pending entries of every consumer.
+func pendingSummary(g *types.StreamGroup) []string {
+	pending := g.PendingRange(types.StreamID{}, types.MaxStreamID, 0)
+	res := []string{strconv.Itoa(len(pending))}
+	if len(pending) == 0 {
+		return res
+	}
+	res = append(res, pending[0].ID.String(), pending[len(pending)-1].ID.String())
+
+	counts := map[string]int{}
+	for _, pe := range pending {
+		counts[pe.Consumer]++
+	}
+	for _, consumer := range g.Consumers() {
+		if n := counts[consumer.Name]; n > 0 {
+			res = append(res, consumer.Name, strconv.Itoa(n))
+		}
+	}
+	return res
+}
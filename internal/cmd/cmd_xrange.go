@@ -0,0 +1,179 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cXRANGE = &CommandMeta{
+	Name:      "XRANGE",
+	Syntax:    "XRANGE key start end [COUNT count]",
+	HelpShort: "XRANGE returns the entries of the stream stored at key within a range of IDs",
+	HelpLong: `
+XRANGE returns the entries of the stream stored at key whose ID is between start and
+end, both inclusive, from the oldest to the newest.
+
+- and + stand for the lowest and the greatest possible IDs. An ID given without its
+sequence number, as ms, stands for ms-0 as start and for the last ID of the millisecond
+as end. Prefixing an ID with ( excludes it from the range.
+
+With COUNT, at most count entries are returned.
+
+Every entry is returned as a JSON array holding its ID followed by its fields and values.
+The command returns an empty list if the key does not exist.
+	`,
+	Examples: `
+localhost:7379> XADD orders 1-0 item book
+OK 1-0
+localhost:7379> XADD orders 2-0 item pen
+OK 2-0
+localhost:7379> XRANGE orders - +
+OK
+0) ["1-0","item","book"]
+1) ["2-0","item","pen"]
+localhost:7379> XRANGE orders (1-0 + COUNT 1
+OK
+0) ["2-0","item","pen"]
+	`,
+	IsReadOnly: true,
+	Eval:       evalXRANGE,
+	Execute:    executeXRANGE,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXRANGE)
+}
+
+// encodeStreamEntry encodes the entry as a JSON array holding
+// the prefix, the ID of the entry and its fields and values.
+func encodeStreamEntry(e *types.StreamEntry, prefix ...string) string {
+	values := make([]string, 0, len(prefix)+1+len(e.Fields))
+	values = append(values, prefix...)
+	values = append(values, e.ID.String())
+	values = append(values, e.Fields...)
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+func newXRANGERes(entries []*types.StreamEntry) *CmdRes {
+	encoded := make([]string, len(entries))
+	for i, e := range entries {
+		encoded[i] = encodeStreamEntry(e)
+	}
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: encoded,
+				},
+			},
+		},
+	}
+}
+
+var (
+	XRANGEResNilRes = newXRANGERes(nil)
+)
+
+func evalXRANGE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return streamRange(c, s, "XRANGE", false)
+}
+
+func executeXRANGE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 3 && len(c.C.Args) != 5 {
+		return XRANGEResNilRes, errors.ErrWrongArgumentCount("XRANGE")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXRANGE(c, shard.Thread.Store())
+}
+
+// streamRange returns the entries of XRANGE, or of XREVRANGE if
+// reverse is true, which takes the end of the range first.
+func streamRange(c *Cmd, s *dstore.Store, command string, reverse bool) (*CmdRes, error) {
+	if len(c.C.Args) != 3 && len(c.C.Args) != 5 {
+		return XRANGEResNilRes, errors.ErrWrongArgumentCount(command)
+	}
+
+	startArg, endArg := c.C.Args[1], c.C.Args[2]
+	if reverse {
+		startArg, endArg = endArg, startArg
+	}
+	start, err := parseStreamRangeID(startArg, true)
+	if err != nil {
+		return XRANGEResNilRes, err
+	}
+	end, err := parseStreamRangeID(endArg, false)
+	if err != nil {
+		return XRANGEResNilRes, err
+	}
+
+	count := 0
+	if len(c.C.Args) == 5 {
+		if strings.ToUpper(c.C.Args[3]) != "COUNT" {
+			return XRANGEResNilRes, errors.ErrInvalidSyntax(command)
+		}
+		if count, err = strconv.Atoi(c.C.Args[4]); err != nil {
+			return XRANGEResNilRes, errors.ErrIntegerOutOfRange
+		}
+		if count <= 0 {
+			return XRANGEResNilRes, nil
+		}
+	}
+
+	obj, err := getStream(s, c.C.Args[0])
+	if err != nil || obj == nil {
+		return XRANGEResNilRes, err
+	}
+	return newXRANGERes(obj.Value.(*types.Stream).Range(start, end, count, reverse)), nil
+}
+
+// parseStreamRangeID parses the start, if start is true, or the end of a range
+// of IDs. - and + stand for the lowest and greatest IDs, and an ID prefixed
+// with ( is excluded from the range.
+func parseStreamRangeID(v string, start bool) (types.StreamID, error) {
+	switch v {
+	case "-":
+		return types.StreamID{}, nil
+	case "+":
+		return types.MaxStreamID, nil
+	}
+
+	exclusive := strings.HasPrefix(v, "(")
+	v = strings.TrimPrefix(v, "(")
+
+	var missingSeq uint64
+	if !start {
+		missingSeq = math.MaxUint64
+	}
+	id, err := types.ParseStreamID(v, missingSeq)
+	if err != nil {
+		return id, errors.ErrGeneral(err.Error())
+	}
+	if !exclusive {
+		return id, nil
+	}
+
+	var ok bool
+	if start {
+		id, ok = id.Next()
+	} else {
+		id, ok = id.Prev()
+	}
+	if !ok {
+		return id, errors.ErrGeneral("invalid ID for the interval")
+	}
+	return id, nil
+}
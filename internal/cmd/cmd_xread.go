@@ -0,0 +1,211 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+var cXREAD = &CommandMeta{
+	Name:      "XREAD",
+	Syntax:    "XREAD [COUNT count] [BLOCK milliseconds] STREAMS key [key ...] id [id ...]",
+	HelpShort: "XREAD returns the entries added to streams after the given IDs, optionally waiting for them",
+	HelpLong: `
+XREAD returns the entries of the streams stored at the keys whose ID is greater than
+the ID given for the stream, from the oldest to the newest. The IDs are given after all
+the keys, in the same order. With $ as ID, only the entries added after the command is
+received are returned.
+
+- COUNT count: returns at most count entries per stream
+- BLOCK milliseconds: if none of the streams has entries to return, waits until an
+  entry is added to one of them or the timeout expires. A timeout of 0 waits forever.
+
+Every entry is returned as a JSON array holding the key of its stream, its ID and
+its fields and values. The command returns an empty list if there are no entries,
+or if the timeout expires. When run in a transaction or a script, the command does
+not wait.
+	`,
+	Examples: `
+localhost:7379> XADD orders 1-0 item book
+OK 1-0
+localhost:7379> XREAD COUNT 10 STREAMS orders 0
+OK
+0) ["orders","1-0","item","book"]
+localhost:7379> XREAD BLOCK 100 STREAMS orders $
+OK
+	`,
+	IsReadOnly: true,
+	Eval:       evalXREAD,
+	Execute:    executeXREAD,
+	GetKeys:    getKeysStreamRead,
+	IsBlocking: true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXREAD)
+}
+
+func newXREADRes(entries []string) *CmdRes {
+	return &CmdRes{
+		Rs: &wire.Result{
+			Message: "OK",
+			Status:  wire.Status_OK,
+			Response: &wire.Result_KEYSRes{
+				KEYSRes: &wire.KEYSRes{
+					Keys: entries,
+				},
+			},
+		},
+	}
+}
+
+var (
+	XREADResNilRes = newXREADRes([]string{})
+)
+
+// streamRead holds the arguments of XREAD and XREADGROUP.
+type streamRead struct {
+	count int
+	// block is the timeout of the command, nil if it does not wait.
+	block    *time.Duration
+	noAck    bool
+	group    string
+	consumer string
+	keys     []string
+	// idsAt is the index of the first ID in the arguments of the command.
+	idsAt int
+}
+
+// parseStreamRead parses the arguments of XREAD, or of XREADGROUP if group is true.
+func parseStreamRead(args []string, command string, group bool) (*streamRead, error) {
+	r := &streamRead{}
+	i := 0
+	for ; i < len(args); i++ {
+		opt := strings.ToUpper(args[i])
+		if opt == "STREAMS" {
+			break
+		}
+
+		switch {
+		case opt == "COUNT" && i+1 < len(args):
+			count, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return nil, errors.ErrIntegerOutOfRange
+			}
+			r.count = max(count, 0)
+			i++
+		case opt == "BLOCK" && i+1 < len(args):
+			ms, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || ms > int64(time.Duration(1<<63-1)/time.Millisecond) {
+				return nil, errors.ErrGeneral("timeout is not an integer or out of range")
+			}
+			if ms < 0 {
+				return nil, errors.ErrGeneral("timeout is negative")
+			}
+			block := time.Duration(ms) * time.Millisecond
+			r.block = &block
+			i++
+		case opt == "NOACK" && group:
+			r.noAck = true
+		case opt == "GROUP" && group && i+2 < len(args):
+			r.group, r.consumer = args[i+1], args[i+2]
+			i += 2
+		default:
+			return nil, errors.ErrInvalidSyntax(command)
+		}
+	}
+
+	if group && r.group == "" {
+		return nil, errors.ErrGeneral("missing GROUP option for " + command)
+	}
+	streams := args[min(i+1, len(args)):]
+	if i >= len(args) || len(streams) == 0 {
+		return nil, errors.ErrWrongArgumentCount(command)
+	}
+	if len(streams)%2 != 0 {
+		return nil, errors.ErrGeneral("unbalanced list of streams for '" + command +
+			"' command, an ID must be given for each key")
+	}
+	r.keys = streams[:len(streams)/2]
+	r.idsAt = len(args) - len(r.keys)
+	return r, nil
+}
+
+func getKeysStreamRead(c *Cmd) []string {
+	r, err := parseStreamRead(c.C.Args, c.C.Cmd, c.C.Cmd == "XREADGROUP")
+	if err != nil {
+		return nil
+	}
+	return r.keys
+}
+
+func evalXREAD(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	r, err := parseStreamRead(c.C.Args, "XREAD", false)
+	if err != nil {
+		return XREADResNilRes, err
+	}
+	return readStreams(c, r, func(string) *dstore.Store { return s })
+}
+
+func executeXREAD(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	r, err := parseStreamRead(c.C.Args, "XREAD", false)
+	if err != nil {
+		return XREADResNilRes, err
+	}
+	res, err := readStreams(c, r, func(key string) *dstore.Store {
+		return sm.GetShardForKey(key).Thread.Store()
+	})
+	if err != nil || len(res.Rs.GetKEYSRes().Keys) > 0 || r.block == nil {
+		return res, err
+	}
+	return c.block(sm, r.keys, *r.block, XREADResNilRes)
+}
+
+// readStreams returns the entries of XREAD. The IDs given as $ are replaced
+// in the arguments by the last ID of their stream, so that the command reads
+// the entries added after it was first executed when executed again.
+func readStreams(c *Cmd, r *streamRead, storeForKey func(key string) *dstore.Store) (*CmdRes, error) {
+	var res []string
+	for i, key := range r.keys {
+		obj, err := getStream(storeForKey(key), key)
+		if c.isBlockingErr(err) {
+			return XREADResNilRes, err
+		}
+
+		var stream *types.Stream
+		if obj != nil {
+			stream = obj.Value.(*types.Stream)
+		}
+		if c.C.Args[r.idsAt+i] == "$" {
+			var lastID types.StreamID
+			if stream != nil {
+				lastID = stream.LastID
+			}
+			c.C.Args[r.idsAt+i] = lastID.String()
+		}
+
+		id, err := types.ParseStreamID(c.C.Args[r.idsAt+i], 0)
+		if err != nil {
+			return XREADResNilRes, errors.ErrGeneral(err.Error())
+		}
+		if stream == nil {
+			continue
+		}
+		for _, e := range stream.After(id, r.count) {
+			res = append(res, encodeStreamEntry(e, key))
+		}
+	}
+	if len(res) == 0 {
+		return XREADResNilRes, nil
+	}
+	return newXREADRes(res), nil
+}
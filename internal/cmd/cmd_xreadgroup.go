@@ -0,0 +1,163 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+var cXREADGROUP = &CommandMeta{
+	Name: "XREADGROUP",
+	Syntax: "XREADGROUP GROUP group consumer [COUNT count] [BLOCK milliseconds] [NOACK] " +
+		"STREAMS key [key ...] id [id ...]",
+	HelpShort: "XREADGROUP reads the entries of streams as a consumer of a consumer group",
+	HelpLong: `
+XREADGROUP reads the entries of the streams stored at the keys as the consumer of the
+consumer group, which must exist for every stream. The consumer is created if it does
+not exist yet. The IDs are given after all the keys, in the same order.
+
+With > as ID, the entries never delivered to any consumer of the group are delivered
+to the consumer. The delivered entries are added to the pending entries list of the
+group, until they are acknowledged with XACK, unless NOACK is given.
+
+With any other ID, the entries pending for the consumer whose ID is greater than the
+given ID are delivered again. Entries removed from the stream since they were delivered
+are returned without fields.
+
+- COUNT count: returns at most count entries per stream
+- BLOCK milliseconds: if all the IDs are > and none of the streams has new entries,
+  waits until an entry is added to one of them or the timeout expires. A timeout
+  of 0 waits forever.
+
+Every entry is returned as a JSON array holding the key of its stream, its ID and
+its fields and values. The command returns an empty list if there are no entries,
+or if the timeout expires. When run in a transaction or a script, the command does
+not wait.
+	`,
+	Examples: `
+localhost:7379> XADD orders 1-0 item book
+OK 1-0
+localhost:7379> XGROUP CREATE orders billing 0
+OK
+localhost:7379> XREADGROUP GROUP billing worker-1 COUNT 10 STREAMS orders >
+OK
+0) ["orders","1-0","item","book"]
+localhost:7379> XREADGROUP GROUP billing worker-1 STREAMS orders 0
+OK
+0) ["orders","1-0","item","book"]
+	`,
+	Eval:              evalXREADGROUP,
+	Execute:           executeXREADGROUP,
+	GetKeys:           getKeysStreamRead,
+	LockKeysExclusive: true,
+	IsBlocking:        true,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXREADGROUP)
+}
+
+var (
+	XREADGROUPResNilRes = newXREADRes([]string{})
+)
+
+func evalXREADGROUP(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	r, err := parseStreamRead(c.C.Args, "XREADGROUP", true)
+	if err != nil {
+		return XREADGROUPResNilRes, err
+	}
+	return readStreamsGroup(c, r, func(string) *dstore.Store { return s })
+}
+
+func executeXREADGROUP(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	r, err := parseStreamRead(c.C.Args, "XREADGROUP", true)
+	if err != nil {
+		return XREADGROUPResNilRes, err
+	}
+	res, err := readStreamsGroup(c, r, func(key string) *dstore.Store {
+		return sm.GetShardForKey(key).Thread.Store()
+	})
+	if err != nil || len(res.Rs.GetKEYSRes().Keys) > 0 || r.block == nil {
+		return res, err
+	}
+	for _, id := range c.C.Args[r.idsAt:] {
+		if id != ">" {
+			return res, nil
+		}
+	}
+	return c.block(sm, r.keys, *r.block, XREADGROUPResNilRes)
+}
+
+// getStreamGroup returns the stream stored at the key and its consumer group,
+// failing if any of them does not exist.
+func getStreamGroup(s *dstore.Store, key, group string) (*object.Obj, *types.StreamGroup, error) {
+	obj, err := getStream(s, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if obj != nil {
+		if g := obj.Value.(*types.Stream).Group(group); g != nil {
+			return obj, g, nil
+		}
+	}
+	return nil, nil, errors.ErrGeneral(fmt.Sprintf("no such key '%s' or consumer group '%s'", key, group))
+}
+
+// readStreamsGroup delivers the entries of XREADGROUP to the consumer.
+func readStreamsGroup(c *Cmd, r *streamRead, storeForKey func(key string) *dstore.Store) (*CmdRes, error) {
+	ids := make([]*types.StreamID, len(r.keys))
+	for i, v := range c.C.Args[r.idsAt:] {
+		if v == ">" {
+			continue
+		}
+		id, err := types.ParseStreamID(v, 0)
+		if err != nil {
+			return XREADGROUPResNilRes, errors.ErrGeneral(err.Error())
+		}
+		ids[i] = &id
+	}
+
+	// The groups of all the streams are looked up before any entry is
+	// delivered, so that a missing group does not fail the command half way.
+	objs := make([]*object.Obj, len(r.keys))
+	groups := make([]*types.StreamGroup, len(r.keys))
+	for i, key := range r.keys {
+		var err error
+		objs[i], groups[i], err = getStreamGroup(storeForKey(key), key, r.group)
+		if c.isBlockingErr(err) {
+			return XREADGROUPResNilRes, err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	var res []string
+	for i, key := range r.keys {
+		if objs[i] == nil {
+			continue
+		}
+
+		stream := objs[i].Value.(*types.Stream)
+		var entries []*types.StreamEntry
+		if ids[i] == nil {
+			entries = groups[i].ReadNew(stream, r.consumer, r.count, r.noAck, now)
+		} else {
+			entries = groups[i].ReadPending(stream, r.consumer, *ids[i], r.count, now)
+		}
+		storeForKey(key).BumpVersion(objs[i])
+		for _, e := range entries {
+			res = append(res, encodeStreamEntry(e, key))
+		}
+	}
+	if len(res) == 0 {
+		return XREADGROUPResNilRes, nil
+	}
+	return newXREADRes(res), nil
+}
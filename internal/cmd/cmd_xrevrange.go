@@ -0,0 +1,56 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+)
+
+var cXREVRANGE = &CommandMeta{
+	Name:      "XREVRANGE",
+	Syntax:    "XREVRANGE key end start [COUNT count]",
+	HelpShort: "XREVRANGE returns the entries of the stream stored at key within a range of IDs, newest first",
+	HelpLong: `
+XREVRANGE returns the entries of the stream stored at key whose ID is between start and
+end, both inclusive, from the newest to the oldest. The end of the range is given first.
+
+The IDs are given as for XRANGE, and every entry is returned as a JSON array holding its
+ID followed by its fields and values. The command returns an empty list if the key does
+not exist.
+	`,
+	Examples: `
+localhost:7379> XADD orders 1-0 item book
+OK 1-0
+localhost:7379> XADD orders 2-0 item pen
+OK 2-0
+localhost:7379> XREVRANGE orders + - COUNT 1
+OK
+0) ["2-0","item","pen"]
+	`,
+	IsReadOnly: true,
+	Eval:       evalXREVRANGE,
+	Execute:    executeXREVRANGE,
+}
+
+func init() {
+	CommandRegistry.AddCommand(cXREVRANGE)
+}
+
+var (
+	XREVRANGEResNilRes = newXRANGERes(nil)
+)
+
+func evalXREVRANGE(c *Cmd, s *dstore.Store) (*CmdRes, error) {
+	return streamRange(c, s, "XREVRANGE", true)
+}
+
+func executeXREVRANGE(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error) {
+	if len(c.C.Args) != 3 && len(c.C.Args) != 5 {
+		return XREVRANGEResNilRes, errors.ErrWrongArgumentCount("XREVRANGE")
+	}
+	shard := sm.GetShardForKey(c.C.Args[0])
+	return evalXREVRANGE(c, shard.Thread.Store())
+}
@@ -16,7 +16,7 @@ import (
 
 var cZADD = &CommandMeta{
 	Name:      "ZADD",
-	Syntax:    "ZADD key [NX | XX] [GT | LT] [CH] [INCR] score member [score member...]",
+	Syntax:    "ZADD key [NX | XX] [GT | LT] [CH] [INCR] [IFVERSION version] [IFEQ score] score member [score member...]",
 	HelpShort: "ZADD adds all the specified members with the specified scores to the sorted set stored at key",
 	HelpLong: `
 ZADD adds all the specified members with the specified scores to the sorted set stored at key.
@@ -29,6 +29,11 @@ The score of the member should be an integer and two members can have the same s
 - LT: Only add new elements if the score is less than the existing score
 - CH: Modify the return value from the number of new elements added to the total number of elements changed
 - INCR: When this option is specified, the scores provided are treated as increments to the score of the existing elements
+- IFVERSION version: Only add the elements if the current version of the key, as returned by VERSION, is version
+- IFEQ score: Only update the member if its current score is score. Only a single member can be updated with IFEQ.
+
+If the IFVERSION or IFEQ condition does not hold, the sorted set is not modified
+and the "precondition failed" error is returned.
 
 The command by default returns the number of elements added to the sorted set.
 	`,
@@ -45,9 +50,16 @@ localhost:7379> ZADD users 10 u1
 OK 0
 localhost:7379> ZADD users CH 11 u1
 OK 1
+localhost:7379> ZADD users IFEQ 11 20 u1
+OK 0
+localhost:7379> ZADD users IFEQ 11 30 u1
+ERR precondition failed
 `,
 	Eval:    evalZADD,
 	Execute: executeZADD,
+	// The IFVERSION and IFEQ conditions are checked and the value written
+	// while no other command modifies the key.
+	LockKeysExclusive: true,
 }
 
 func init() {
@@ -94,23 +106,45 @@ func evalZADD(c *Cmd, s *dsstore.Store) (*CmdRes, error) {
 		members = append(members, nonParams[i+1])
 	}
 
-	var ss *types.SortedSet
 	obj := s.Get(key)
-	if obj == nil {
-		ss = types.NewSortedSet()
-		s.Put(key, s.NewObj(ss, -1, object.ObjTypeSortedSet), dsstore.WithPutCmd(dsstore.ZAdd))
-	} else {
-		if obj.Type != object.ObjTypeSortedSet {
-			return ZADDResNilRes, errors.ErrWrongTypeOperation
+	if obj != nil && obj.Type != object.ObjTypeSortedSet {
+		return ZADDResNilRes, errors.ErrWrongTypeOperation
+	}
+
+	if err := checkIfVersion("ZADD", obj, params); err != nil {
+		return ZADDResNilRes, err
+	}
+	if v, ok := params[types.IFEQ]; ok {
+		if len(members) != 1 {
+			return ZADDResNilRes, errors.ErrInvalidSyntax("ZADD")
 		}
-		ss = obj.Value.(*types.SortedSet)
+		score, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ZADDResNilRes, errors.ErrInvalidValue("ZADD", "IFEQ")
+		}
+		matched := false
+		if obj != nil {
+			if n := obj.Value.(*types.SortedSet).GetByKey(members[0]); n != nil {
+				matched = int64(n.Score()) == score
+			}
+		}
+		if !matched {
+			return ZADDResNilRes, errors.ErrPreconditionFailed
+		}
+	}
+
+	if obj == nil {
+		obj = s.NewObj(types.NewSortedSet(), -1, object.ObjTypeSortedSet)
+		s.Put(key, obj, dsstore.WithPutCmd(dsstore.ZAdd))
 	}
 
 	// Note: Validation of the params is done in the types.SortedSet.ZADD method
-	count, err := ss.ZADD(scores, members, params)
+	count, err := obj.Value.(*types.SortedSet).ZADD(scores, members, params)
 	if err != nil {
 		return ZADDResNilRes, err
 	}
+	s.BumpVersion(obj)
+	s.SignalKeyReady(key)
 	return newZADDRes(count), nil
 }
 
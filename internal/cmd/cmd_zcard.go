@@ -29,6 +29,7 @@ OK 3
 localhost:7379> ZCARD nonexistent_key
 OK 0
 	`,
+	IsReadOnly:  true,
 	Eval:        evalZCARD,
 	Execute:     executeZCARD,
 	IsWatchable: true,
@@ -45,6 +45,7 @@ OK 3
 localhost:7379> ZCOUNT k 10 10
 OK 1
 	`,
+	IsReadOnly:  true,
 	Eval:        evalZCOUNT,
 	Execute:     executeZCOUNT,
 	IsWatchable: true,
@@ -110,6 +110,9 @@ func evalZPOPMAX(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 			Rank:   int64(totalElements) - int64(i),
 		})
 	}
+	if len(elements) > 0 {
+		s.BumpVersion(obj)
+	}
 	return newZPOPMAXRes(elements), nil
 }
 
@@ -108,6 +108,9 @@ func evalZPOPMIN(c *Cmd, s *dstore.Store) (*CmdRes, error) {
 			Rank:   int64(i + 1),
 		})
 	}
+	if len(elements) > 0 {
+		s.BumpVersion(obj)
+	}
 	return newZPOPMINRes(elements), nil
 }
 
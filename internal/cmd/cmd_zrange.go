@@ -51,6 +51,7 @@ OK
 4) 40, d
 5) 50, e
 `,
+	IsReadOnly:  true,
 	Eval:        evalZRANGE,
 	Execute:     executeZRANGE,
 	IsWatchable: true,
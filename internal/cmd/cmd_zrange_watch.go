@@ -12,7 +12,7 @@ import (
 
 var cZRANGEWATCH = &CommandMeta{
 	Name:      "ZRANGE.WATCH",
-	Syntax:    "ZRANGE.WATCH key start stop [BYSCORE | BYRANK]",
+	Syntax:    "ZRANGE.WATCH key start stop [BYSCORE | BYRANK] [DIFF]",
 	HelpShort: "ZRANGE.WATCH creates a query subscription over the ZRANGE command",
 	HelpLong: `
 ZRANGE.WATCH creates a query subscription over the ZRANGE command. The client invoking the command
@@ -20,6 +20,13 @@ will receive the output of the ZRANGE command (not just the notification) whenev
 the key is updated.
 
 You can update the key in any other client. The ZRANGE.WATCH client will receive the updated value.
+
+ZRANGE.WATCH accepts a trailing DIFF option, with which the notifications carry only the members changed
+since the previous notification rather than the whole result. The first notification, and every
+watch-diff-full-frame-interval notification, carries the whole result. A diff has the message
+"OK diff" and lists one triple per changed member: "+", the member and its score when it was set,
+or "-", the member and an empty string when it was removed. A client whose sequence number skips
+should wait for the next whole result. DIFF cannot be combined with a THROTTLE interval.
 	`,
 	Examples: `
 client1:7379> ZADD users 10 alice 20 bob 30 charlie
@@ -38,6 +38,7 @@ OK 3) 30, charlie
 localhost:7379> ZRANK users daniel
 OK 0) 0, daniel
 	`,
+	IsReadOnly:  true,
 	Eval:        evalZRANK,
 	Execute:     executeZRANK,
 	IsWatchable: true,
@@ -93,6 +93,9 @@ func evalZREM(c *Cmd, s *dsstore.Store) (*CmdRes, error) {
 			countRem++
 		}
 	}
+	if countRem > 0 {
+		s.BumpVersion(obj)
+	}
 
 	return newZREMRes(countRem), nil
 }
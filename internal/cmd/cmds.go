@@ -26,6 +26,14 @@ type Cmd struct {
 	ClientID string
 	Mode     string
 	Meta     *CommandMeta
+
+	// Done is closed when the client of the command disconnects or the
+	// server shuts down. Blocking commands only wait for their keys when
+	// it is set, and stop waiting once it is closed.
+	Done <-chan struct{}
+
+	// blocked is set while a blocking command waits on its keys.
+	blocked *blockedState
 }
 
 func (c *Cmd) String() string {
@@ -43,6 +51,33 @@ func (c *Cmd) Key() string {
 	return ""
 }
 
+// Keys returns the keys the command operates on, as declared by the KeySpec
+// or GetKeys of the command. Commands declaring neither operate on the first argument.
+func (c *Cmd) Keys() []string {
+	if c.Meta != nil && c.Meta.GetKeys != nil {
+		return c.Meta.GetKeys(c)
+	}
+	if c.Meta == nil || c.Meta.KeySpec.Step <= 0 {
+		if len(c.C.Args) > 0 {
+			return c.C.Args[:1]
+		}
+		return nil
+	}
+
+	ks := c.Meta.KeySpec
+	last := ks.Last
+	if last < 0 {
+		last += len(c.C.Args)
+	}
+	last = min(last, len(c.C.Args)-1)
+
+	var keys []string
+	for i := ks.First; i <= last; i += ks.Step {
+		keys = append(keys, c.C.Args[i])
+	}
+	return keys
+}
+
 func (c *Cmd) Execute(sm *shardmanager.ShardManager) (*CmdRes, error) {
 	res := &CmdRes{
 		Rs: &wire.Result{},
@@ -58,7 +93,11 @@ func (c *Cmd) Execute(sm *shardmanager.ShardManager) (*CmdRes, error) {
 		c.Meta = meta
 	}
 
-	res, err = c.Meta.Execute(c, sm)
+	res, err = c.executeLocked(sm)
+	for err == errBlocked {
+		res, err = c.wait(sm)
+	}
+
 	slog.Debug("command executed",
 		slog.Any("cmd", c.String()),
 		slog.String("client_id", c.ClientID),
@@ -67,6 +106,21 @@ func (c *Cmd) Execute(sm *shardmanager.ShardManager) (*CmdRes, error) {
 	return res, err
 }
 
+// executeLocked executes the command while holding the locks of the shards
+// it operates on. Commands spanning multiple keys lock the shards owning
+// them exclusively so that they are observed as a single operation.
+func (c *Cmd) executeLocked(sm *shardmanager.ShardManager) (*CmdRes, error) {
+	var unlock func()
+	if c.Meta.LockAllShards {
+		unlock = sm.LockAll()
+	} else {
+		keys := c.Keys()
+		unlock = sm.LockKeys(len(keys) > 1 || c.Meta.LockKeysExclusive, keys...)
+	}
+	defer unlock()
+	return c.Meta.Execute(c, sm)
+}
+
 type CmdRes struct {
 	Rs       *wire.Result
 	ClientID string
@@ -79,8 +133,40 @@ type CommandMeta struct {
 	Examples    string
 	HelpLong    string
 	IsWatchable bool
-	Eval        func(c *Cmd, s *store.Store) (*CmdRes, error)
-	Execute     func(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error)
+	// IsReadOnly marks the commands that do not modify the data.
+	IsReadOnly bool
+	KeySpec    KeySpec
+	Eval       func(c *Cmd, s *store.Store) (*CmdRes, error)
+	Execute    func(c *Cmd, sm *shardmanager.ShardManager) (*CmdRes, error)
+
+	// GetKeys returns the keys of commands whose keys cannot be declared
+	// through a KeySpec. It takes precedence over the KeySpec.
+	GetKeys func(c *Cmd) []string
+
+	// LockAllShards makes the command hold the write lock of every shard
+	// while it executes, irrespective of the keys it operates on.
+	LockAllShards bool
+
+	// LockKeysExclusive makes the command hold the write lock of the shards
+	// owning its keys even when it operates on a single key.
+	LockKeysExclusive bool
+
+	// IsBlocking marks the commands that may wait on their keys, which the
+	// server executes apart from the io-thread pool so as not to hold it.
+	IsBlocking bool
+}
+
+// KeySpec declares the positions of the keys in the arguments of a command,
+// similar to the first key, last key and step reported by COMMAND INFO in Redis.
+type KeySpec struct {
+	// First is the index of the first key in the arguments.
+	First int
+	// Last is the index of the last key in the arguments.
+	// Negative values are relative to the end of the arguments.
+	Last int
+	// Step is the distance between two consecutive keys. A KeySpec
+	// with a zero Step is treated as a single key at the first argument.
+	Step int
 }
 
 type CmdRegistry struct {
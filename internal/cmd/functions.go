@@ -0,0 +1,326 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc64"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dicedb/dice/internal/errors"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// functionFlagNoWrites marks the functions that do not modify the data
+// and can be called with FCALL_RO.
+const functionFlagNoWrites = "no-writes"
+
+// functionsDumpVersion is the version of the serialization format
+// written by FUNCTION DUMP.
+const functionsDumpVersion byte = 1
+
+var (
+	libraryShebang = regexp.MustCompile(`^#!lua name=([A-Za-z0-9_]+)\s*$`)
+	functionName   = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+// library is a set of functions loaded together by FUNCTION LOAD.
+type library struct {
+	name      string
+	code      string
+	proto     *lua.FunctionProto
+	functions map[string]*luaFunction
+}
+
+// luaFunction is a function registered by a library through dice.register_function.
+type luaFunction struct {
+	name     string
+	library  *library
+	noWrites bool
+}
+
+// functionRegistry holds the libraries loaded by FUNCTION LOAD and their
+// functions. It is shared by all the shards and io-threads, and is rebuilt
+// from the FUNCTION commands in the WAL on restart.
+type functionRegistry struct {
+	mu        sync.RWMutex
+	libraries map[string]*library
+	functions map[string]*luaFunction
+}
+
+var functions = &functionRegistry{
+	libraries: map[string]*library{},
+	functions: map[string]*luaFunction{},
+}
+
+// compileLibrary compiles the code of a library and returns the library with
+// the functions it registers. The name of the library is taken from the
+// "#!lua name=<name>" shebang on the first line of the code.
+func compileLibrary(c *Cmd, code string) (*library, error) {
+	header, body, _ := strings.Cut(code, "\n")
+	m := libraryShebang.FindStringSubmatch(header)
+	if m == nil {
+		return nil, errors.ErrGeneral("missing or invalid library shebang, expected '#!lua name=<library name>'")
+	}
+
+	// The shebang is replaced by an empty line to keep the line numbers of errors.
+	chunk, err := parse.Parse(strings.NewReader("\n"+body), m[1])
+	if err != nil {
+		return nil, errors.ErrGeneral("error compiling library: " + strings.TrimSpace(err.Error()))
+	}
+	proto, err := lua.Compile(chunk, m[1])
+	if err != nil {
+		return nil, errors.ErrGeneral("error compiling library: " + strings.TrimSpace(err.Error()))
+	}
+
+	lib := &library{name: m[1], code: code, proto: proto}
+	s := newScriptState(c)
+	defer s.Close()
+	if lib.functions, err = s.loadLibrary(lib); err != nil {
+		return nil, err
+	}
+	if len(lib.functions) == 0 {
+		return nil, errors.ErrGeneral("no functions registered")
+	}
+	return lib, nil
+}
+
+// loadLibrary runs the code of the library and returns the functions it registers.
+func (s *scriptState) loadLibrary(lib *library) (map[string]*luaFunction, error) {
+	s.registered = map[string]*luaFunction{}
+	s.callbacks = map[string]*lua.LFunction{}
+	defer func() { s.registered = nil }()
+
+	if _, err := s.pcall(s.NewFunctionFromProto(lib.proto)); err != nil {
+		return nil, err
+	}
+	for _, fn := range s.registered {
+		fn.library = lib
+	}
+	return s.registered, nil
+}
+
+// registerFunction implements dice.register_function, which is called either as
+// dice.register_function(name, callback) or as dice.register_function{function_name=name,
+// callback=callback, flags={...}}.
+func (s *scriptState) registerFunction(L *lua.LState) int {
+	if s.registered == nil {
+		L.RaiseError("dice.register_function can only be called while loading a library")
+		return 0
+	}
+
+	var name string
+	var callback *lua.LFunction
+	var flags []string
+	switch v := L.Get(1).(type) {
+	case lua.LString:
+		name, callback = string(v), L.CheckFunction(2)
+	case *lua.LTable:
+		n, ok := v.RawGetString("function_name").(lua.LString)
+		if !ok {
+			L.RaiseError("function_name argument given to dice.register_function must be a string")
+		}
+		cb, ok := v.RawGetString("callback").(*lua.LFunction)
+		if !ok {
+			L.RaiseError("callback argument given to dice.register_function must be a function")
+		}
+		name, callback = string(n), cb
+		if t, ok := v.RawGetString("flags").(*lua.LTable); ok {
+			t.ForEach(func(_, f lua.LValue) {
+				flags = append(flags, f.String())
+			})
+		}
+	default:
+		L.ArgError(1, "function name or table expected")
+	}
+
+	if !functionName.MatchString(name) {
+		L.RaiseError("function names can only contain letters, numbers, or underscores(_)")
+	}
+	if _, ok := s.registered[name]; ok {
+		L.RaiseError("function '%s' already registered by the library", name)
+	}
+
+	fn := &luaFunction{name: name}
+	for _, f := range flags {
+		if f != functionFlagNoWrites {
+			L.RaiseError("unknown flag '%s' given to dice.register_function", f)
+		}
+		fn.noWrites = true
+	}
+	s.registered[name] = fn
+	s.callbacks[name] = callback
+	return 0
+}
+
+// runFunction runs the function with the keys and args passed as its
+// arguments. The commands called by the function are executed by execute.
+func runFunction(c *Cmd, fn *luaFunction, keys, args []string, execute func(sub *Cmd) (*CmdRes, error)) (*CmdRes, error) {
+	s := newScriptState(c)
+	defer s.Close()
+
+	if _, err := s.loadLibrary(fn.library); err != nil {
+		return nil, err
+	}
+
+	s.execute = execute
+	ret, err := s.pcall(s.callbacks[fn.name],
+		stringsToLua(s.LState, keys), stringsToLua(s.LState, args))
+	if err != nil {
+		return nil, err
+	}
+	return scriptResult(ret)
+}
+
+func (r *functionRegistry) get(name string) *luaFunction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.functions[name]
+}
+
+// add adds the libraries to the registry. Existing libraries with the same
+// name are replaced if replace is true, otherwise an error is returned.
+// Either all the libraries are added or none of them.
+func (r *functionRegistry) add(libs []*library, replace bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := map[string]bool{}
+	for _, lib := range libs {
+		if names[lib.name] || (!replace && r.libraries[lib.name] != nil) {
+			return errors.ErrGeneral(fmt.Sprintf("library '%s' already exists", lib.name))
+		}
+		names[lib.name] = true
+	}
+
+	owners := map[string]string{}
+	for _, lib := range libs {
+		for name := range lib.functions {
+			owner := owners[name]
+			if owner == "" {
+				if fn := r.functions[name]; fn != nil && !names[fn.library.name] {
+					owner = fn.library.name
+				}
+			}
+			if owner != "" {
+				return errors.ErrGeneral(fmt.Sprintf("function '%s' already exists in library '%s'", name, owner))
+			}
+			owners[name] = lib.name
+		}
+	}
+
+	for _, lib := range libs {
+		r.remove(lib.name)
+		r.libraries[lib.name] = lib
+		for name, fn := range lib.functions {
+			r.functions[name] = fn
+		}
+	}
+	return nil
+}
+
+// remove removes the library and its functions. The caller must hold the lock.
+func (r *functionRegistry) remove(name string) bool {
+	lib := r.libraries[name]
+	if lib == nil {
+		return false
+	}
+	for fn := range lib.functions {
+		delete(r.functions, fn)
+	}
+	delete(r.libraries, name)
+	return true
+}
+
+func (r *functionRegistry) delete(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remove(name)
+}
+
+// reset replaces all the libraries of the registry with libs.
+func (r *functionRegistry) reset(libs []*library) error {
+	fresh := &functionRegistry{
+		libraries: map[string]*library{},
+		functions: map[string]*luaFunction{},
+	}
+	if err := fresh.add(libs, false); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.libraries, r.functions = fresh.libraries, fresh.functions
+	return nil
+}
+
+func (r *functionRegistry) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.libraries = map[string]*library{}
+	r.functions = map[string]*luaFunction{}
+}
+
+// list returns the libraries sorted by name.
+func (r *functionRegistry) list() []*library {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	libs := make([]*library, 0, len(r.libraries))
+	for _, lib := range r.libraries {
+		libs = append(libs, lib)
+	}
+	sort.Slice(libs, func(i, j int) bool {
+		return libs[i].name < libs[j].name
+	})
+	return libs
+}
+
+// dumpFunctions serializes the code of the libraries. The serialized value is
+//
+//	version (1 byte) | number of libraries | code of each library | crc64 of the preceding bytes (8 bytes)
+//
+// using the same encoding of integers and strings as DumpObject.
+func dumpFunctions(libs []*library) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(functionsDumpVersion)
+	dumpWriteInt(&buf, int64(len(libs)))
+	for _, lib := range libs {
+		dumpWriteString(&buf, lib.code)
+	}
+	return binary.BigEndian.AppendUint64(buf.Bytes(), crc64.Checksum(buf.Bytes(), dumpCRCTable))
+}
+
+// restoreFunctions returns the code of the libraries serialized by dumpFunctions.
+func restoreFunctions(b []byte) ([]string, error) {
+	errPayload := errors.ErrGeneral("payload version or checksum are wrong")
+	if len(b) < 9 {
+		return nil, errPayload
+	}
+	data, sum := b[:len(b)-8], binary.BigEndian.Uint64(b[len(b)-8:])
+	if data[0] != functionsDumpVersion || crc64.Checksum(data, dumpCRCTable) != sum {
+		return nil, errPayload
+	}
+
+	r := bytes.NewReader(data[1:])
+	n, err := dumpReadLen(r)
+	if err != nil {
+		return nil, errPayload
+	}
+	codes := make([]string, n)
+	for i := range codes {
+		if codes[i], err = dumpReadString(r); err != nil {
+			return nil, errPayload
+		}
+	}
+	if r.Len() != 0 {
+		return nil, errPayload
+	}
+	return codes, nil
+}
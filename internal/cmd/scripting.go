@@ -0,0 +1,463 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA1 identifies scripts, as in Redis, it is not used for security.
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dicedb-go/wire"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// scriptCache holds the compiled scripts by the SHA1 of their source.
+// It is shared by all the shards and io-threads.
+type scriptCache struct {
+	mu      sync.RWMutex
+	scripts map[string]*lua.FunctionProto
+}
+
+var scripts = &scriptCache{scripts: map[string]*lua.FunctionProto{}}
+
+// load compiles the script, caches it and returns its SHA1.
+func (sc *scriptCache) load(src string) (string, *lua.FunctionProto, error) {
+	sum := sha1.Sum([]byte(src)) //nolint:gosec
+	sha := hex.EncodeToString(sum[:])
+	if proto := sc.get(sha); proto != nil {
+		return sha, proto, nil
+	}
+
+	chunk, err := parse.Parse(strings.NewReader(src), "script")
+	if err != nil {
+		return "", nil, errors.ErrGeneral("error compiling script: " + strings.TrimSpace(err.Error()))
+	}
+	proto, err := lua.Compile(chunk, "script")
+	if err != nil {
+		return "", nil, errors.ErrGeneral("error compiling script: " + strings.TrimSpace(err.Error()))
+	}
+
+	sc.mu.Lock()
+	sc.scripts[sha] = proto
+	sc.mu.Unlock()
+	return sha, proto, nil
+}
+
+func (sc *scriptCache) get(sha string) *lua.FunctionProto {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.scripts[strings.ToLower(sha)]
+}
+
+func (sc *scriptCache) flush() {
+	sc.mu.Lock()
+	sc.scripts = map[string]*lua.FunctionProto{}
+	sc.mu.Unlock()
+}
+
+// scriptDeniedCommands cannot be called from scripts, either because they
+// control the connection, are handled by the io-thread, or because they
+// operate on all the shards.
+var scriptDeniedCommands = map[string]bool{
+	"EVAL": true, "EVALSHA": true, "SCRIPT": true,
+	"MULTI": true, "EXEC": true, "DISCARD": true,
+	"HANDSHAKE": true, "UNWATCH": true,
+	"WATCH.STATS": true, "WATCH.LIST": true,
+	"PUBLISH": true, "SUBSCRIBE": true, "PSUBSCRIBE": true,
+	"UNSUBSCRIBE": true, "PUNSUBSCRIBE": true, "PUBSUB": true,
+	"FUNCTION": true, "FCALL": true, "FCALL_RO": true,
+	"FLUSHDB": true, "KEYS": true, "EXPORT": true, "IMPORT": true,
+}
+
+// parseScriptArgs splits the arguments of EVAL and EVALSHA
+// into the script or its SHA1, the keys and the arguments.
+func parseScriptArgs(c *Cmd) (script string, keys, args []string, err error) {
+	if len(c.C.Args) < 2 {
+		return "", nil, nil, errors.ErrWrongArgumentCount(c.C.Cmd)
+	}
+	n, err := strconv.Atoi(c.C.Args[1])
+	if err != nil || n < 0 {
+		return "", nil, nil, errors.ErrGeneral("number of keys can't be negative or non-integer")
+	}
+	if n > len(c.C.Args)-2 {
+		return "", nil, nil, errors.ErrGeneral("number of keys can't be greater than number of args")
+	}
+	return c.C.Args[0], c.C.Args[2 : 2+n], c.C.Args[2+n:], nil
+}
+
+func getKeysScript(c *Cmd) []string {
+	_, keys, _, err := parseScriptArgs(c)
+	if err != nil {
+		return nil
+	}
+	return keys
+}
+
+// executeOnShards returns the function executing the commands called by a script
+// whose keys are keys. The commands are executed directly, as the shards owning
+// the keys are locked by the caller, and can only access the declared keys.
+// Commands modifying the data are rejected if readOnly is true.
+func executeOnShards(sm *shardmanager.ShardManager, keys []string, readOnly bool) func(sub *Cmd) (*CmdRes, error) {
+	declared := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		declared[k] = true
+	}
+	return func(sub *Cmd) (*CmdRes, error) {
+		if readOnly && !sub.Meta.IsReadOnly {
+			return nil, errors.ErrGeneral("write commands are not allowed from read-only functions")
+		}
+		for _, k := range sub.Keys() {
+			if !declared[k] {
+				return nil, errors.ErrGeneral(fmt.Sprintf("script tried accessing key '%s' that is not declared in KEYS", k))
+			}
+		}
+		return sub.Meta.Execute(sub, sm)
+	}
+}
+
+// executeOnStore returns the function executing the commands
+// called by a script against a single store.
+func executeOnStore(s *dstore.Store, readOnly bool) func(sub *Cmd) (*CmdRes, error) {
+	return func(sub *Cmd) (*CmdRes, error) {
+		if readOnly && !sub.Meta.IsReadOnly {
+			return nil, errors.ErrGeneral("write commands are not allowed from read-only functions")
+		}
+		return sub.Meta.Eval(sub, s)
+	}
+}
+
+// scriptState is a Lua state with the libraries and the dice table available
+// to scripts and functions.
+type scriptState struct {
+	*lua.LState
+	c      *Cmd
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// execute executes the commands called through dice.call and dice.pcall.
+	// Commands can not be called while it is nil, like when loading a library.
+	execute func(sub *Cmd) (*CmdRes, error)
+
+	// registered holds the functions registered through dice.register_function.
+	// Functions can only be registered while it is not nil.
+	registered map[string]*luaFunction
+
+	// callbacks holds the Lua functions of the functions registered by the library
+	// loaded in the state.
+	callbacks map[string]*lua.LFunction
+}
+
+// newScriptState returns a new state whose scripts are aborted if they run
+// for longer than the configured time limit.
+func newScriptState(c *Cmd) *scriptState {
+	s := &scriptState{
+		LState: lua.NewState(lua.Options{SkipOpenLibs: true}),
+		c:      c,
+	}
+	openScriptLibs(s.LState)
+
+	limit := time.Duration(config.Config.ScriptTimeLimitMs) * time.Millisecond
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), limit)
+	s.SetContext(s.ctx)
+
+	call := func(raise bool) lua.LGFunction {
+		return func(L *lua.LState) int {
+			res, err := s.callCommand(L)
+			if err != nil {
+				if raise {
+					L.Error(lua.LString(err.Error()), 0)
+					return 0
+				}
+				L.Push(replyTable(L, "err", err.Error()))
+				return 1
+			}
+			L.Push(resultToLua(L, res.Rs))
+			return 1
+		}
+	}
+	dice := s.NewTable()
+	s.SetFuncs(dice, map[string]lua.LGFunction{
+		"call":  call(true),
+		"pcall": call(false),
+		"error_reply": func(L *lua.LState) int {
+			L.Push(replyTable(L, "err", L.CheckString(1)))
+			return 1
+		},
+		"status_reply": func(L *lua.LState) int {
+			L.Push(replyTable(L, "ok", L.CheckString(1)))
+			return 1
+		},
+		"register_function": s.registerFunction,
+	})
+	s.SetGlobal("dice", dice)
+	return s
+}
+
+func (s *scriptState) Close() {
+	s.cancel()
+	s.LState.Close()
+}
+
+// pcall calls fn with args and returns the value it returns.
+func (s *scriptState) pcall(fn lua.LValue, args ...lua.LValue) (lua.LValue, error) {
+	err := s.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...)
+	if err != nil {
+		if s.ctx.Err() != nil {
+			return nil, errors.ErrGeneral(fmt.Sprintf("script exceeded the time limit of %d ms", config.Config.ScriptTimeLimitMs))
+		}
+		if apiErr, ok := err.(*lua.ApiError); ok {
+			return nil, errors.ErrGeneral(apiErr.Object.String())
+		}
+		return nil, err
+	}
+	ret := s.Get(-1)
+	s.Pop(1)
+	return ret, nil
+}
+
+// runScript runs the compiled script with the keys and args exposed as the
+// KEYS and ARGV tables. The commands called by the script are executed by execute.
+func runScript(c *Cmd, proto *lua.FunctionProto, keys, args []string, execute func(sub *Cmd) (*CmdRes, error)) (*CmdRes, error) {
+	s := newScriptState(c)
+	defer s.Close()
+
+	s.execute = execute
+	s.SetGlobal("KEYS", stringsToLua(s.LState, keys))
+	s.SetGlobal("ARGV", stringsToLua(s.LState, args))
+
+	ret, err := s.pcall(s.NewFunctionFromProto(proto))
+	if err != nil {
+		return nil, err
+	}
+	return scriptResult(ret)
+}
+
+// openScriptLibs opens the libraries available to scripts. The libraries
+// giving access to the file system, the OS and the interpreter are left out.
+func openScriptLibs(L *lua.LState) {
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range []string{"dofile", "loadfile", "loadstring", "print", "_printregs", "setfenv"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	// The scripts are replayed from the WAL, so math.random returns the
+	// same sequence on every run unless the script seeds it itself.
+	rng := rand.New(rand.NewSource(0)) //nolint:gosec // scripts must be deterministic.
+	mathLib := L.GetGlobal(lua.MathLibName).(*lua.LTable)
+	L.SetFuncs(mathLib, map[string]lua.LGFunction{
+		"random": func(L *lua.LState) int {
+			switch L.GetTop() {
+			case 0:
+				L.Push(lua.LNumber(rng.Float64()))
+			case 1:
+				n := L.CheckInt(1)
+				if n < 1 {
+					L.ArgError(1, "interval is empty")
+				}
+				L.Push(lua.LNumber(rng.Intn(n) + 1))
+			default:
+				m, n := L.CheckInt(1), L.CheckInt(2)
+				if m > n {
+					L.ArgError(2, "interval is empty")
+				}
+				L.Push(lua.LNumber(rng.Intn(n-m+1) + m))
+			}
+			return 1
+		},
+		"randomseed": func(L *lua.LState) int {
+			rng.Seed(L.CheckInt64(1))
+			return 0
+		},
+	})
+}
+
+// callCommand executes the command passed to dice.call or dice.pcall.
+func (s *scriptState) callCommand(L *lua.LState) (*CmdRes, error) {
+	if s.execute == nil {
+		return nil, errors.ErrGeneral("commands can not be called while loading a library")
+	}
+	if L.GetTop() == 0 {
+		return nil, errors.ErrGeneral("please specify at least one argument for dice.call()")
+	}
+
+	var argv []string
+	for i := 1; i <= L.GetTop(); i++ {
+		switch v := L.Get(i).(type) {
+		case lua.LString, lua.LNumber:
+			argv = append(argv, v.String())
+		default:
+			return nil, errors.ErrGeneral("command arguments must be strings or integers")
+		}
+	}
+
+	name := strings.ToUpper(argv[0])
+	meta, ok := CommandRegistry.CommandMetas[name]
+	if !ok {
+		return nil, errors.ErrUnknownCmd(argv[0])
+	}
+	if scriptDeniedCommands[name] || meta.LockAllShards || strings.HasSuffix(name, ".WATCH") {
+		return nil, errors.ErrGeneral(fmt.Sprintf("command '%s' is not allowed from scripts", name))
+	}
+
+	return s.execute(&Cmd{
+		C:        &wire.Command{Cmd: name, Args: argv[1:]},
+		IsReplay: s.c.IsReplay,
+		ClientID: s.c.ClientID,
+		Mode:     s.c.Mode,
+		Meta:     meta,
+	})
+}
+
+func stringsToLua(L *lua.LState, strs []string) *lua.LTable {
+	t := L.CreateTable(len(strs), 0)
+	for _, s := range strs {
+		t.Append(lua.LString(s))
+	}
+	return t
+}
+
+func replyTable(L *lua.LState, field, msg string) *lua.LTable {
+	t := L.NewTable()
+	t.RawSetString(field, lua.LString(msg))
+	return t
+}
+
+// resultToLua converts the result of a command into a Lua value. Responses
+// with a single field are converted to the value of the field, and responses
+// with multiple fields to a table keyed by the JSON names of the fields.
+// Commands without a response return their message.
+func resultToLua(L *lua.LState, rs *wire.Result) lua.LValue {
+	m := rs.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("response"))
+	if fd == nil {
+		return lua.LString(rs.Message)
+	}
+
+	res := m.Get(fd).Message()
+	fields := res.Descriptor().Fields()
+	switch fields.Len() {
+	case 0:
+		return lua.LString(rs.Message)
+	case 1:
+		return protoFieldToLua(L, fields.Get(0), res.Get(fields.Get(0)))
+	}
+	return protoMessageToLua(L, res)
+}
+
+func protoMessageToLua(L *lua.LState, msg protoreflect.Message) *lua.LTable {
+	t := L.NewTable()
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		t.RawSetString(fd.JSONName(), protoFieldToLua(L, fd, msg.Get(fd)))
+	}
+	return t
+}
+
+func protoFieldToLua(L *lua.LState, fd protoreflect.FieldDescriptor, v protoreflect.Value) lua.LValue {
+	switch {
+	case fd.IsList():
+		t := L.NewTable()
+		for i := 0; i < v.List().Len(); i++ {
+			t.Append(protoValueToLua(L, v.List().Get(i)))
+		}
+		return t
+	case fd.IsMap():
+		t := L.NewTable()
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			t.RawSetString(k.String(), protoValueToLua(L, mv))
+			return true
+		})
+		return t
+	}
+	return protoValueToLua(L, v)
+}
+
+func protoValueToLua(L *lua.LState, v protoreflect.Value) lua.LValue {
+	switch x := v.Interface().(type) {
+	case bool:
+		return lua.LBool(x)
+	case string:
+		return lua.LString(x)
+	case []byte:
+		return lua.LString(x)
+	case int32:
+		return lua.LNumber(x)
+	case int64:
+		return lua.LNumber(x)
+	case uint32:
+		return lua.LNumber(x)
+	case uint64:
+		return lua.LNumber(x)
+	case float32:
+		return lua.LNumber(x)
+	case float64:
+		return lua.LNumber(x)
+	case protoreflect.EnumNumber:
+		return lua.LNumber(x)
+	case protoreflect.Message:
+		return protoMessageToLua(L, x)
+	}
+	return lua.LNil
+}
+
+// scriptResult converts the value returned by a script into the result of
+// EVAL. Numbers are truncated to integers, tables with an err or ok field
+// are returned as an error or a status, and other tables are returned as
+// a list of strings.
+func scriptResult(v lua.LValue) (*CmdRes, error) {
+	rs := &wire.Result{Message: "OK", Status: wire.Status_OK}
+	switch v := v.(type) {
+	case lua.LNumber:
+		rs.Response = &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: int64(v)}}
+	case lua.LString:
+		rs.Response = &wire.Result_GETRes{GETRes: &wire.GETRes{Value: string(v)}}
+	case lua.LBool:
+		if v {
+			rs.Response = &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: 1}}
+		} else {
+			rs.Response = &wire.Result_GETRes{GETRes: &wire.GETRes{}}
+		}
+	case *lua.LTable:
+		if e, ok := v.RawGetString("err").(lua.LString); ok {
+			return nil, errors.ErrGeneral(string(e))
+		}
+		if s, ok := v.RawGetString("ok").(lua.LString); ok {
+			rs.Message = string(s)
+			break
+		}
+		values := make([]string, 0, v.Len())
+		for i := 1; i <= v.Len(); i++ {
+			values = append(values, lua.LVAsString(v.RawGetInt(i)))
+		}
+		rs.Response = &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: values}}
+	default:
+		rs.Response = &wire.Result_GETRes{GETRes: &wire.GETRes{}}
+	}
+	return &CmdRes{Rs: rs}, nil
+}
@@ -56,12 +56,14 @@ var (
 	ErrInternalServer             = errors.New("internal server error, unable to process command")                       // Represents a generic internal server error.
 	ErrAuth                       = errors.New("AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?")
 	ErrAborted                    = errors.New("server received ABORT command")
+	ErrServerShuttingDown         = errors.New("server is shutting down")
 	ErrEmptyCommand               = errors.New("empty command")
 	ErrInvalidIPAddress           = errors.New("invalid IP address")
 	ErrInvalidFingerprint         = errors.New("invalid fingerprint")
 	ErrKeyDoesNotExist            = errors.New("could not perform this operation on a key that doesn't exist")
 	ErrKeyExists                  = errors.New("key exists")
 	ErrUnknownObjectType          = errors.New("unknown object type")
+	ErrPreconditionFailed         = errors.New("precondition failed")
 
 	ErrInvalidValue = func(command, param string) error {
 		return fmt.Errorf("invalid value for a parameter in '%s' command for %s parameter", strings.ToUpper(command), strings.ToUpper(param))
@@ -49,6 +49,23 @@ func (ep *Epoll) Subscribe(event Event) error {
 	return nil
 }
 
+// Modify replaces the operations monitored on the file descriptor
+func (ep *Epoll) Modify(event Event) error {
+	nativeEvent := event.toNative()
+	if err := syscall.EpollCtl(ep.fd, syscall.EPOLL_CTL_MOD, event.Fd, &nativeEvent); err != nil {
+		return fmt.Errorf("epoll modify: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the events of the file descriptor
+func (ep *Epoll) Unsubscribe(event Event) error {
+	if err := syscall.EpollCtl(ep.fd, syscall.EPOLL_CTL_DEL, event.Fd, nil); err != nil {
+		return fmt.Errorf("epoll unsubscribe: %w", err)
+	}
+	return nil
+}
+
 // Poll polls for all the subscribed events simultaneously
 // and returns all the events that were triggered
 // It blocks until at least one event is triggered or the timeout is reached
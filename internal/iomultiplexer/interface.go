@@ -12,6 +12,14 @@ type IOMultiplexer interface {
 	// When the event is triggered, the Poll method will return it
 	Subscribe(event Event) error
 
+	// Modify replaces the operations monitored on the file descriptor of
+	// the given event, which is already subscribed
+	Modify(event Event) error
+
+	// Unsubscribe unsubscribes from the events of the file descriptor
+	// of the given event, which is no longer returned by the Poll method
+	Unsubscribe(event Event) error
+
 	// Poll polls for all the subscribed events simultaneously
 	// and returns all the events that were triggered
 	// It blocks until at least one event is triggered or the timeout is reached
@@ -48,6 +48,29 @@ func (kq *KQueue) Subscribe(event Event) error {
 	return nil
 }
 
+// Modify replaces the operations monitored on the file descriptor. The
+// kqueue filters are monitored separately, hence the write filter is added or
+// deleted, the read one being always monitored.
+func (kq *KQueue) Modify(event Event) error {
+	flags := uint16(syscall.EV_DELETE)
+	if event.Op&OpWrite != 0 {
+		flags = syscall.EV_ADD
+	}
+	change := Event{Fd: event.Fd, Op: OpWrite}.toNative(flags)
+	if _, err := syscall.Kevent(kq.fd, []syscall.Kevent_t{change}, nil, nil); err != nil && err != syscall.ENOENT {
+		return fmt.Errorf("kqueue modify: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the events of the file descriptor
+func (kq *KQueue) Unsubscribe(event Event) error {
+	if _, err := syscall.Kevent(kq.fd, []syscall.Kevent_t{event.toNative(syscall.EV_DELETE)}, nil, nil); err != nil {
+		return fmt.Errorf("kqueue unsubscribe: %w", err)
+	}
+	return nil
+}
+
 // Poll polls for all the subscribed events simultaneously
 // and returns all the events that were triggered
 // It blocks until at least one event is triggered or the timeout is reached
@@ -37,6 +37,10 @@ type Obj struct {
 	// Value holds the actual content or data of the object, which can be of any type.
 	// This allows flexibility in storing various kinds of objects (simple or complex).
 	Value interface{}
+
+	// Version is bumped by the store on every mutation of the object and is
+	// used by the IFVERSION option for optimistic concurrency control.
+	Version uint64
 }
 
 // ExtendedObj is an extension of the `Obj` struct, designed to add extra
@@ -94,29 +98,45 @@ const (
 	ObjTypeDequeue
 	ObjTypeHLL
 	ObjTypeFloat
+	ObjTypeStream
 )
 
+var objectTypeNames = [...]string{
+	"string",
+	"",
+	"",
+	"json",
+	"bytes",
+	"int",
+	"set",
+	"ssmap",
+	"sortedset",
+	"countminsketch",
+	"bf",
+	"dequeue",
+	"hll",
+	"float",
+	"stream",
+}
+
 // String returns the name of the object type as a string
 func (ot ObjectType) String() string {
-	names := [...]string{
-		"string",
-		"",
-		"",
-		"json",
-		"bytes",
-		"int",
-		"set",
-		"ssmap",
-		"sortedset",
-		"countminsketch",
-		"bf",
-		"dequeue",
-		"hll",
-		"float",
+	if ot < ObjectType(len(objectTypeNames)) {
+		return objectTypeNames[ot]
 	}
+	return "Unknown"
+}
 
-	if ot < ObjectType(len(names)) {
-		return names[ot]
+// ParseObjectType returns the object type for the name returned by String.
+// The bool return value is false if the name does not match any type.
+func ParseObjectType(name string) (ObjectType, bool) {
+	if name == "" {
+		return 0, false
 	}
-	return "Unknown"
+	for i, n := range objectTypeNames {
+		if n == name {
+			return ObjectType(i), true
+		}
+	}
+	return 0, false
 }
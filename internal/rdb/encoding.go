@@ -0,0 +1,353 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package rdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var errTruncated = errors.New("truncated encoded value")
+
+// lzfDecompress decompresses the LZF compressed b whose
+// uncompressed length is ulen.
+func lzfDecompress(b []byte, ulen int) ([]byte, error) {
+	out := make([]byte, 0, ulen)
+	for i := 0; i < len(b); {
+		ctrl := int(b[i])
+		i++
+
+		// Literal run of ctrl+1 bytes
+		if ctrl < 1<<5 {
+			n := ctrl + 1
+			if i+n > len(b) {
+				return nil, errTruncated
+			}
+			out = append(out, b[i:i+n]...)
+			i += n
+			continue
+		}
+
+		// Back reference
+		n := ctrl >> 5
+		if n == 7 {
+			if i >= len(b) {
+				return nil, errTruncated
+			}
+			n += int(b[i])
+			i++
+		}
+		if i >= len(b) {
+			return nil, errTruncated
+		}
+		ref := len(out) - ((ctrl&0x1F)<<8 | int(b[i])) - 1
+		i++
+		if ref < 0 {
+			return nil, errors.New("invalid lzf back reference")
+		}
+		// The reference can overlap with the bytes being written,
+		// so copy byte by byte.
+		for j := 0; j < n+2; j++ {
+			out = append(out, out[ref+j])
+		}
+	}
+
+	if len(out) != ulen {
+		return nil, fmt.Errorf("lzf: expected %d bytes, got %d", ulen, len(out))
+	}
+	return out, nil
+}
+
+// decodeZiplist returns the entries of a ziplist.
+func decodeZiplist(b []byte) ([]string, error) {
+	// zlbytes (4), zltail (4), zllen (2)
+	if len(b) < 11 {
+		return nil, errTruncated
+	}
+
+	var items []string
+	i := 10
+	for {
+		if i >= len(b) {
+			return nil, errTruncated
+		}
+		if b[i] == 0xFF {
+			return items, nil
+		}
+
+		// Length of the previous entry, 1 or 5 bytes
+		if b[i] == 0xFE {
+			i += 5
+		} else {
+			i++
+		}
+		if i >= len(b) {
+			return nil, errTruncated
+		}
+
+		enc := b[i]
+		i++
+		var n int
+		switch enc >> 6 {
+		case 0:
+			n = int(enc & 0x3F)
+		case 1:
+			if i >= len(b) {
+				return nil, errTruncated
+			}
+			n = int(enc&0x3F)<<8 | int(b[i])
+			i++
+		case 2:
+			if i+4 > len(b) {
+				return nil, errTruncated
+			}
+			n = int(binary.BigEndian.Uint32(b[i:]))
+			i += 4
+		default:
+			v, size, err := ziplistInt(enc, b[i:])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, strconv.FormatInt(v, 10))
+			i += size
+			continue
+		}
+
+		if i+n > len(b) {
+			return nil, errTruncated
+		}
+		items = append(items, string(b[i:i+n]))
+		i += n
+	}
+}
+
+// ziplistInt decodes the integer entry with encoding enc from b and
+// returns the value and the number of bytes it occupies.
+func ziplistInt(enc byte, b []byte) (int64, int, error) {
+	var size int
+	switch enc {
+	case 0xC0:
+		size = 2
+	case 0xD0:
+		size = 4
+	case 0xE0:
+		size = 8
+	case 0xF0:
+		size = 3
+	case 0xFE:
+		size = 1
+	default:
+		if enc >= 0xF1 && enc <= 0xFD {
+			return int64(enc&0x0F) - 1, 0, nil
+		}
+		return 0, 0, fmt.Errorf("unknown ziplist encoding 0x%X", enc)
+	}
+
+	if len(b) < size {
+		return 0, 0, errTruncated
+	}
+	switch size {
+	case 1:
+		return int64(int8(b[0])), size, nil
+	case 2:
+		return int64(int16(binary.LittleEndian.Uint16(b))), size, nil
+	case 3:
+		v := int32(b[0]) | int32(b[1])<<8 | int32(int8(b[2]))<<16
+		return int64(v), size, nil
+	case 4:
+		return int64(int32(binary.LittleEndian.Uint32(b))), size, nil
+	default:
+		return int64(binary.LittleEndian.Uint64(b)), size, nil
+	}
+}
+
+// decodeListpack returns the entries of a listpack.
+func decodeListpack(b []byte) ([]string, error) {
+	// total bytes (4), number of elements (2)
+	if len(b) < 7 {
+		return nil, errTruncated
+	}
+
+	var items []string
+	i := 6
+	for {
+		if i >= len(b) {
+			return nil, errTruncated
+		}
+		enc := b[i]
+		if enc == 0xFF {
+			return items, nil
+		}
+
+		start := i
+		var strLen int
+		var isInt bool
+		var v int64
+		switch {
+		case enc&0x80 == 0:
+			// 7 bit unsigned int
+			isInt, v = true, int64(enc&0x7F)
+			i++
+		case enc&0xC0 == 0x80:
+			// 6 bit string length
+			strLen = int(enc & 0x3F)
+			i++
+		case enc&0xE0 == 0xC0:
+			// 13 bit signed int
+			if i+2 > len(b) {
+				return nil, errTruncated
+			}
+			u := int64(enc&0x1F)<<8 | int64(b[i+1])
+			if u >= 1<<12 {
+				u -= 1 << 13
+			}
+			isInt, v = true, u
+			i += 2
+		case enc&0xF0 == 0xE0:
+			// 12 bit string length
+			if i+2 > len(b) {
+				return nil, errTruncated
+			}
+			strLen = int(enc&0x0F)<<8 | int(b[i+1])
+			i += 2
+		case enc == 0xF0:
+			// 32 bit string length
+			if i+5 > len(b) {
+				return nil, errTruncated
+			}
+			strLen = int(binary.LittleEndian.Uint32(b[i+1:]))
+			i += 5
+		case enc >= 0xF1 && enc <= 0xF4:
+			size := [...]int{2, 3, 4, 8}[enc-0xF1]
+			if i+1+size > len(b) {
+				return nil, errTruncated
+			}
+			d := b[i+1 : i+1+size]
+			switch size {
+			case 2:
+				v = int64(int16(binary.LittleEndian.Uint16(d)))
+			case 3:
+				v = int64(int32(d[0]) | int32(d[1])<<8 | int32(int8(d[2]))<<16)
+			case 4:
+				v = int64(int32(binary.LittleEndian.Uint32(d)))
+			default:
+				v = int64(binary.LittleEndian.Uint64(d))
+			}
+			isInt = true
+			i += 1 + size
+		default:
+			return nil, fmt.Errorf("unknown listpack encoding 0x%X", enc)
+		}
+
+		if isInt {
+			items = append(items, strconv.FormatInt(v, 10))
+		} else {
+			if i+strLen > len(b) {
+				return nil, errTruncated
+			}
+			items = append(items, string(b[i:i+strLen]))
+			i += strLen
+		}
+
+		// Skip the backlen which encodes the size of the entry
+		i += listpackBacklenSize(i - start)
+	}
+}
+
+// listpackBacklenSize returns the number of bytes used to
+// store the length of an entry of size n.
+func listpackBacklenSize(n int) int {
+	switch {
+	case n <= 127:
+		return 1
+	case n < 16383:
+		return 2
+	case n < 2097151:
+		return 3
+	case n < 268435455:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// decodeIntset returns the members of an intset.
+func decodeIntset(b []byte) ([]string, error) {
+	if len(b) < 8 {
+		return nil, errTruncated
+	}
+	size := int(binary.LittleEndian.Uint32(b))
+	n := int(binary.LittleEndian.Uint32(b[4:]))
+	if size != 2 && size != 4 && size != 8 {
+		return nil, fmt.Errorf("invalid intset encoding %d", size)
+	}
+	if len(b) < 8+n*size {
+		return nil, errTruncated
+	}
+
+	items := make([]string, n)
+	for i := 0; i < n; i++ {
+		d := b[8+i*size:]
+		var v int64
+		switch size {
+		case 2:
+			v = int64(int16(binary.LittleEndian.Uint16(d)))
+		case 4:
+			v = int64(int32(binary.LittleEndian.Uint32(d)))
+		default:
+			v = int64(binary.LittleEndian.Uint64(d))
+		}
+		items[i] = strconv.FormatInt(v, 10)
+	}
+	return items, nil
+}
+
+// decodeZipmap returns the alternating keys and values of a zipmap.
+func decodeZipmap(b []byte) ([]string, error) {
+	if len(b) < 2 {
+		return nil, errTruncated
+	}
+
+	var items []string
+	i := 1
+	for {
+		if i >= len(b) {
+			return nil, errTruncated
+		}
+		if b[i] == 0xFF {
+			return items, nil
+		}
+
+		for _, isValue := range []bool{false, true} {
+			if i >= len(b) {
+				return nil, errTruncated
+			}
+			n := int(b[i])
+			i++
+			if n == 254 {
+				if i+4 > len(b) {
+					return nil, errTruncated
+				}
+				n = int(binary.LittleEndian.Uint32(b[i:]))
+				i += 4
+			}
+
+			free := 0
+			if isValue {
+				if i >= len(b) {
+					return nil, errTruncated
+				}
+				free = int(b[i])
+				i++
+			}
+			if i+n+free > len(b) {
+				return nil, errTruncated
+			}
+			items = append(items, string(b[i:i+n]))
+			i += n + free
+		}
+	}
+}
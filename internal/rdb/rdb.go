@@ -0,0 +1,689 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+// Package rdb implements a parser for the Redis RDB file format.
+//
+// The parser understands RDB versions up to 12 (Redis 7.4) and decodes
+// strings, lists, sets, hashes and sorted sets in all their on-disk
+// encodings (plain, ziplist, listpack, intset, zipmap and quicklist)
+// along with the key expiries. Streams are parsed and skipped, as their
+// entries and consumer groups are not decoded. Modules are not supported,
+// and the parsing stops with ErrUnsupportedType on a module value.
+package rdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+const maxSupportedVersion = 12
+
+// Opcodes that can appear in place of a value type.
+const (
+	opSlotInfo      = 0xF4
+	opFunction2     = 0xF5
+	opFunctionPreGA = 0xF6
+	opModuleAux     = 0xF7
+	opIdle          = 0xF8
+	opFreq          = 0xF9
+	opAux           = 0xFA
+	opResizeDB      = 0xFB
+	opExpireTimeMs  = 0xFC
+	opExpireTime    = 0xFD
+	opSelectDB      = 0xFE
+	opEOF           = 0xFF
+)
+
+// Value types as encoded in the RDB file.
+const (
+	typeString          = 0
+	typeList            = 1
+	typeSet             = 2
+	typeZSet            = 3
+	typeHash            = 4
+	typeZSet2           = 5
+	typeModulePreGA     = 6
+	typeModule2         = 7
+	typeHashZipmap      = 9
+	typeListZiplist     = 10
+	typeSetIntset       = 11
+	typeZSetZiplist     = 12
+	typeHashZiplist     = 13
+	typeListQuicklist   = 14
+	typeStreamListpacks = 15
+	typeHashListpack    = 16
+	typeZSetListpack    = 17
+	typeListQuicklist2  = 18
+	typeStreamListpack2 = 19
+	typeSetListpack     = 20
+	typeStreamListpack3 = 21
+	typeHashMetadata    = 24
+	typeHashListpackEx  = 25
+)
+
+// Special string encodings, used when the top two bits of the length are 11.
+const (
+	encInt8  = 0
+	encInt16 = 1
+	encInt32 = 2
+	encLZF   = 3
+)
+
+// Quicklist node containers.
+const (
+	quicklistNodePlain  = 1
+	quicklistNodePacked = 2
+)
+
+// ErrUnsupportedType is returned when the file holds a value that
+// cannot be decoded, such as a module type.
+var ErrUnsupportedType = errors.New("unsupported rdb value type")
+
+// Kind is the kind of value held by an Entry.
+type Kind uint8
+
+const (
+	KindString Kind = iota
+	KindList
+	KindSet
+	KindHash
+	KindZSet
+)
+
+func (k Kind) String() string {
+	return [...]string{"string", "list", "set", "hash", "zset"}[k]
+}
+
+// ZMember is a member of a sorted set along with its score.
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+// Entry is a single key read from the RDB file.
+//
+// Depending on the Kind, Value holds a string (KindString),
+// []string (KindList, KindSet), map[string]string (KindHash)
+// or []ZMember (KindZSet).
+type Entry struct {
+	DB    int
+	Key   string
+	Kind  Kind
+	Value interface{}
+
+	// ExpiresAt is the absolute expiry of the key in milliseconds
+	// since epoch. It is 0 if the key does not expire.
+	ExpiresAt int64
+}
+
+// Parser reads entries from an RDB file.
+type Parser struct {
+	r       *bufio.Reader
+	version int
+	buf     [8]byte
+}
+
+// NewParser creates a parser that reads the RDB file from r.
+func NewParser(r io.Reader) *Parser {
+	return &Parser{r: bufio.NewReader(r)}
+}
+
+// Version returns the RDB version of the file. It is populated
+// once Parse has read the header.
+func (p *Parser) Version() int {
+	return p.version
+}
+
+// Parse reads the whole file and calls cb for every key.
+// Parsing stops at the first error returned by cb.
+func (p *Parser) Parse(cb func(e *Entry) error) error {
+	if err := p.readHeader(); err != nil {
+		return err
+	}
+
+	var db int
+	var expiresAt int64
+	for {
+		t, err := p.r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("rdb: reading opcode: %w", err)
+		}
+
+		switch t {
+		case opEOF:
+			// The 8 byte CRC64 checksum follows for versions >= 5,
+			// we do not verify it.
+			return nil
+		case opSelectDB:
+			n, err := p.readLength()
+			if err != nil {
+				return err
+			}
+			db = int(n)
+			continue
+		case opResizeDB:
+			if _, err := p.readLength(); err != nil {
+				return err
+			}
+			if _, err := p.readLength(); err != nil {
+				return err
+			}
+			continue
+		case opSlotInfo:
+			for i := 0; i < 3; i++ {
+				if _, err := p.readLength(); err != nil {
+					return err
+				}
+			}
+			continue
+		case opAux:
+			if _, err := p.readString(); err != nil {
+				return err
+			}
+			if _, err := p.readString(); err != nil {
+				return err
+			}
+			continue
+		case opFunction2:
+			if _, err := p.readString(); err != nil {
+				return err
+			}
+			continue
+		case opFunctionPreGA, opModuleAux:
+			return fmt.Errorf("%w: opcode 0x%X", ErrUnsupportedType, t)
+		case opExpireTime:
+			if _, err := io.ReadFull(p.r, p.buf[:4]); err != nil {
+				return err
+			}
+			expiresAt = int64(binary.LittleEndian.Uint32(p.buf[:4])) * 1000
+			continue
+		case opExpireTimeMs:
+			if _, err := io.ReadFull(p.r, p.buf[:8]); err != nil {
+				return err
+			}
+			expiresAt = int64(binary.LittleEndian.Uint64(p.buf[:8]))
+			continue
+		case opIdle:
+			if _, err := p.readLength(); err != nil {
+				return err
+			}
+			continue
+		case opFreq:
+			if _, err := p.r.ReadByte(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, err := p.readString()
+		if err != nil {
+			return fmt.Errorf("rdb: reading key: %w", err)
+		}
+
+		e := &Entry{DB: db, Key: string(key), ExpiresAt: expiresAt}
+		expiresAt = 0
+
+		skip, err := p.readValue(t, e)
+		if err != nil {
+			return fmt.Errorf("rdb: reading value of key %s: %w", e.Key, err)
+		}
+		if skip {
+			continue
+		}
+		if err := cb(e); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Parser) readHeader() error {
+	var h [9]byte
+	if _, err := io.ReadFull(p.r, h[:]); err != nil {
+		return fmt.Errorf("rdb: reading header: %w", err)
+	}
+	if string(h[:5]) != "REDIS" {
+		return errors.New("rdb: invalid header, not an rdb file")
+	}
+	v, err := strconv.Atoi(string(h[5:]))
+	if err != nil {
+		return fmt.Errorf("rdb: invalid version %q", h[5:])
+	}
+	if v < 1 || v > maxSupportedVersion {
+		return fmt.Errorf("rdb: unsupported version %d", v)
+	}
+	p.version = v
+	return nil
+}
+
+// readValue decodes the value of type t into e. It returns true if
+// the value was read but should not be handed over to the caller.
+//
+//nolint:gocyclo
+func (p *Parser) readValue(t byte, e *Entry) (bool, error) {
+	switch t {
+	case typeString:
+		b, err := p.readString()
+		e.Kind, e.Value = KindString, string(b)
+		return false, err
+
+	case typeList, typeSet:
+		items, err := p.readStringList()
+		e.Kind, e.Value = KindList, items
+		if t == typeSet {
+			e.Kind = KindSet
+		}
+		return false, err
+
+	case typeZSet, typeZSet2:
+		n, err := p.readLength()
+		if err != nil {
+			return false, err
+		}
+		members := make([]ZMember, 0, n)
+		for i := uint64(0); i < n; i++ {
+			m, err := p.readString()
+			if err != nil {
+				return false, err
+			}
+			var score float64
+			if t == typeZSet2 {
+				score, err = p.readBinaryDouble()
+			} else {
+				score, err = p.readStringDouble()
+			}
+			if err != nil {
+				return false, err
+			}
+			members = append(members, ZMember{Member: string(m), Score: score})
+		}
+		e.Kind, e.Value = KindZSet, members
+		return false, nil
+
+	case typeHash:
+		// The length of a hash is the number of field-value pairs.
+		n, err := p.readLength()
+		if err != nil {
+			return false, err
+		}
+		items, err := p.readStrings(int(n) * 2)
+		e.Kind, e.Value = KindHash, pairsToMap(items)
+		return false, err
+
+	case typeHashMetadata:
+		// Hashes with field expiries, the expiry of the fields is dropped.
+		if _, err := io.ReadFull(p.r, p.buf[:8]); err != nil {
+			return false, err
+		}
+		n, err := p.readLength()
+		if err != nil {
+			return false, err
+		}
+		m := make(map[string]string, n)
+		for i := uint64(0); i < n; i++ {
+			if _, err := p.readLength(); err != nil {
+				return false, err
+			}
+			items, err := p.readStrings(2)
+			if err != nil {
+				return false, err
+			}
+			m[items[0]] = items[1]
+		}
+		e.Kind, e.Value = KindHash, m
+		return false, nil
+
+	case typeHashZipmap:
+		b, err := p.readString()
+		if err != nil {
+			return false, err
+		}
+		items, err := decodeZipmap(b)
+		e.Kind, e.Value = KindHash, pairsToMap(items)
+		return false, err
+
+	case typeListZiplist, typeSetIntset, typeZSetZiplist, typeHashZiplist,
+		typeHashListpack, typeZSetListpack, typeSetListpack, typeHashListpackEx:
+		return false, p.readPacked(t, e)
+
+	case typeListQuicklist, typeListQuicklist2:
+		n, err := p.readLength()
+		if err != nil {
+			return false, err
+		}
+		var items []string
+		for i := uint64(0); i < n; i++ {
+			container := uint64(quicklistNodePacked)
+			if t == typeListQuicklist2 {
+				if container, err = p.readLength(); err != nil {
+					return false, err
+				}
+			}
+			b, err := p.readString()
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case container == quicklistNodePlain:
+				items = append(items, string(b))
+			case t == typeListQuicklist:
+				node, err := decodeZiplist(b)
+				if err != nil {
+					return false, err
+				}
+				items = append(items, node...)
+			default:
+				node, err := decodeListpack(b)
+				if err != nil {
+					return false, err
+				}
+				items = append(items, node...)
+			}
+		}
+		e.Kind, e.Value = KindList, items
+		return false, nil
+
+	case typeStreamListpacks, typeStreamListpack2, typeStreamListpack3:
+		return true, p.skipStream(t)
+
+	case typeModulePreGA, typeModule2:
+		return false, fmt.Errorf("%w: module", ErrUnsupportedType)
+	}
+	return false, fmt.Errorf("%w: %d", ErrUnsupportedType, t)
+}
+
+// readPacked decodes the values that are stored as a single string
+// holding a ziplist, listpack or intset.
+func (p *Parser) readPacked(t byte, e *Entry) error {
+	if t == typeHashListpackEx {
+		// Minimum expiry of the fields, which is dropped.
+		if _, err := io.ReadFull(p.r, p.buf[:8]); err != nil {
+			return err
+		}
+	}
+
+	b, err := p.readString()
+	if err != nil {
+		return err
+	}
+
+	var items []string
+	switch t {
+	case typeSetIntset:
+		items, err = decodeIntset(b)
+	case typeListZiplist, typeZSetZiplist, typeHashZiplist:
+		items, err = decodeZiplist(b)
+	default:
+		items, err = decodeListpack(b)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch t {
+	case typeListZiplist:
+		e.Kind, e.Value = KindList, items
+	case typeSetIntset, typeSetListpack:
+		e.Kind, e.Value = KindSet, items
+	case typeHashZiplist, typeHashListpack:
+		e.Kind, e.Value = KindHash, pairsToMap(items)
+	case typeHashListpackEx:
+		// Entries are stored as field, value, ttl triplets.
+		m := make(map[string]string, len(items)/3)
+		for i := 0; i+2 < len(items); i += 3 {
+			m[items[i]] = items[i+1]
+		}
+		e.Kind, e.Value = KindHash, m
+	case typeZSetZiplist, typeZSetListpack:
+		members := make([]ZMember, 0, len(items)/2)
+		for i := 0; i+1 < len(items); i += 2 {
+			score, err := strconv.ParseFloat(items[i+1], 64)
+			if err != nil {
+				return fmt.Errorf("invalid sorted set score %q", items[i+1])
+			}
+			members = append(members, ZMember{Member: items[i], Score: score})
+		}
+		e.Kind, e.Value = KindZSet, members
+	}
+	return nil
+}
+
+func (p *Parser) skipStream(t byte) error {
+	// Listpacks holding the entries, keyed by the master ID.
+	n, err := p.readLength()
+	if err != nil {
+		return err
+	}
+	if _, err := p.readStrings(int(n) * 2); err != nil {
+		return err
+	}
+
+	// Length, last ID and for newer versions first ID, max deleted ID
+	// and the number of entries added.
+	lengths := 3
+	if t >= typeStreamListpack2 {
+		lengths += 5
+	}
+	for i := 0; i < lengths; i++ {
+		if _, err := p.readLength(); err != nil {
+			return err
+		}
+	}
+
+	groups, err := p.readLength()
+	if err != nil {
+		return err
+	}
+	for g := uint64(0); g < groups; g++ {
+		if _, err := p.readString(); err != nil {
+			return err
+		}
+		// Last delivered ID and entries read
+		lengths := 2
+		if t >= typeStreamListpack2 {
+			lengths++
+		}
+		for i := 0; i < lengths; i++ {
+			if _, err := p.readLength(); err != nil {
+				return err
+			}
+		}
+
+		// Group pending entries: 16 byte ID, 8 byte delivery time and delivery count
+		pel, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		for i := uint64(0); i < pel; i++ {
+			if _, err := p.r.Discard(24); err != nil {
+				return err
+			}
+			if _, err := p.readLength(); err != nil {
+				return err
+			}
+		}
+
+		consumers, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		for c := uint64(0); c < consumers; c++ {
+			if _, err := p.readString(); err != nil {
+				return err
+			}
+			// Seen time and for version 3 the active time
+			skip := 8
+			if t >= typeStreamListpack3 {
+				skip += 8
+			}
+			if _, err := p.r.Discard(skip); err != nil {
+				return err
+			}
+			// Consumer pending entries: 16 byte ID
+			pel, err := p.readLength()
+			if err != nil {
+				return err
+			}
+			if _, err := p.r.Discard(int(pel) * 16); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readLength reads a length encoded value.
+func (p *Parser) readLength() (uint64, error) {
+	n, special, err := p.readLengthOrEncoding()
+	if err != nil {
+		return 0, err
+	}
+	if special {
+		return 0, fmt.Errorf("unexpected string encoding %d in place of a length", n)
+	}
+	return n, nil
+}
+
+// readLengthOrEncoding reads a length encoded value. If the top two bits
+// are set, the value is the special string encoding and special is true.
+func (p *Parser) readLengthOrEncoding() (n uint64, special bool, err error) {
+	b, err := p.r.ReadByte()
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch b >> 6 {
+	case 0:
+		return uint64(b & 0x3F), false, nil
+	case 1:
+		b2, err := p.r.ReadByte()
+		if err != nil {
+			return 0, false, err
+		}
+		return uint64(b&0x3F)<<8 | uint64(b2), false, nil
+	case 2:
+		switch b {
+		case 0x80:
+			if _, err := io.ReadFull(p.r, p.buf[:4]); err != nil {
+				return 0, false, err
+			}
+			return uint64(binary.BigEndian.Uint32(p.buf[:4])), false, nil
+		case 0x81:
+			if _, err := io.ReadFull(p.r, p.buf[:8]); err != nil {
+				return 0, false, err
+			}
+			return binary.BigEndian.Uint64(p.buf[:8]), false, nil
+		}
+		return 0, false, fmt.Errorf("unknown length encoding 0x%X", b)
+	default:
+		return uint64(b & 0x3F), true, nil
+	}
+}
+
+// readString reads a string encoded value which can be a plain string,
+// an integer or an LZF compressed string.
+func (p *Parser) readString() ([]byte, error) {
+	n, special, err := p.readLengthOrEncoding()
+	if err != nil {
+		return nil, err
+	}
+
+	if !special {
+		b := make([]byte, n)
+		_, err := io.ReadFull(p.r, b)
+		return b, err
+	}
+
+	switch n {
+	case encInt8:
+		b, err := p.r.ReadByte()
+		return strconv.AppendInt(nil, int64(int8(b)), 10), err
+	case encInt16:
+		if _, err := io.ReadFull(p.r, p.buf[:2]); err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(nil, int64(int16(binary.LittleEndian.Uint16(p.buf[:2]))), 10), nil
+	case encInt32:
+		if _, err := io.ReadFull(p.r, p.buf[:4]); err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(nil, int64(int32(binary.LittleEndian.Uint32(p.buf[:4]))), 10), nil
+	case encLZF:
+		clen, err := p.readLength()
+		if err != nil {
+			return nil, err
+		}
+		ulen, err := p.readLength()
+		if err != nil {
+			return nil, err
+		}
+		compressed := make([]byte, clen)
+		if _, err := io.ReadFull(p.r, compressed); err != nil {
+			return nil, err
+		}
+		return lzfDecompress(compressed, int(ulen))
+	}
+	return nil, fmt.Errorf("unknown string encoding %d", n)
+}
+
+// readStringList reads a length followed by as many strings.
+func (p *Parser) readStringList() ([]string, error) {
+	n, err := p.readLength()
+	if err != nil {
+		return nil, err
+	}
+	return p.readStrings(int(n))
+}
+
+func (p *Parser) readStrings(n int) ([]string, error) {
+	items := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := p.readString()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, string(b))
+	}
+	return items, nil
+}
+
+// readStringDouble reads a score stored as a string, used by the
+// older sorted set encoding.
+func (p *Parser) readStringDouble() (float64, error) {
+	n, err := p.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case 253:
+		return math.NaN(), nil
+	case 254:
+		return math.Inf(1), nil
+	case 255:
+		return math.Inf(-1), nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(p.r, b); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(string(b), 64)
+}
+
+func (p *Parser) readBinaryDouble() (float64, error) {
+	if _, err := io.ReadFull(p.r, p.buf[:8]); err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(p.buf[:8])), nil
+}
+
+func pairsToMap(items []string) map[string]string {
+	m := make(map[string]string, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		m[items[i]] = items[i+1]
+	}
+	return m
+}
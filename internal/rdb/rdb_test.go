@@ -0,0 +1,200 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package rdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rdbBuilder writes the RDB encoding of values for the tests.
+type rdbBuilder struct {
+	bytes.Buffer
+}
+
+func (b *rdbBuilder) length(n int) {
+	switch {
+	case n < 1<<6:
+		b.WriteByte(byte(n))
+	case n < 1<<14:
+		b.WriteByte(byte(n>>8) | 0x40)
+		b.WriteByte(byte(n))
+	default:
+		b.WriteByte(0x80)
+		_ = binary.Write(b, binary.BigEndian, uint32(n))
+	}
+}
+
+func (b *rdbBuilder) str(s string) {
+	b.length(len(s))
+	b.WriteString(s)
+}
+
+func listpack(entries ...interface{}) string {
+	var b bytes.Buffer
+	b.Write(make([]byte, 6))
+	for _, e := range entries {
+		switch v := e.(type) {
+		case int:
+			// 7 bit unsigned int
+			b.WriteByte(byte(v))
+			b.WriteByte(1)
+		case string:
+			// 6 bit string length
+			b.WriteByte(0x80 | byte(len(v)))
+			b.WriteString(v)
+			b.WriteByte(byte(1 + len(v)))
+		}
+	}
+	b.WriteByte(0xFF)
+	out := b.Bytes()
+	binary.LittleEndian.PutUint32(out, uint32(len(out)))
+	binary.LittleEndian.PutUint16(out[4:], uint16(len(entries)))
+	return string(out)
+}
+
+func ziplist(entries ...interface{}) string {
+	var b bytes.Buffer
+	b.Write(make([]byte, 10))
+	for _, e := range entries {
+		b.WriteByte(0)
+		switch v := e.(type) {
+		case int:
+			// 16 bit int
+			b.WriteByte(0xC0)
+			_ = binary.Write(&b, binary.LittleEndian, int16(v))
+		case string:
+			b.WriteByte(byte(len(v)))
+			b.WriteString(v)
+		}
+	}
+	b.WriteByte(0xFF)
+	return string(b.Bytes())
+}
+
+func parseAll(t *testing.T, b []byte) map[string]*Entry {
+	t.Helper()
+	entries := map[string]*Entry{}
+	err := NewParser(bytes.NewReader(b)).Parse(func(e *Entry) error {
+		entries[e.Key] = e
+		return nil
+	})
+	assert.NoError(t, err)
+	return entries
+}
+
+func TestParse(t *testing.T) {
+	var b rdbBuilder
+	b.WriteString("REDIS0011")
+	b.WriteByte(opAux)
+	b.str("redis-ver")
+	b.str("7.2.4")
+	b.WriteByte(opSelectDB)
+	b.length(0)
+	b.WriteByte(opResizeDB)
+	b.length(9)
+	b.length(1)
+
+	// Plain string with an expiry
+	b.WriteByte(opExpireTimeMs)
+	_ = binary.Write(&b, binary.LittleEndian, uint64(4102444800000))
+	b.WriteByte(typeString)
+	b.str("str")
+	b.str("hello")
+
+	// Integer encoded string
+	b.WriteByte(typeString)
+	b.str("int")
+	b.WriteByte(0xC0 | encInt16)
+	_ = binary.Write(&b, binary.LittleEndian, int16(-1234))
+
+	// LZF compressed string: literal "abc" followed by a back reference
+	// copying 6 bytes starting 3 bytes back.
+	b.WriteByte(typeString)
+	b.str("lzf")
+	b.WriteByte(0xC0 | encLZF)
+	b.length(6)
+	b.length(9)
+	b.Write([]byte{2, 'a', 'b', 'c', 4 << 5, 2})
+
+	b.WriteByte(typeList)
+	b.str("list")
+	b.length(2)
+	b.str("a")
+	b.str("b")
+
+	b.WriteByte(typeHash)
+	b.str("hash")
+	b.length(1)
+	b.str("f1")
+	b.str("v1")
+
+	b.WriteByte(typeZSet2)
+	b.str("zset")
+	b.length(1)
+	b.str("m1")
+	_ = binary.Write(&b, binary.LittleEndian, math.Float64bits(1.5))
+
+	b.WriteByte(typeHashListpack)
+	b.str("hashlp")
+	b.str(listpack("f1", "v1", "f2", 42))
+
+	b.WriteByte(typeZSetZiplist)
+	b.str("zsetzl")
+	b.str(ziplist("m1", 10, "m2", -20))
+
+	b.WriteByte(typeSetIntset)
+	b.str("intset")
+	intset := []byte{2, 0, 0, 0, 2, 0, 0, 0, 0xFF, 0xFF, 7, 0}
+	b.str(string(intset))
+
+	b.WriteByte(opSelectDB)
+	b.length(1)
+	b.WriteByte(typeListQuicklist2)
+	b.str("ql")
+	b.length(2)
+	b.length(quicklistNodePacked)
+	b.str(listpack("a", 1))
+	b.length(quicklistNodePlain)
+	b.str("plain")
+
+	b.WriteByte(opEOF)
+	b.Write(make([]byte, 8))
+
+	entries := parseAll(t, b.Bytes())
+	assert.Len(t, entries, 10)
+
+	assert.Equal(t, &Entry{Key: "str", Kind: KindString, Value: "hello", ExpiresAt: 4102444800000}, entries["str"])
+	assert.Equal(t, "-1234", entries["int"].Value)
+	assert.Equal(t, "abcabcabc", entries["lzf"].Value)
+	assert.Equal(t, []string{"a", "b"}, entries["list"].Value)
+	assert.Equal(t, map[string]string{"f1": "v1"}, entries["hash"].Value)
+	assert.Equal(t, []ZMember{{"m1", 1.5}}, entries["zset"].Value)
+	assert.Equal(t, map[string]string{"f1": "v1", "f2": "42"}, entries["hashlp"].Value)
+	assert.Equal(t, []ZMember{{"m1", 10}, {"m2", -20}}, entries["zsetzl"].Value)
+	assert.Equal(t, KindSet, entries["intset"].Kind)
+	assert.Equal(t, []string{"-1", "7"}, entries["intset"].Value)
+	assert.Equal(t, 1, entries["ql"].DB)
+	assert.Equal(t, []string{"a", "1", "plain"}, entries["ql"].Value)
+	assert.Zero(t, entries["int"].ExpiresAt)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"invalid header":      []byte("NOTRD0011"),
+		"unsupported version": []byte("REDIS0099"),
+		"truncated":           []byte("REDIS0011\x00\x03k"),
+		"module type":         []byte("REDIS0011\x07\x01k"),
+	}
+	for name, b := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewParser(bytes.NewReader(b)).Parse(func(*Entry) error { return nil })
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/iomultiplexer"
+	"github.com/dicedb/dicedb-go/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// prefixSize is the size of the big-endian length prefixing every message.
+const prefixSize = 4
+
+// maxReadsPerEvent bounds the reads made on a connection for one readiness
+// event, so that a client sending a large pipeline does not hold back the others.
+const maxReadsPerEvent = 16
+
+// closeFlushTimeout bounds the time the results pending when a connection is
+// closed are given to be written, the client being cut off past it.
+const closeFlushTimeout = time.Second
+
+// errOutputBufferLimit fails the connection of a client whose pending results
+// exceed the client-output-buffer-limit-bytes configuration.
+var errOutputBufferLimit = errors.New("the results waiting to be sent exceed the client output buffer limit")
+
+// connWire is the connection of a client. The commands are read from fd by
+// the event loop once it is readable, while the results are written to raw,
+// a duplicate of fd, without blocking. The bytes the socket does not accept
+// are kept in out, and written once the event loop reports fd writable, so
+// that a client not reading its results does not hold back the goroutine
+// sending them. The commands of a TLS connection are instead read through
+// conn, which wraps raw, and its results written to conn by a goroutine,
+// as are those of the connections the event loop does not poll.
+type connWire struct {
+	fd         int
+	conn       net.Conn
+	raw        net.Conn
+	rawConn    syscall.RawConn
+	maxMsgSize int
+
+	// buf holds the bytes read from fd that do not form a whole command yet.
+	// It is only accessed by the event loop.
+	buf []byte
+
+	// writeMu guards the fields below.
+	writeMu sync.Mutex
+	// out holds the bytes of the results not written yet.
+	out []byte
+	// writeErr is the error a write failed with, after which the results
+	// are discarded.
+	writeErr error
+	// mux is the io multiplexer polling fd, told to report fd writable
+	// while out is not empty. It is nil for the connections not polled.
+	mux iomultiplexer.IOMultiplexer
+	// flushing is set while a goroutine writes out to conn, and closing
+	// once the connection is to be closed after it.
+	flushing bool
+	closing  bool
+}
+
+// keepAliveProbes is the number of unanswered keepalive probes after which
+// the client is considered gone.
+const keepAliveProbes = 3
+
+// newConnWire returns the connection of the client socket fd. Once it is idle
+// for keepAlive, a probe is sent every third of keepAlive, and the connection
+// fails once keepAliveProbes of them go unanswered. A keepAlive of 0 sends none.
+func newConnWire(maxMsgSize int, keepAlive time.Duration, fd int) (*connWire, error) {
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		return nil, fmt.Errorf("failed to set connection to non-blocking: %w", err)
+	}
+
+	dupFD, err := syscall.Dup(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to duplicate connection: %w", err)
+	}
+	file := os.NewFile(uintptr(dupFD), fmt.Sprintf("client-connection-%d", fd))
+	conn, err := net.FileConn(file)
+	// net.FileConn duplicates the file, which is not needed anymore.
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		if err := tcpConn.SetNoDelay(true); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set TCP_NODELAY: %w", err)
+		}
+		if err := tcpConn.SetKeepAliveConfig(net.KeepAliveConfig{
+			Enable:   keepAlive > 0,
+			Idle:     keepAlive,
+			Interval: max(keepAlive/3, time.Second),
+			Count:    keepAliveProbes,
+		}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set keepalive: %w", err)
+		}
+	}
+
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("unsupported connection type %T", conn)
+	}
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &connWire{fd: fd, conn: conn, raw: conn, rawConn: rawConn, maxMsgSize: maxMsgSize}, nil
+}
+
+// newTLSConnWire returns the TLS connection of the client socket fd, whose
+// handshake is made by handshake. fd is closed, the connection being read
+// through conn.
+func newTLSConnWire(maxMsgSize int, keepAlive time.Duration, fd int, tlsConfig *tls.Config) (*connWire, error) {
+	w, err := newConnWire(maxMsgSize, keepAlive, fd)
+	if err != nil {
+		return nil, err
+	}
+	_ = syscall.Close(fd)
+	w.conn = tls.Server(w.raw, tlsConfig)
+	return w, nil
+}
+
+// handshake runs the TLS handshake of the connection, which has to complete
+// within timeout.
+func (w *connWire) handshake(timeout time.Duration) error {
+	tlsConn, ok := w.conn.(*tls.Conn)
+	if !ok {
+		return nil
+	}
+	_ = w.raw.SetDeadline(time.Now().Add(timeout))
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("TLS handshake failed: %w", err)
+	}
+	return w.raw.SetDeadline(time.Time{})
+}
+
+// read reads the bytes available on the connection into scratch, and returns
+// the commands they complete. It returns io.EOF once the client closed the
+// connection, along with the commands received before.
+func (w *connWire) read(scratch []byte) ([]*wire.Command, error) {
+	var readErr error
+	for i := 0; i < maxReadsPerEvent; i++ {
+		n, err := syscall.Read(w.fd, scratch)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err == syscall.EAGAIN || err == syscall.EWOULDBLOCK {
+			break
+		}
+		if err != nil {
+			readErr = err
+			break
+		}
+		if n == 0 {
+			readErr = io.EOF
+			break
+		}
+		w.buf = append(w.buf, scratch[:n]...)
+		if n < len(scratch) {
+			break
+		}
+	}
+
+	cmds, err := w.commands()
+	if err != nil {
+		return cmds, err
+	}
+	return cmds, readErr
+}
+
+// readConn reads from conn into scratch, blocking until some bytes are
+// available, and returns the commands they complete.
+func (w *connWire) readConn(scratch []byte) ([]*wire.Command, error) {
+	n, readErr := w.conn.Read(scratch)
+	w.buf = append(w.buf, scratch[:n]...)
+	cmds, err := w.commands()
+	if err != nil {
+		return cmds, err
+	}
+	return cmds, readErr
+}
+
+// commands returns the commands buf holds, leaving the bytes of the
+// incomplete one in it.
+func (w *connWire) commands() ([]*wire.Command, error) {
+	var cmds []*wire.Command
+	for len(w.buf) >= prefixSize {
+		size := binary.BigEndian.Uint32(w.buf)
+		if size == 0 || size > uint32(w.maxMsgSize) {
+			return cmds, fmt.Errorf("invalid message size: %d", size)
+		}
+		if len(w.buf) < prefixSize+int(size) {
+			break
+		}
+
+		c := &wire.Command{}
+		if err := proto.Unmarshal(w.buf[prefixSize:prefixSize+int(size)], c); err != nil {
+			return cmds, err
+		}
+		cmds = append(cmds, c)
+		w.buf = w.buf[prefixSize+int(size):]
+	}
+
+	// An idle connection does not hold on to a buffer.
+	if len(w.buf) == 0 {
+		w.buf = nil
+	} else {
+		w.buf = append([]byte(nil), w.buf...)
+	}
+	return cmds, nil
+}
+
+// Send queues the result to be written to the client, without waiting on the
+// client to read it. The client is disconnected once the results it has not
+// read yet exceed the client-output-buffer-limit-bytes configuration.
+func (w *connWire) Send(_ context.Context, rs *wire.Result) *wire.WireError {
+	msg, err := proto.Marshal(rs)
+	if err != nil {
+		return &wire.WireError{Kind: wire.CorruptMessage, Cause: err}
+	}
+
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	if w.writeErr != nil {
+		return &wire.WireError{Kind: wire.Terminated, Cause: w.writeErr}
+	}
+
+	// The results are written in order, hence behind the pending ones.
+	pending := len(w.out) > 0 || w.flushing
+	w.out = binary.BigEndian.AppendUint32(w.out, uint32(len(msg)))
+	w.out = append(w.out, msg...)
+	if limit := config.Config.ClientOutputBufferLimitBytes; pending && limit > 0 && len(w.out) > limit {
+		slog.Warn("client exceeded the output buffer limit, disconnecting it",
+			slog.Int("client-fd", w.fd),
+			slog.Int("limit", limit))
+		w.fail(errOutputBufferLimit)
+		w.close()
+		return &wire.WireError{Kind: wire.Terminated, Cause: errOutputBufferLimit}
+	}
+	if pending {
+		return nil
+	}
+
+	if w.mux == nil {
+		w.flushing = true
+		go w.flushConn()
+		return nil
+	}
+	if err := w.writeOut(); err != nil {
+		w.fail(err)
+		return &wire.WireError{Kind: wire.Terminated, Cause: err}
+	}
+	if len(w.out) > 0 {
+		w.watchWritable(true)
+	}
+	return nil
+}
+
+// poll makes the results be written without blocking, once the event loop
+// polls fd with mux.
+func (w *connWire) poll(mux iomultiplexer.IOMultiplexer) {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	w.mux = mux
+}
+
+// stopPolling is called by the event loop before closing fd, the pending
+// results being written to conn from then on.
+func (w *connWire) stopPolling() {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	w.mux = nil
+	if len(w.out) > 0 && !w.flushing && w.writeErr == nil {
+		w.flushing = true
+		go w.flushConn()
+	}
+}
+
+// flush writes the pending results once the event loop reports fd writable.
+func (w *connWire) flush() error {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	if w.writeErr != nil {
+		return w.writeErr
+	}
+	if err := w.writeOut(); err != nil {
+		w.fail(err)
+		return err
+	}
+	if len(w.out) == 0 {
+		w.watchWritable(false)
+	}
+	return nil
+}
+
+// writeOut writes the bytes of out the socket accepts without blocking.
+func (w *connWire) writeOut() error {
+	var writeErr error
+	err := w.rawConn.Write(func(fd uintptr) bool {
+		for len(w.out) > 0 {
+			n, err := syscall.Write(int(fd), w.out)
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil {
+				if err != syscall.EAGAIN && err != syscall.EWOULDBLOCK {
+					writeErr = err
+				}
+				break
+			}
+			w.out = w.out[n:]
+		}
+		return true
+	})
+	// An idle connection does not hold on to a buffer.
+	if len(w.out) == 0 {
+		w.out = nil
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// watchWritable tells the event loop to report fd writable or to stop doing
+// so. fd is not closed by the event loop while mux is set.
+func (w *connWire) watchWritable(writable bool) {
+	if w.mux == nil {
+		return
+	}
+	op := iomultiplexer.OpRead
+	if writable {
+		op |= iomultiplexer.OpWrite
+	}
+	if err := w.mux.Modify(iomultiplexer.Event{Fd: w.fd, Op: op}); err != nil {
+		slog.Warn("failed to poll the connection", slog.Int("client-fd", w.fd), slog.Any("error", err))
+	}
+}
+
+// flushConn writes the pending results to conn until there are none left,
+// parking its goroutine while the client does not read them, and closes the
+// connection afterwards if it was closed meanwhile.
+func (w *connWire) flushConn() {
+	for {
+		w.writeMu.Lock()
+		out := w.out
+		w.out = nil
+		if len(out) == 0 || w.writeErr != nil {
+			w.flushing = false
+			closing := w.closing
+			w.writeMu.Unlock()
+			if closing {
+				w.close()
+			}
+			return
+		}
+		w.writeMu.Unlock()
+
+		if _, err := w.conn.Write(out); err != nil {
+			w.writeMu.Lock()
+			w.fail(err)
+			w.writeMu.Unlock()
+		}
+	}
+}
+
+// fail discards the pending results, and the following ones.
+func (w *connWire) fail(err error) {
+	w.writeErr = err
+	w.out = nil
+}
+
+// Close closes the connection once the results being written to conn are, if
+// any, waiting closeFlushTimeout at most on a client not reading them. The
+// results waiting for fd to be writable are discarded.
+func (w *connWire) Close() {
+	w.writeMu.Lock()
+	if w.flushing {
+		w.closing = true
+		_ = w.raw.SetWriteDeadline(time.Now().Add(closeFlushTimeout))
+		w.writeMu.Unlock()
+		return
+	}
+	w.writeMu.Unlock()
+	w.close()
+}
+
+// close shuts the connection down, which the event loop notices as the
+// client disconnecting, and closes raw. fd is closed by the event loop.
+// The TLS connections are closed without a close_notify alert, which
+// would wait on the result being written to a slow client, if any.
+func (w *connWire) close() {
+	if c, ok := w.raw.(interface {
+		CloseRead() error
+		CloseWrite() error
+	}); ok {
+		_ = c.CloseRead()
+		_ = c.CloseWrite()
+	}
+	if err := w.raw.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		slog.Warn("error closing client connection", slog.Int("client-fd", w.fd), slog.Any("error", err))
+	}
+}
@@ -5,149 +5,320 @@ package ironhawk
 
 import (
 	"context"
+	"crypto/tls"
 	"log/slog"
+	"strconv"
 	"strings"
-
-	"github.com/dicedb/dicedb-go"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dicedb/dice/config"
 	"github.com/dicedb/dice/internal/auth"
 	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/errors"
 	"github.com/dicedb/dice/internal/shardmanager"
 	"github.com/dicedb/dice/internal/wal"
 	"github.com/dicedb/dicedb-go/wire"
 )
 
+// ioThreadIDs is the last id assigned to a connection.
+var ioThreadIDs atomic.Uint64
+
+// IOThread holds the state of a client connection. Its commands are read by
+// the event loop of the server, and executed in order by the io-thread pool.
 type IOThread struct {
+	// ID is assigned by the server to the connection, whereas the ClientID
+	// is chosen by the client, and shared by its command and watch connections.
+	ID         uint64
 	ClientID   string
 	Mode       string
 	Session    *auth.Session
-	serverWire *dicedb.ServerWire
+	serverWire *connWire
+
+	// connCtx is canceled once the client disconnects, which stops the
+	// blocking command it waits on, if any.
+	connCtx    context.Context
+	disconnect context.CancelFunc
+
+	// mu guards the commands waiting to be executed. busy is set while the
+	// io-thread is scheduled on the pool, so that its commands are executed
+	// one at a time. Once closed, no more commands are received, and the
+	// io-thread stops as soon as it is not busy, closeErr telling why.
+	// lastActive is the time the client last sent a command, or its last
+	// command completed at.
+	mu         sync.Mutex
+	pending    []*wire.Command
+	busy       bool
+	closed     bool
+	closeErr   error
+	lastActive time.Time
+
+	// txn holds the queued commands while the client is in a transaction.
+	txn *txn
+
+	// watchQueue holds the watch notifications waiting to be sent.
+	watchQueue *watchQueue
+
+	// inflight is the command being executed, if any, logged when the
+	// io-thread is cut off on shutdown.
+	inflight atomic.Pointer[wire.Command]
 }
 
-func NewIOThread(clientFD int) (*IOThread, error) {
-	w, err := dicedb.NewServerWire(config.MaxRequestSize, config.KeepAlive, clientFD)
+// NewIOThread returns the io-thread of the client socket clientFD, which is
+// a TLS connection if tlsConfig is set.
+func NewIOThread(ctx context.Context, clientFD int, tlsConfig *tls.Config) (*IOThread, error) {
+	keepAlive := time.Duration(config.Config.TCPKeepAlive) * time.Second
+	var w *connWire
+	var err error
+	if tlsConfig != nil {
+		w, err = newTLSConnWire(config.MaxRequestSize, keepAlive, clientFD, tlsConfig)
+	} else {
+		w, err = newConnWire(config.MaxRequestSize, keepAlive, clientFD)
+	}
 	if err != nil {
-		if err.Kind == wire.NotEstablished {
-			slog.Error("failed to establish connection to client", slog.Int("client-fd", clientFD), slog.Any("error", err))
-
-			return nil, err.Unwrap()
-		}
-		slog.Error("unexpected error during client connection establishment, this should be reported to DiceDB maintainers", slog.Int("client-fd", clientFD))
-		return nil, err.Unwrap()
+		slog.Error("failed to establish connection to client", slog.Int("client-fd", clientFD), slog.Any("error", err))
+		return nil, err
 	}
 
+	connCtx, disconnect := context.WithCancel(ctx)
 	return &IOThread{
+		ID:         ioThreadIDs.Add(1),
 		serverWire: w,
+		connCtx:    connCtx,
+		disconnect: disconnect,
 		Session:    auth.NewSession(),
+		watchQueue: newWatchQueue(),
+		lastActive: time.Now(),
 	}, nil
 }
 
-func (t *IOThread) Start(ctx context.Context, shardManager *shardmanager.ShardManager, watchManager *WatchManager) error {
-	for {
-		var c *wire.Command
-		recvCh := make(chan *wire.Command, 1)
-		errCh := make(chan error, 1)
-
-		go func() {
-			tmpC, err := t.serverWire.Receive()
-			if err != nil {
-				errCh <- err.Unwrap()
-				return
-			}
-			recvCh <- tmpC
-		}()
-
-		select {
-		case <-ctx.Done():
-			slog.Debug("io-thread context canceled, shutting down receive loop")
-			return ctx.Err()
-		case err := <-errCh:
-			return err
-		case tmp := <-recvCh:
-			c = tmp
-		}
+// receive queues the commands read from the client. It reports whether the
+// io-thread is to be scheduled on the pool to execute them.
+func (t *IOThread) receive(cmds []*wire.Command) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed || len(cmds) == 0 {
+		return false
+	}
+	t.pending = append(t.pending, cmds...)
+	t.lastActive = time.Now()
+	if t.busy {
+		return false
+	}
+	t.busy = true
+	return true
+}
 
-		_c := &cmd.Cmd{
-			C:        c,
-			ClientID: t.ClientID,
-			Mode:     t.Mode,
+// next returns the next command to execute. Once there is none, the io-thread
+// is no longer busy and next returns nil, along with whether it is closed, in
+// which case the caller stops it.
+func (t *IOThread) next() (c *wire.Command, closed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) == 0 {
+		t.busy = false
+		t.lastActive = time.Now()
+		return nil, t.closed
+	}
+	c = t.pending[0]
+	t.pending[0] = nil
+	t.pending = t.pending[1:]
+	return c, false
+}
+
+// close stops the io-thread from receiving commands, err telling why. A
+// disconnected client has its blocking command stopped, and the commands it
+// sent before disconnecting executed, whereas on shutdown those are dropped
+// and the in-flight command completes. close reports whether the io-thread
+// is not busy, in which case the caller stops it, else the pool does once
+// it has no command left.
+func (t *IOThread) close(err error) (idle bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.closed, t.closeErr = true, err
+	if err == errors.ErrServerShuttingDown {
+		t.pending = nil
+	} else {
+		t.disconnect()
+	}
+	return !t.busy
+}
+
+// expire closes the io-thread if it is a command-mode connection that has
+// been idle for longer than timeout, and reports whether it did, in which
+// case the caller stops it. The watch-mode connections are idle by design,
+// and are instead kept alive by the keepalive probes.
+func (t *IOThread) expire(now time.Time, timeout time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed || t.busy || t.Mode == "watch" || now.Sub(t.lastActive) < timeout {
+		return false
+	}
+	t.closed, t.closeErr = true, ErrIdleTimeout
+	t.disconnect()
+	return true
+}
+
+// execute executes the command c received from the client and sends its
+// result. It returns an error if the result could not be sent.
+func (t *IOThread) execute(c *wire.Command, shardManager *shardmanager.ShardManager, watchManager *WatchManager, ioThreadManager *IOThreadManager) error {
+	t.inflight.Store(c)
+	defer t.inflight.Store(nil)
+
+	c, rs := t.handleTxn(c)
+	if rs != nil {
+		if sendErr := t.serverWire.Send(t.connCtx, rs); sendErr != nil {
+			return sendErr.Unwrap()
 		}
+		return nil
+	}
 
-		res, err := _c.Execute(shardManager)
-		if err != nil {
-			res = &cmd.CmdRes{
-				Rs: &wire.Result{
-					Status:  wire.Status_ERR,
-					Message: err.Error(),
-				},
-			}
-			if sendErr := t.serverWire.Send(ctx, res.Rs); sendErr != nil {
+	// The THROTTLE and DIFF options of the .WATCH commands are handled
+	// by the watch manager, hence they are removed before the command executes.
+	var opts watchOptions
+	if strings.HasSuffix(c.Cmd, ".WATCH") && !isPatternWatchCmd(c.Cmd) {
+		var err error
+		if opts, err = parseWatchOptions(c); err != nil {
+			rs := &wire.Result{Status: wire.Status_ERR, Message: err.Error()}
+			if sendErr := t.serverWire.Send(t.connCtx, rs); sendErr != nil {
 				return sendErr.Unwrap()
 			}
-			// Continue in case of error
-			continue
+			return nil
 		}
+	}
 
-		res.Rs.Status = wire.Status_OK
-		if res.Rs.Message == "" {
-			res.Rs.Message = "OK"
-		}
+	_c := &cmd.Cmd{
+		C:        c,
+		ClientID: t.ClientID,
+		Mode:     t.Mode,
+		Done:     t.connCtx.Done(),
+	}
 
-		// Log command to WAL if enabled and not a replay
-		if wal.DefaultWAL != nil && !_c.IsReplay {
-			if err := wal.DefaultWAL.LogCommand(_c.C); err != nil {
-				slog.Error("failed to log command to WAL", slog.Any("error", err))
-			}
+	res, err := _c.Execute(shardManager)
+	if err == nil && isPubSubCmd(c.Cmd) {
+		res, err = watchManager.HandlePubSub(_c, t)
+	}
+	if err == nil && c.Cmd == "WATCH.STATS" {
+		res = watchManager.Stats()
+	}
+	if err == nil && c.Cmd == "WATCH.LIST" {
+		res = watchManager.List()
+	}
+	if err == nil && c.Cmd == "INFO" {
+		res = ioThreadManager.Info(watchManager.WatchConnectionCount())
+	}
+	if err == nil && c.Cmd == "HANDSHAKE" {
+		if err = watchManager.RegisterThread(t, c.Args[0], c.Args[1]); err == nil {
+			res = newHandshakeRes(t)
 		}
-
-		// TODO: Optimize this. We are doing this for all command execution
-		// Also, we are allowing people to override the client ID.
-		// Also, CLientID is duplicated in command and io-thread.
-		// Also, we shouldn't allow execution/registration incase of invalid commands
-		// like for B.WATCH cmd since it'll err out we shall return and not create subscription
-		if err == nil {
-			t.ClientID = _c.ClientID
+	}
+	// The subscriptions are held by client id, hence a connection
+	// without one would share them with the others.
+	if err == nil && strings.HasSuffix(c.Cmd, ".WATCH") && t.ClientID == "" {
+		err = errors.ErrGeneral("HANDSHAKE is required before " + c.Cmd)
+	}
+	if err != nil {
+		res = &cmd.CmdRes{
+			Rs: &wire.Result{
+				Status:  wire.Status_ERR,
+				Message: err.Error(),
+			},
 		}
-
-		if _c.Meta.IsWatchable {
-			_cWatch := _c
-			_cWatch.C.Cmd += ".WATCH"
-			res.Rs.Fingerprint64 = _cWatch.Fingerprint()
+		if sendErr := t.serverWire.Send(t.connCtx, res.Rs); sendErr != nil {
+			return sendErr.Unwrap()
 		}
+		return nil
+	}
+
+	res.Rs.Status = wire.Status_OK
+	if res.Rs.Message == "" {
+		res.Rs.Message = "OK"
+	}
 
-		if c.Cmd == "HANDSHAKE" && err == nil {
-			t.ClientID = _c.C.Args[0]
-			t.Mode = _c.C.Args[1]
+	// Log command to WAL if enabled and not a replay
+	if wal.DefaultWAL != nil && !_c.IsReplay {
+		if err := wal.DefaultWAL.LogCommand(_c.C); err != nil {
+			slog.Error("failed to log command to WAL", slog.Any("error", err))
 		}
+	}
 
-		isWatchCmd := strings.HasSuffix(c.Cmd, "WATCH")
+	// The fingerprint of a watchable command is the one of its .WATCH
+	// command, computed on a copy so that the command itself is left as is.
+	if _c.Meta.IsWatchable {
+		_cWatch := &cmd.Cmd{C: &wire.Command{Cmd: c.Cmd + ".WATCH", Args: c.Args}}
+		res.Rs.Fingerprint64 = _cWatch.Fingerprint()
+	}
 
-		if isWatchCmd {
-			watchManager.HandleWatch(_c, t)
-		} else if strings.HasSuffix(c.Cmd, "UNWATCH") {
-			watchManager.HandleUnwatch(_c, t)
-		}
+	if c.Cmd == "MULTI" && err == nil {
+		t.txn = &txn{}
+	}
 
-		watchManager.RegisterThread(t)
+	isWatchCmd := strings.HasSuffix(c.Cmd, ".WATCH")
 
-		// Only send the response directly if this is not a watch command
-		// For watch commands, the response will be sent by NotifyWatchers
-		if !isWatchCmd {
-			if sendErr := t.serverWire.Send(ctx, res.Rs); sendErr != nil {
-				return sendErr.Unwrap()
-			}
+	if isWatchCmd {
+		watchManager.HandleWatch(_c, t, opts)
+	} else if strings.HasSuffix(c.Cmd, "UNWATCH") {
+		watchManager.HandleUnwatch(_c, t)
+	}
+
+	// Only send the response directly if this is not a watch command
+	// For watch commands, the response will be sent by NotifyWatchers,
+	// except for the pattern watches which are not bound to a key.
+	if !isWatchCmd || isPatternWatchCmd(c.Cmd) {
+		if sendErr := t.serverWire.Send(t.connCtx, res.Rs); sendErr != nil {
+			return sendErr.Unwrap()
 		}
+	}
+
+	// A client reconnecting its watch connection resumes its
+	// subscriptions, once the handshake has been responded to.
+	if c.Cmd == "HANDSHAKE" && err == nil {
+		watchManager.ResumeSubscriptions(t, shardManager)
+	}
 
-		// TODO: Streamline this because we need ordering of updates
-		// that are being sent to watchers.
-		if err == nil {
-			watchManager.NotifyWatchers(_c, shardManager, t)
+	// TODO: Streamline this because we need ordering of updates
+	// that are being sent to watchers.
+	if err == nil {
+		watchManager.NotifyWatchers(_c, shardManager, t)
+	}
+	return nil
+}
+
+// notifyShutdown stops the delivery of the watch notifications of the io-thread,
+// and tells its client that the server is shutting down if it is in the watch mode.
+// The pending notifications are dropped, the client resuming its subscriptions
+// with their latest state once it reconnects.
+func (t *IOThread) notifyShutdown() {
+	t.watchQueue.stop()
+	if t.Mode == "watch" {
+		rs := &wire.Result{Status: wire.Status_ERR, Message: errors.ErrServerShuttingDown.Error()}
+		if err := t.serverWire.Send(t.connCtx, rs); err != nil {
+			slog.Debug("failed to notify watcher of the shutdown",
+				slog.Uint64("conn_id", t.ID),
+				slog.String("client_id", t.ClientID),
+				slog.Any("error", err.Unwrap()))
 		}
 	}
 }
 
+// newHandshakeRes returns the result of the HANDSHAKE of the io-thread,
+// carrying the id of its connection.
+func newHandshakeRes(t *IOThread) *cmd.CmdRes {
+	return &cmd.CmdRes{
+		Rs: &wire.Result{
+			Message:  "OK conn_id=" + strconv.FormatUint(t.ID, 10),
+			Response: &wire.Result_HANDSHAKERes{HANDSHAKERes: &wire.HANDSHAKERes{}},
+		},
+	}
+}
+
 func (t *IOThread) Stop() error {
+	t.disconnect()
+	t.watchQueue.stop()
 	t.serverWire.Close()
 	t.Session.Expire()
 	return nil
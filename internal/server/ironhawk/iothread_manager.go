@@ -5,21 +5,31 @@ package ironhawk
 
 import (
 	"errors"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
 	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/cmd"
 )
 
 type IOThreadManager struct {
 	connectedClients sync.Map
 	numIOThreads     atomic.Uint32
 	mu               sync.Mutex
+
+	// The connections rejected for exceeding MaxClients, closed for being
+	// idle for longer than the timeout, and closed for not answering the
+	// keepalive probes.
+	rejected     atomic.Int64
+	timedOut     atomic.Int64
+	keepAliveErr atomic.Int64
 }
 
 var (
 	ErrMaxClientsReached = errors.New("maximum number of clients reached")
 	ErrIOThreadNotFound  = errors.New("io-thread not found")
+	ErrIdleTimeout       = errors.New("connection idle for longer than the timeout")
 )
 
 func NewIOThreadManager() *IOThreadManager {
@@ -31,10 +41,11 @@ func (m *IOThreadManager) RegisterIOThread(ioThread *IOThread) error {
 	defer m.mu.Unlock()
 
 	if m.IOThreadCount() >= uint32(config.Config.MaxClients) {
+		m.rejected.Add(1)
 		return ErrMaxClientsReached
 	}
 
-	m.connectedClients.Store(ioThread.ClientID, ioThread)
+	m.connectedClients.Store(ioThread.ID, ioThread)
 	m.numIOThreads.Add(1)
 	return nil
 }
@@ -43,7 +54,15 @@ func (m *IOThreadManager) IOThreadCount() uint32 {
 	return m.numIOThreads.Load()
 }
 
-func (m *IOThreadManager) UnregisterIOThread(id string) error {
+// Range calls fn for each registered io-thread.
+func (m *IOThreadManager) Range(fn func(t *IOThread)) {
+	m.connectedClients.Range(func(_, v any) bool {
+		fn(v.(*IOThread))
+		return true
+	})
+}
+
+func (m *IOThreadManager) UnregisterIOThread(id uint64) error {
 	if client, loaded := m.connectedClients.LoadAndDelete(id); loaded {
 		w := client.(*IOThread)
 		if err := w.Stop(); err != nil {
@@ -56,3 +75,19 @@ func (m *IOThreadManager) UnregisterIOThread(id string) error {
 	m.numIOThreads.Add(^uint32(0))
 	return nil
 }
+
+// Info returns the clients section of INFO, as a flat list of name and
+// value pairs, watchClients being the number of watch-mode connections.
+func (m *IOThreadManager) Info(watchClients int) *cmd.CmdRes {
+	info := []string{
+		"connected_clients", strconv.FormatUint(uint64(m.IOThreadCount()), 10),
+		"watch_clients", strconv.Itoa(watchClients),
+		"maxclients", strconv.Itoa(config.Config.MaxClients),
+		"rejected_connections", strconv.FormatInt(m.rejected.Load(), 10),
+		"timeout", strconv.Itoa(config.Config.Timeout),
+		"timed_out_clients", strconv.FormatInt(m.timedOut.Load(), 10),
+		"tcp_keepalive", strconv.Itoa(config.Config.TCPKeepAlive),
+		"keepalive_disconnected_clients", strconv.FormatInt(m.keepAliveErr.Load(), 10),
+	}
+	return newWatchListRes(info)
+}
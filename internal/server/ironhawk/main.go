@@ -5,36 +5,100 @@ package ironhawk
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/cmd"
+	derrors "github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/iomultiplexer"
 	"github.com/dicedb/dice/internal/shardmanager"
+	"github.com/dicedb/dicedb-go/wire"
 )
 
+// pollTimeout bounds the time the event loop waits for events,
+// so that it notices the server shutting down.
+const pollTimeout = 100 * time.Millisecond
+
+// readBufferSize is the size of the buffer the event loop reads the
+// commands of the clients into.
+const readBufferSize = 64 * 1024
+
+// maxCommandsPerTurn bounds the commands of an io-thread executed before it
+// is scheduled again, so that a client sending a large pipeline does not
+// hold a worker of the pool while the others wait.
+const maxCommandsPerTurn = 64
+
+// idleCheckInterval is the interval at which the event loop closes the
+// connections idle for longer than the timeout.
+const idleCheckInterval = time.Second
+
 type Server struct {
-	Host            string
-	Port            int
-	serverFD        int
-	connBacklogSize int
-	shardManager    *shardmanager.ShardManager
-	watchManager    *WatchManager
-	ioThreadManager *IOThreadManager
+	Hosts          []string
+	Port           int
+	UnixSocket     string
+	UnixSocketPerm string
+	TLSPort        int
+	listenerFDs    []int
+	// tlsListenerFDs are the listeners of listenerFDs accepting TLS
+	// connections, with the certificates of tlsCerts.
+	tlsListenerFDs []int
+	tlsCerts       *tlsCertificates
+	// unixSocketCreated is set once the unix domain socket file is created,
+	// so that it is the only file removed on shutdown.
+	unixSocketCreated bool
+	connBacklogSize   int
+	shardManager      *shardmanager.ShardManager
+	watchManager      *WatchManager
+	ioThreadManager   *IOThreadManager
+
+	// jobs holds the io-threads having commands to execute, which the
+	// workers of the io-thread pool take in turn. An io-thread is scheduled
+	// at most once at a time, hence jobs never holds more than MaxClients.
+	jobs chan *IOThread
+
+	// ioThreadWg tracks the io-threads, which stop once the client
+	// disconnects, or on shutdown once their in-flight command completes.
+	// ioCtx is canceled to cut off those still running after the shutdown
+	// timeout. draining is closed once the shutdown starts.
+	ioThreadWg sync.WaitGroup
+	draining   chan struct{}
+	ioCtx      context.Context
+	cutOff     context.CancelFunc
+
+	// tlsRejects holds a token for each rejected TLS client being sent
+	// the reason of its rejection.
+	tlsRejects chan struct{}
 }
 
 func NewServer(shardManager *shardmanager.ShardManager, ioThreadManager *IOThreadManager, watchManager *WatchManager) *Server {
+	ioCtx, cutOff := context.WithCancel(context.Background())
 	return &Server{
-		Host:            config.Config.Host,
+		Hosts:           config.Config.Hosts(),
 		Port:            config.Config.Port,
+		UnixSocket:      config.Config.UnixSocket,
+		UnixSocketPerm:  config.Config.UnixSocketPerm,
+		TLSPort:         config.Config.TLSPort,
 		connBacklogSize: config.DefaultConnBacklogSize,
 		shardManager:    shardManager,
 		ioThreadManager: ioThreadManager,
 		watchManager:    watchManager,
+		jobs:            make(chan *IOThread, max(config.Config.MaxClients, 1)),
+		draining:        make(chan struct{}),
+		ioCtx:           ioCtx,
+		cutOff:          cutOff,
+		tlsRejects:      make(chan struct{}, maxTLSRejects),
 	}
 }
 
@@ -44,18 +108,30 @@ func (s *Server) Run(ctx context.Context) (err error) {
 		return err
 	}
 
-	defer releasePort(s.serverFD)
+	mux, err := iomultiplexer.New()
+	if err != nil {
+		s.releasePorts()
+		return fmt.Errorf("failed to create the io multiplexer: %w", err)
+	}
+	defer mux.Close()
+	for _, fd := range s.listenerFDs {
+		if err = mux.Subscribe(iomultiplexer.Event{Fd: fd, Op: iomultiplexer.OpRead}); err != nil {
+			s.releasePorts()
+			return fmt.Errorf("failed to subscribe to the server socket: %w", err)
+		}
+	}
+
+	s.startIOThreadPool()
 
 	errChan := make(chan error, 1)
-	wg := &sync.WaitGroup{}
+	loopDone := make(chan struct{})
 
-	wg.Add(1)
-	go func(wg *sync.WaitGroup) {
-		defer wg.Done()
-		if err := s.AcceptConnectionRequests(ctx, wg); err != nil {
+	go func() {
+		defer close(loopDone)
+		if err := s.eventLoop(ctx, mux); err != nil {
 			errChan <- fmt.Errorf("failed to accept connections %w", err)
 		}
-	}(wg)
+	}()
 
 	select {
 	case <-ctx.Done():
@@ -64,114 +140,482 @@ func (s *Server) Run(ctx context.Context) (err error) {
 		slog.Error("error while accepting connections, initiating shutdown", slog.Any("error", err))
 	}
 
-	s.Shutdown()
+	// The port is released before the connections are drained, so that
+	// the new connections are refused rather than left in the backlog.
+	<-loopDone
+	slog.Info("no new connections will be accepted")
+	s.releasePorts()
 
-	wg.Wait() // Wait for the go routines to finish
+	s.Shutdown()
 	slog.Info("exiting gracefully")
 
 	return err
 }
 
-func (s *Server) BindAndListen() error {
-	serverFD, socketErr := syscall.Socket(syscall.AF_INET, syscall.SOCK_STREAM, 0)
-	if socketErr != nil {
-		return fmt.Errorf("failed to create socket: %w", socketErr)
-	}
-
-	// Close the socket on exit if an error occurs
-	var err error
+// BindAndListen listens on the port of each of the host addresses, and on
+// their TLS port and the unix domain socket if those are configured. On error, the listeners
+// created are closed.
+func (s *Server) BindAndListen() (err error) {
 	defer func() {
 		if err != nil {
-			if closeErr := syscall.Close(serverFD); closeErr != nil {
-				// Wrap the close error with the original bind/listen error
-				slog.Error("Error occurred", slog.Any("error", err), "additionally, failed to close socket", slog.Any("close-err", closeErr))
-			} else {
-				slog.Error("Error occurred", slog.Any("error", err))
-			}
+			s.releasePorts()
 		}
 	}()
 
-	if err = syscall.SetsockoptInt(serverFD, syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
-		return fmt.Errorf("failed to set SO_REUSEADDR: %w", err)
+	for _, host := range s.Hosts {
+		fd, err := s.listenTCP(host, s.Port)
+		if err != nil {
+			return err
+		}
+		s.listenerFDs = append(s.listenerFDs, fd)
+	}
+	if s.TLSPort > 0 {
+		if s.tlsCerts, err = newTLSCertificates(config.Config.TLSCertFile, config.Config.TLSKeyFile,
+			config.Config.TLSCACertFile, config.Config.TLSAuthClients); err != nil {
+			return err
+		}
+		for _, host := range s.Hosts {
+			fd, err := s.listenTCP(host, s.TLSPort)
+			if err != nil {
+				return err
+			}
+			s.listenerFDs = append(s.listenerFDs, fd)
+			s.tlsListenerFDs = append(s.tlsListenerFDs, fd)
+		}
+	}
+	if s.UnixSocket != "" {
+		fd, err := s.listenUnix(s.UnixSocket, s.UnixSocketPerm)
+		if err != nil {
+			return err
+		}
+		s.listenerFDs = append(s.listenerFDs, fd)
+		s.unixSocketCreated = true
+	}
+	if len(s.listenerFDs) == 0 {
+		return errors.New("no address to listen on")
 	}
+	return nil
+}
 
-	if err = syscall.SetNonblock(serverFD, true); err != nil {
-		return fmt.Errorf("failed to set socket to non-blocking: %w", err)
+// listenTCP listens on the port of the IPv4 or IPv6 address host. An IPv6
+// listener only accepts IPv6 connections, so that the server can bind to
+// both 0.0.0.0 and :: on the same port.
+func (s *Server) listenTCP(host string, port int) (int, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return -1, fmt.Errorf("invalid IP address: %s", host)
 	}
 
-	ip4 := net.ParseIP(s.Host)
-	if ip4 == nil {
-		return fmt.Errorf("invalid IP address: %s", s.Host)
+	family := syscall.AF_INET6
+	var sockAddr syscall.Sockaddr
+	if ip4 := ip.To4(); ip4 != nil {
+		family = syscall.AF_INET
+		addr := &syscall.SockaddrInet4{Port: port}
+		copy(addr.Addr[:], ip4)
+		sockAddr = addr
+	} else {
+		addr := &syscall.SockaddrInet6{Port: port}
+		copy(addr.Addr[:], ip.To16())
+		sockAddr = addr
 	}
 
-	sockAddr := &syscall.SockaddrInet4{
-		Port: s.Port,
-		Addr: [4]byte{ip4[0], ip4[1], ip4[2], ip4[3]},
+	fd, err := syscall.Socket(family, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return -1, fmt.Errorf("failed to create socket: %w", err)
 	}
-	if err = syscall.Bind(serverFD, sockAddr); err != nil {
-		return fmt.Errorf("failed to bind socket: %w", err)
+	if err := syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
+		releasePort(fd)
+		return -1, fmt.Errorf("failed to set SO_REUSEADDR: %w", err)
+	}
+	if family == syscall.AF_INET6 {
+		if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY, 1); err != nil {
+			releasePort(fd)
+			return -1, fmt.Errorf("failed to set IPV6_V6ONLY: %w", err)
+		}
 	}
+	if err := s.listen(fd, sockAddr, nil); err != nil {
+		releasePort(fd)
+		return -1, fmt.Errorf("failed to listen on %s: %w", net.JoinHostPort(host, strconv.Itoa(port)), err)
+	}
+	return fd, nil
+}
 
-	if err = syscall.Listen(serverFD, s.connBacklogSize); err != nil {
-		return fmt.Errorf("failed to listen on socket: %w", err)
+// listenUnix listens on the unix domain socket at path, with the permissions
+// perm given in octal. A socket left at path by a server that did not shut
+// down cleanly is removed, whereas any other file is left as is.
+func (s *Server) listenUnix(path, perm string) (int, error) {
+	mode, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || mode > 0o777 {
+		return -1, fmt.Errorf("invalid unix socket permissions: %s", perm)
+	}
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return -1, fmt.Errorf("failed to listen on %s: the file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return -1, fmt.Errorf("failed to remove stale unix socket: %w", err)
+		}
+	}
+
+	fd, err := syscall.Socket(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return -1, fmt.Errorf("failed to create socket: %w", err)
 	}
+	// The permissions are set once the socket file is created by
+	// bind, and before any connection is accepted.
+	chmod := func() error { return os.Chmod(path, os.FileMode(mode)) }
+	if err := s.listen(fd, &syscall.SockaddrUnix{Name: path}, chmod); err != nil {
+		releasePort(fd)
+		_ = os.Remove(path)
+		return -1, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	return fd, nil
+}
 
-	s.serverFD = serverFD
+// listen binds the socket fd to the address and listens on it, calling
+// afterBind, if any, in between.
+func (s *Server) listen(fd int, sockAddr syscall.Sockaddr, afterBind func() error) error {
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		return fmt.Errorf("failed to set socket to non-blocking: %w", err)
+	}
+	if err := syscall.Bind(fd, sockAddr); err != nil {
+		return fmt.Errorf("failed to bind socket: %w", err)
+	}
+	if afterBind != nil {
+		if err := afterBind(); err != nil {
+			return err
+		}
+	}
+	if err := syscall.Listen(fd, s.connBacklogSize); err != nil {
+		return fmt.Errorf("failed to listen on socket: %w", err)
+	}
 	return nil
 }
 
+// releasePorts closes the listeners, and removes the unix domain socket.
+func (s *Server) releasePorts() {
+	for _, fd := range s.listenerFDs {
+		releasePort(fd)
+	}
+	s.listenerFDs, s.tlsListenerFDs = nil, nil
+	if s.unixSocketCreated {
+		s.unixSocketCreated = false
+		if err := os.Remove(s.UnixSocket); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to remove unix socket", slog.String("path", s.UnixSocket), slog.Any("error", err))
+		}
+	}
+}
+
 func releasePort(serverFD int) {
 	if err := syscall.Close(serverFD); err != nil {
 		slog.Error("Failed to close server socket", slog.Any("error", err))
 	}
 }
 
-func (s *Server) AcceptConnectionRequests(ctx context.Context, wg *sync.WaitGroup) error {
-	for {
-		select {
-		case <-ctx.Done():
-			slog.Info("no new connections will be accepted")
-			return ctx.Err()
-		default:
-			clientFD, _, err := syscall.Accept(s.serverFD)
-			if err != nil {
-				if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EWOULDBLOCK) {
-					continue // No more connections to accept at this time
+// eventLoop accepts the connections and reads the commands of the clients
+// once the io multiplexer reports their sockets readable, handing the
+// io-threads having commands to execute over to the io-thread pool, and
+// writes the pending results of the clients once their sockets are writable.
+// It owns the sockets of the clients, and closes those left once ctx is done,
+// the io-threads still running being drained by Shutdown.
+func (s *Server) eventLoop(ctx context.Context, mux iomultiplexer.IOMultiplexer) error {
+	threads := make(map[int]*IOThread)
+	defer func() {
+		for fd, thread := range threads {
+			thread.serverWire.stopPolling()
+			_ = syscall.Close(fd)
+		}
+	}()
+
+	scratch := make([]byte, readBufferSize)
+	lastIdleCheck := time.Now()
+	for ctx.Err() == nil {
+		events, err := mux.Poll(pollTimeout)
+		if err != nil {
+			if errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			return fmt.Errorf("failed to poll for events: %w", err)
+		}
+
+		for _, event := range events {
+			if slices.Contains(s.listenerFDs, event.Fd) {
+				if err := s.acceptConnections(mux, event.Fd, threads, scratch); err != nil {
+					return err
 				}
-				return fmt.Errorf("error accepting connection: %w", err)
+				continue
 			}
 
-			thread, err := NewIOThread(clientFD)
-			if err != nil {
-				slog.Error("failed to create io-thread", slog.String("id", "-xxx"), slog.Any("error", err))
+			thread, ok := threads[event.Fd]
+			if !ok {
 				continue
 			}
+			var err error
+			if event.Op&iomultiplexer.OpWrite != 0 {
+				err = thread.serverWire.flush()
+			}
+			if err == nil && event.Op != iomultiplexer.OpWrite {
+				var cmds []*wire.Command
+				cmds, err = thread.serverWire.read(scratch)
+				if thread.receive(cmds) {
+					s.jobs <- thread
+				}
+			}
+			if err == nil {
+				continue
+			}
+
+			s.closeConn(mux, threads, event.Fd)
+			if thread.close(err) {
+				s.stopIOThread(thread)
+			}
+		}
 
-			wg.Add(1)
-			go s.startIOThread(ctx, wg, thread)
+		if timeout := time.Duration(config.Config.Timeout) * time.Second; timeout > 0 && time.Since(lastIdleCheck) >= idleCheckInterval {
+			lastIdleCheck = time.Now()
+			for fd, thread := range threads {
+				if thread.expire(lastIdleCheck, timeout) {
+					s.closeConn(mux, threads, fd)
+					s.stopIOThread(thread)
+				}
+			}
 		}
 	}
+	return nil
 }
 
-func (s *Server) startIOThread(ctx context.Context, wg *sync.WaitGroup, thread *IOThread) {
-	defer wg.Done()
-	err := thread.Start(ctx, s.shardManager, s.watchManager)
-	if err != nil {
-		if err == io.EOF {
-			s.watchManager.CleanupThreadWatchSubscriptions(thread)
-			slog.Debug("client disconnected. io-thread stopped",
-				slog.String("client_id", thread.ClientID),
-				slog.String("mode", thread.Mode),
-			)
-		} else {
-			slog.Debug("io-thread errored out",
+// closeConn unsubscribes from the socket fd of a client and closes it.
+func (s *Server) closeConn(mux iomultiplexer.IOMultiplexer, threads map[int]*IOThread, fd int) {
+	threads[fd].serverWire.stopPolling()
+	if err := mux.Unsubscribe(iomultiplexer.Event{Fd: fd, Op: iomultiplexer.OpRead}); err != nil {
+		slog.Warn("failed to unsubscribe from the connection", slog.Uint64("conn_id", threads[fd].ID), slog.Any("error", err))
+	}
+	delete(threads, fd)
+	_ = syscall.Close(fd)
+}
+
+// acceptConnections accepts the pending connections of the listener, creating their
+// io-threads and subscribing to their sockets.
+func (s *Server) acceptConnections(mux iomultiplexer.IOMultiplexer, listenerFD int, threads map[int]*IOThread, scratch []byte) error {
+	for {
+		clientFD, _, err := syscall.Accept(listenerFD)
+		if err != nil {
+			if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EWOULDBLOCK) {
+				return nil // No more connections to accept at this time
+			}
+			if errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			return fmt.Errorf("error accepting connection: %w", err)
+		}
+
+		// The TLS connections are read by serveTLS rather than polled,
+		// and their socket is closed once wrapped.
+		var tlsConfig *tls.Config
+		if slices.Contains(s.tlsListenerFDs, listenerFD) {
+			tlsConfig = s.tlsCerts.serverConfig
+		}
+		thread, err := NewIOThread(s.ioCtx, clientFD, tlsConfig)
+		if err != nil {
+			slog.Error("failed to create io-thread", slog.Int("client-fd", clientFD), slog.Any("error", err))
+			_ = syscall.Close(clientFD)
+			continue
+		}
+		if err := s.ioThreadManager.RegisterIOThread(thread); err != nil {
+			slog.Warn("rejecting connection", slog.Uint64("conn_id", thread.ID), slog.Any("error", err))
+			if tlsConfig != nil {
+				select {
+				case s.tlsRejects <- struct{}{}:
+					s.ioThreadWg.Add(1)
+					go s.rejectTLS(thread, err)
+				default:
+					_ = thread.Stop()
+				}
+				continue
+			}
+			s.reject(thread, err, scratch)
+			_ = syscall.Close(clientFD)
+			continue
+		}
+		if tlsConfig != nil {
+			s.ioThreadWg.Add(1)
+			go s.serveTLS(thread)
+			continue
+		}
+		if err := mux.Subscribe(iomultiplexer.Event{Fd: clientFD, Op: iomultiplexer.OpRead}); err != nil {
+			slog.Error("failed to subscribe to the connection", slog.Uint64("conn_id", thread.ID), slog.Any("error", err))
+			_ = s.ioThreadManager.UnregisterIOThread(thread.ID)
+			_ = syscall.Close(clientFD)
+			continue
+		}
+
+		thread.serverWire.poll(mux)
+		s.ioThreadWg.Add(1)
+		threads[clientFD] = thread
+	}
+}
+
+// reject sends the error err to a client, which reads it as the result of
+// its first command, and closes its connection. The commands already received
+// are discarded, so that closing the socket does not reset the connection
+// before the client reads the error.
+func (s *Server) reject(thread *IOThread, err error, scratch []byte) {
+	rs := &wire.Result{Status: wire.Status_ERR, Message: err.Error()}
+	if sendErr := thread.serverWire.Send(s.ioCtx, rs); sendErr != nil {
+		slog.Debug("failed to notify the rejected client", slog.Uint64("conn_id", thread.ID), slog.Any("error", sendErr.Unwrap()))
+	}
+	_, _ = thread.serverWire.read(scratch)
+	_ = thread.Stop()
+}
+
+// startIOThreadPool starts the workers executing the commands of the
+// io-threads, which return once jobs is closed on shutdown.
+func (s *Server) startIOThreadPool() {
+	n := runtime.NumCPU()
+	if config.Config.NumIOThreads > 0 {
+		n = config.Config.NumIOThreads
+	}
+	for i := 0; i < n; i++ {
+		go func() {
+			for thread := range s.jobs {
+				s.serve(thread)
+			}
+		}()
+	}
+}
+
+// serve executes the commands of the io-thread, and stops it once it has
+// none left and is closed. A blocking command is executed on a goroutine of
+// its own, the io-thread being scheduled again once it completes, so that a
+// client waiting on its keys does not hold a worker of the pool.
+func (s *Server) serve(thread *IOThread) {
+	for i := 0; i < maxCommandsPerTurn; i++ {
+		c, closed := thread.next()
+		if c == nil {
+			if closed {
+				s.stopIOThread(thread)
+			}
+			return
+		}
+
+		if meta, ok := cmd.CommandRegistry.CommandMetas[c.Cmd]; ok && meta.IsBlocking {
+			go func() {
+				s.execute(thread, c)
+				s.jobs <- thread
+			}()
+			return
+		}
+		s.execute(thread, c)
+	}
+	s.jobs <- thread
+}
+
+func (s *Server) execute(thread *IOThread, c *wire.Command) {
+	if err := thread.execute(c, s.shardManager, s.watchManager, s.ioThreadManager); err != nil {
+		// The io-thread is busy executing c, hence it is stopped by serve.
+		thread.close(err)
+	}
+}
+
+func (s *Server) stopIOThread(thread *IOThread) {
+	defer s.ioThreadWg.Done()
+	err := thread.closeErr
+	if errors.Is(err, derrors.ErrServerShuttingDown) {
+		thread.notifyShutdown()
+		if thread.txn != nil {
+			slog.Warn("transaction discarded on shutdown",
+				slog.Uint64("conn_id", thread.ID),
 				slog.String("client_id", thread.ClientID),
-				slog.String("mode", thread.Mode),
-				slog.Any("error", err))
+				slog.Int("queued_commands", len(thread.txn.cmds)))
 		}
+	} else if err == ErrIdleTimeout {
+		s.ioThreadManager.timedOut.Add(1)
+		slog.Debug("closing idle connection",
+			slog.Uint64("conn_id", thread.ID),
+			slog.String("client_id", thread.ClientID))
+	} else if errors.Is(err, syscall.ETIMEDOUT) {
+		// The client did not answer the keepalive probes.
+		s.ioThreadManager.keepAliveErr.Add(1)
+		slog.Info("client unreachable, closing connection",
+			slog.Uint64("conn_id", thread.ID),
+			slog.String("client_id", thread.ClientID),
+			slog.String("mode", thread.Mode))
+	} else if err == io.EOF {
+		slog.Debug("client disconnected. io-thread stopped",
+			slog.Uint64("conn_id", thread.ID),
+			slog.String("client_id", thread.ClientID),
+			slog.String("mode", thread.Mode),
+		)
+	} else {
+		slog.Debug("io-thread errored out",
+			slog.Uint64("conn_id", thread.ID),
+			slog.String("client_id", thread.ClientID),
+			slog.String("mode", thread.Mode),
+			slog.Any("error", err))
+	}
+
+	// The watch connection of the client is released however the io-thread
+	// stopped, so that the client can establish another one.
+	s.watchManager.CleanupThreadWatchSubscriptions(thread)
+	if unregisterErr := s.ioThreadManager.UnregisterIOThread(thread.ID); unregisterErr != nil {
+		slog.Warn("failed to unregister io-thread", slog.Uint64("conn_id", thread.ID), slog.Any("error", unregisterErr))
 	}
 }
 
+// Shutdown drains the connections: each io-thread stops once its in-flight
+// command completes, and the watch-mode clients are told that the server is
+// shutting down. The io-threads still running after the shutdown timeout,
+// typically blocked in a blocking command, are cut off and logged. Shutdown
+// returns once all the io-threads have stopped.
 func (s *Server) Shutdown() {
-	// Not implemented
+	select {
+	case <-s.draining:
+		return
+	default:
+		close(s.draining)
+	}
+
+	timeout := time.Duration(config.Config.ShutdownTimeoutMs) * time.Millisecond
+	slog.Info("draining connections",
+		slog.Int("connections", int(s.ioThreadManager.IOThreadCount())),
+		slog.Duration("timeout", timeout))
+
+	// The idle io-threads stop right away, the others once their
+	// in-flight command completes.
+	s.ioThreadManager.Range(func(t *IOThread) {
+		if t.close(derrors.ErrServerShuttingDown) {
+			s.stopIOThread(t)
+		}
+	})
+	defer close(s.jobs)
+
+	drained := make(chan struct{})
+	go func() {
+		s.ioThreadWg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		slog.Info("connections drained")
+		return
+	case <-time.After(timeout):
+	}
+
+	n := 0
+	s.ioThreadManager.Range(func(t *IOThread) {
+		attrs := []any{
+			slog.Uint64("conn_id", t.ID),
+			slog.String("client_id", t.ClientID),
+			slog.String("mode", t.Mode),
+		}
+		if c := t.inflight.Load(); c != nil {
+			attrs = append(attrs, slog.String("cmd", c.Cmd))
+		}
+		slog.Warn("connection cut off on shutdown", attrs...)
+		n++
+	})
+	s.cutOff()
+	<-drained
+	slog.Warn("connections drained, some were cut off", slog.Int("cut_off", n))
 }
@@ -0,0 +1,163 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"runtime"
+	"sync"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/shardmanager"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// The slow consumer policies, applied when the watch notifications
+// of a client exceed the size of its queue.
+const (
+	slowConsumerDropOldest = "drop-oldest"
+	slowConsumerDisconnect = "disconnect"
+)
+
+// notifierQueueSize is the number of modified keys each notifier worker
+// buffers before the writing clients wait for it.
+const notifierQueueSize = 4096
+
+// keyChange is a key modified by a command, whose watchers are to be
+// notified, or the key of a fingerprint whose state is to be sent to the
+// io-thread t.
+type keyChange struct {
+	c            *cmd.Cmd
+	key          string
+	t            *IOThread
+	shardManager *shardmanager.ShardManager
+	// fp is the fingerprint whose state is to be sent, if not zero.
+	fp uint64
+}
+
+// notifier carries out the watch notifications off the path of the writing
+// clients. The modified keys are handed to a fixed set of workers, a key
+// always going to the same worker so that its notifications are produced in
+// the order of its changes. The workers execute the watched commands and queue
+// the results to the io-threads of the watchers, each sending its queue on its
+// own, so that a slow watcher does not hold back the others.
+type notifier struct {
+	workers []chan keyChange
+}
+
+func newNotifier(w *WatchManager) *notifier {
+	n := &notifier{workers: make([]chan keyChange, runtime.NumCPU())}
+	for i := range n.workers {
+		n.workers[i] = make(chan keyChange, notifierQueueSize)
+		go func(changes chan keyChange) {
+			for kc := range changes {
+				w.notifyKey(kc)
+			}
+		}(n.workers[i])
+	}
+	return n
+}
+
+func (n *notifier) submit(kc keyChange) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(kc.key))
+	n.workers[h.Sum32()%uint32(len(n.workers))] <- kc
+}
+
+// watchQueue holds the notifications waiting to be sent on the connection
+// of an io-thread. The notifications are sent by a goroutine started once
+// the first of them is queued, which returns once the queue is empty, so
+// that an idle watcher does not hold a goroutine.
+type watchQueue struct {
+	mu      sync.Mutex
+	queue   []*wire.Result
+	sending bool
+	stopped bool
+}
+
+func newWatchQueue() *watchQueue {
+	return &watchQueue{}
+}
+
+// enqueue queues the notification for the io-thread t. When the queue is full
+// the oldest notification is dropped, or the io-thread is disconnected,
+// depending on the slow consumer policy.
+func (w *WatchManager) enqueue(t *IOThread, rs *wire.Result) {
+	q := t.watchQueue
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+
+	size := config.Config.WatchQueueSize
+	if size > 0 && len(q.queue) >= size {
+		if config.Config.WatchSlowConsumerPolicy == slowConsumerDisconnect {
+			q.stopped = true
+			q.queue = nil
+			q.mu.Unlock()
+
+			w.stats.disconnected.Add(1)
+			slog.Warn("watcher exceeded the watch queue size, disconnecting it",
+				slog.String("client_id", t.ClientID),
+				slog.Int("size", size))
+			t.serverWire.Close()
+			return
+		}
+		q.queue = q.queue[1:]
+		w.stats.dropped.Add(1)
+	}
+	q.queue = append(q.queue, rs)
+	if !q.sending {
+		q.sending = true
+		go q.deliver(t, w.stats)
+	}
+	q.mu.Unlock()
+}
+
+// deliver sends the queued notifications on the connection of the
+// io-thread until the queue is empty or stopped. The queue is stopped and its
+// connection closed once a notification fails to be sent, as the following
+// ones would be sent after a gap, or fail as well.
+func (q *watchQueue) deliver(t *IOThread, stats *watchStats) {
+	for {
+		q.mu.Lock()
+		queue := q.queue
+		q.queue = nil
+		if len(queue) == 0 || q.stopped {
+			q.sending = false
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+
+		for _, rs := range queue {
+			if err := t.serverWire.Send(context.Background(), rs); err != nil {
+				slog.Error("failed to write response to thread",
+					slog.Any("client_id", t.ClientID),
+					slog.String("mode", t.Mode),
+					slog.Any("error", err))
+				q.mu.Lock()
+				q.stopped = true
+				q.queue = nil
+				q.sending = false
+				q.mu.Unlock()
+				t.serverWire.Close()
+				return
+			}
+			stats.sent.Add(1)
+		}
+	}
+}
+
+// stop drops the queued notifications and stops their delivery.
+func (q *watchQueue) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopped = true
+	q.queue = nil
+}
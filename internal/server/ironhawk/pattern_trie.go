@@ -0,0 +1,112 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import "strings"
+
+// patternTrie indexes the fingerprints of the pattern watches by the literal
+// prefix of their pattern, that is the part before the first wildcard. A
+// modified key is then matched only against the patterns whose literal prefix
+// the key starts with, rather than against every pattern.
+type patternTrie struct {
+	root *patternTrieNode
+}
+
+type patternTrieNode struct {
+	children map[byte]*patternTrieNode
+	// fps are the fingerprints of the patterns whose literal prefix
+	// ends at this node.
+	fps map[uint64]bool
+}
+
+func newPatternTrie() *patternTrie {
+	return &patternTrie{root: newPatternTrieNode()}
+}
+
+func newPatternTrieNode() *patternTrieNode {
+	return &patternTrieNode{
+		children: map[byte]*patternTrieNode{},
+		fps:      map[uint64]bool{},
+	}
+}
+
+// literalPrefix returns the part of the pattern before its first wildcard,
+// character class or escape.
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?[\\"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+func (t *patternTrie) insert(pattern string, fp uint64) {
+	node := t.root
+	prefix := literalPrefix(pattern)
+	for i := 0; i < len(prefix); i++ {
+		child, ok := node.children[prefix[i]]
+		if !ok {
+			child = newPatternTrieNode()
+			node.children[prefix[i]] = child
+		}
+		node = child
+	}
+	node.fps[fp] = true
+}
+
+// remove removes the fingerprint of the pattern and prunes the nodes
+// left without any pattern.
+func (t *patternTrie) remove(pattern string, fp uint64) {
+	prefix := literalPrefix(pattern)
+	path := []*patternTrieNode{t.root}
+	node := t.root
+	for i := 0; i < len(prefix); i++ {
+		node = node.children[prefix[i]]
+		if node == nil {
+			return
+		}
+		path = append(path, node)
+	}
+	delete(node.fps, fp)
+
+	for i := len(path) - 1; i > 0; i-- {
+		n := path[i]
+		if len(n.fps) > 0 || len(n.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, prefix[i-1])
+	}
+}
+
+// size returns an estimate of the memory, in bytes, held by the trie.
+func (t *patternTrie) size() int {
+	n := 0
+	nodes := []*patternTrieNode{t.root}
+	for len(nodes) > 0 {
+		node := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		n += mapEntrySize + len(node.fps)*mapEntrySize
+		for _, child := range node.children {
+			nodes = append(nodes, child)
+		}
+	}
+	return n
+}
+
+// match calls fn with the fingerprints of the patterns whose literal
+// prefix is a prefix of the key. The caller must still match the key
+// against the pattern.
+func (t *patternTrie) match(key string, fn func(fp uint64)) {
+	node := t.root
+	for i := 0; ; i++ {
+		for fp := range node.fps {
+			fn(fp)
+		}
+		if i == len(key) {
+			return
+		}
+		if node = node.children[key[i]]; node == nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dice/internal/regex"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// pubSubCmds are the commands whose effect is carried out by the io-thread,
+// once the command has been validated by its evaluation.
+var pubSubCmds = map[string]bool{
+	"PUBLISH":      true,
+	"SUBSCRIBE":    true,
+	"PSUBSCRIBE":   true,
+	"UNSUBSCRIBE":  true,
+	"PUNSUBSCRIBE": true,
+	"PUBSUB":       true,
+}
+
+func isPubSubCmd(c string) bool {
+	return pubSubCmds[c]
+}
+
+// subscriber holds the subscriptions of a client and the messages
+// waiting to be sent on its watch connection.
+type subscriber struct {
+	clientID string
+	// owner is the io-thread the client subscribed from; the subscriptions
+	// are dropped once it disconnects.
+	owner    *IOThread
+	channels map[string]bool
+	patterns map[string]bool
+
+	mu    sync.Mutex
+	queue []*wire.Result
+	// size is the total size, in bytes, of the queued messages.
+	size int
+	// sending is set while a goroutine delivers the queued messages,
+	// which returns once there is none left.
+	sending bool
+	removed bool
+}
+
+func newSubscriber(clientID string, owner *IOThread) *subscriber {
+	return &subscriber{
+		clientID: clientID,
+		owner:    owner,
+		channels: map[string]bool{},
+		patterns: map[string]bool{},
+	}
+}
+
+func (s *subscriber) count() int {
+	return len(s.channels) + len(s.patterns)
+}
+
+// enqueue queues the message for delivery. It returns false if the queued
+// messages exceed the output buffer limit of the subscriber, if it has one.
+func (s *subscriber) enqueue(w *WatchManager, rs *wire.Result) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.removed {
+		return true
+	}
+	s.queue = append(s.queue, rs)
+	s.size += proto.Size(rs)
+	if !s.sending {
+		s.sending = true
+		go s.deliver(w)
+	}
+	limit := config.Config.PubSubOutputBufferLimitBytes
+	return limit <= 0 || s.size <= limit
+}
+
+// deliver sends the queued messages on the watch connection of the client
+// until there is none left, the subscriber is removed or a message fails to
+// be sent. The messages that cannot be sent are dropped.
+func (s *subscriber) deliver(w *WatchManager) {
+	for {
+		s.mu.Lock()
+		queue := s.queue
+		s.queue, s.size = nil, 0
+		if len(queue) == 0 || s.removed {
+			s.sending = false
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		thread := w.watchThread(s.clientID)
+		if thread == nil {
+			w.stats.pubsubDropped.Add(int64(len(queue)))
+			continue
+		}
+		for i, rs := range queue {
+			if err := thread.serverWire.Send(context.Background(), rs); err != nil {
+				slog.Error("failed to deliver message to subscriber",
+					slog.String("client_id", s.clientID),
+					slog.Any("error", err))
+				w.stats.pubsubDropped.Add(int64(len(queue) - i))
+				s.mu.Lock()
+				s.sending = false
+				s.mu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// pubSub holds the channel and pattern subscriptions of the clients.
+// Messages are delivered on the watch connection of the clients, hence
+// a client not having one is subscribed but does not receive messages.
+type pubSub struct {
+	mu          sync.RWMutex
+	channels    map[string]map[string]bool
+	patterns    map[string]map[string]bool
+	subscribers map[string]*subscriber
+}
+
+func newPubSub() *pubSub {
+	return &pubSub{
+		channels:    map[string]map[string]bool{},
+		patterns:    map[string]map[string]bool{},
+		subscribers: map[string]*subscriber{},
+	}
+}
+
+func newPubSubCountRes(n int) *cmd.CmdRes {
+	return &cmd.CmdRes{
+		Rs: &wire.Result{
+			Status:   wire.Status_OK,
+			Message:  "OK",
+			Response: &wire.Result_INCRRes{INCRRes: &wire.INCRRes{Value: int64(n)}},
+		},
+	}
+}
+
+func newPubSubListRes(values []string) *wire.Result {
+	return &wire.Result{
+		Status:   wire.Status_OK,
+		Message:  "OK",
+		Response: &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: values}},
+	}
+}
+
+// watchThread returns the watch io-thread of the client, if it has one.
+func (w *WatchManager) watchThread(clientID string) *IOThread {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	t := w.clientWatchThreadMap[clientID]
+	if t == nil || t.Mode != "watch" {
+		return nil
+	}
+	return t
+}
+
+// HandlePubSub carries out the Pub/Sub command c, received by the io-thread t.
+func (w *WatchManager) HandlePubSub(c *cmd.Cmd, t *IOThread) (*cmd.CmdRes, error) {
+	ps := w.pubsub
+	args := c.C.Args
+	switch c.C.Cmd {
+	case "PUBLISH":
+		return newPubSubCountRes(w.publish(args[0], args[1])), nil
+	case "SUBSCRIBE", "PSUBSCRIBE":
+		if t.ClientID == "" {
+			return nil, errors.ErrGeneral("HANDSHAKE is required before " + c.C.Cmd)
+		}
+		return newPubSubCountRes(ps.subscribe(t, args, c.C.Cmd == "PSUBSCRIBE")), nil
+	case "UNSUBSCRIBE", "PUNSUBSCRIBE":
+		return newPubSubCountRes(ps.unsubscribe(t.ClientID, args, c.C.Cmd == "PUNSUBSCRIBE")), nil
+	}
+
+	// PUBSUB
+	if strings.ToUpper(args[0]) == "CHANNELS" {
+		pattern := "*"
+		if len(args) > 1 {
+			pattern = args[1]
+		}
+		return &cmd.CmdRes{Rs: newPubSubListRes(ps.activeChannels(pattern))}, nil
+	}
+	return &cmd.CmdRes{Rs: newPubSubListRes(ps.numSub(args[1:]))}, nil
+}
+
+// index returns the subscribers of the patterns, if pattern is true,
+// or of the channels, along with the subscriptions of sub.
+func (ps *pubSub) index(sub *subscriber, pattern bool) (index map[string]map[string]bool, subscriptions map[string]bool) {
+	if pattern {
+		return ps.patterns, sub.patterns
+	}
+	return ps.channels, sub.channels
+}
+
+func (ps *pubSub) subscribe(t *IOThread, names []string, pattern bool) int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub := ps.subscribers[t.ClientID]
+	if sub == nil {
+		sub = newSubscriber(t.ClientID, t)
+		ps.subscribers[t.ClientID] = sub
+	}
+	sub.owner = t
+
+	index, subscriptions := ps.index(sub, pattern)
+	for _, name := range names {
+		if _, ok := index[name]; !ok {
+			index[name] = map[string]bool{}
+		}
+		index[name][t.ClientID] = true
+		subscriptions[name] = true
+	}
+	return sub.count()
+}
+
+// unsubscribe removes the subscriptions of the client to the names,
+// or to all the channels or patterns if no name is given.
+func (ps *pubSub) unsubscribe(clientID string, names []string, pattern bool) int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub := ps.subscribers[clientID]
+	if sub == nil {
+		return 0
+	}
+
+	index, subscriptions := ps.index(sub, pattern)
+	if len(names) == 0 {
+		for name := range subscriptions {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		delete(subscriptions, name)
+		delete(index[name], clientID)
+		if len(index[name]) == 0 {
+			delete(index, name)
+		}
+	}
+
+	if sub.count() == 0 {
+		ps.removeLocked(sub)
+	}
+	return sub.count()
+}
+
+// removeLocked drops all the subscriptions of sub and its queued messages.
+// The caller must hold ps.mu.
+func (ps *pubSub) removeLocked(sub *subscriber) {
+	if ps.subscribers[sub.clientID] != sub {
+		return
+	}
+	for _, pattern := range []bool{false, true} {
+		index, subscriptions := ps.index(sub, pattern)
+		for name := range subscriptions {
+			delete(index[name], sub.clientID)
+			if len(index[name]) == 0 {
+				delete(index, name)
+			}
+		}
+	}
+	delete(ps.subscribers, sub.clientID)
+
+	sub.mu.Lock()
+	sub.removed = true
+	sub.queue, sub.size = nil, 0
+	sub.mu.Unlock()
+}
+
+// removeThread drops the subscriptions made from the io-thread t.
+func (ps *pubSub) removeThread(t *IOThread) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if sub := ps.subscribers[t.ClientID]; sub != nil && sub.owner == t {
+		ps.removeLocked(sub)
+	}
+}
+
+type delivery struct {
+	sub *subscriber
+	rs  *wire.Result
+}
+
+// publish queues the message for the subscribers of the channel and of the
+// patterns matching it, and returns the number of deliveries. Only the
+// clients having a watch connection are counted.
+func (w *WatchManager) publish(channel, message string) int {
+	ps := w.pubsub
+	var deliveries []delivery
+
+	ps.mu.RLock()
+	for clientID := range ps.channels[channel] {
+		deliveries = append(deliveries, delivery{
+			sub: ps.subscribers[clientID],
+			rs:  newPubSubListRes([]string{"message", channel, message}),
+		})
+	}
+	for pattern, clientIDs := range ps.patterns {
+		if !regex.WildCardMatch(pattern, channel) {
+			continue
+		}
+		for clientID := range clientIDs {
+			deliveries = append(deliveries, delivery{
+				sub: ps.subscribers[clientID],
+				rs:  newPubSubListRes([]string{"pmessage", pattern, channel, message}),
+			})
+		}
+	}
+	ps.mu.RUnlock()
+
+	count := 0
+	for _, d := range deliveries {
+		thread := w.watchThread(d.sub.clientID)
+		if thread == nil {
+			continue
+		}
+		count++
+		if d.sub.enqueue(w, d.rs) {
+			continue
+		}
+
+		// The subscriber does not keep up with the messages, hence it is
+		// disconnected rather than letting its queue grow unbounded.
+		slog.Warn("subscriber exceeded the output buffer limit, disconnecting it",
+			slog.String("client_id", d.sub.clientID),
+			slog.Int("limit", config.Config.PubSubOutputBufferLimitBytes))
+		ps.mu.Lock()
+		ps.removeLocked(d.sub)
+		ps.mu.Unlock()
+		thread.serverWire.Close()
+	}
+	return count
+}
+
+// activeChannels returns the channels having at least one subscriber
+// and matching the pattern, sorted by name.
+func (ps *pubSub) activeChannels(pattern string) []string {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	channels := []string{}
+	for channel := range ps.channels {
+		if regex.WildCardMatch(pattern, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// numSub returns the channels along with their number of subscribers,
+// as a flat list of channel and count pairs.
+func (ps *pubSub) numSub(channels []string) []string {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	res := make([]string, 0, 2*len(channels))
+	for _, channel := range channels {
+		res = append(res, channel, strconv.Itoa(len(ps.channels[channel])))
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// watchThrottle coalesces the notifications of the subscription of a client
+// so that at most one is sent per interval. The first update is sent right
+// away, and the updates received within the interval are coalesced into the
+// latest one, sent once the interval elapses.
+type watchThrottle struct {
+	interval time.Duration
+	w        *WatchManager
+
+	mu       sync.Mutex
+	lastSent time.Time
+	pending  *wire.Result
+	thread   *IOThread
+	timer    *time.Timer
+}
+
+func newWatchThrottle(interval time.Duration, w *WatchManager) *watchThrottle {
+	return &watchThrottle{interval: interval, w: w}
+}
+
+// notify queues the result to the thread, or keeps it to be queued once the
+// interval elapses if a notification was queued less than an interval ago.
+func (th *watchThrottle) notify(thread *IOThread, rs *wire.Result) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	elapsed := time.Since(th.lastSent)
+	if th.timer == nil && elapsed >= th.interval {
+		th.lastSent = time.Now()
+		th.w.enqueue(thread, rs)
+		return
+	}
+
+	if th.pending != nil {
+		th.w.stats.coalesced.Add(1)
+	}
+	th.pending, th.thread = rs, thread
+	if th.timer == nil {
+		th.timer = time.AfterFunc(th.interval-elapsed, th.flush)
+	}
+}
+
+// flush queues the latest result received during the interval.
+func (th *watchThrottle) flush() {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.timer = nil
+	if th.pending == nil {
+		return
+	}
+	th.lastSent = time.Now()
+	th.w.enqueue(th.thread, th.pending)
+	th.pending, th.thread = nil, nil
+}
+
+// stop drops the pending result, if any.
+func (th *watchThrottle) stop() {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	if th.timer != nil {
+		th.timer.Stop()
+		th.timer = nil
+	}
+	if th.pending != nil {
+		th.w.stats.coalesced.Add(1)
+	}
+	th.pending, th.thread = nil, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// tlsHandshakeTimeout bounds the time a client is given to complete the TLS
+// handshake, so that a client that never does is not kept around.
+const tlsHandshakeTimeout = 10 * time.Second
+
+// tlsCertificates holds the TLS configuration built from the certificate
+// files, which is swapped on reload. The connections already established
+// keep the configuration they were accepted with.
+type tlsCertificates struct {
+	certFile     string
+	keyFile      string
+	caCertFile   string
+	clientAuth   tls.ClientAuthType
+	current      atomic.Pointer[tls.Config]
+	serverConfig *tls.Config
+}
+
+// newTLSCertificates loads the certificates of the tls- options.
+func newTLSCertificates(certFile, keyFile, caCertFile, authClients string) (*tlsCertificates, error) {
+	c := &tlsCertificates{certFile: certFile, keyFile: keyFile, caCertFile: caCertFile}
+	switch authClients {
+	case "yes":
+		c.clientAuth = tls.RequireAndVerifyClientCert
+	case "optional":
+		c.clientAuth = tls.VerifyClientCertIfGiven
+	case "no":
+		c.clientAuth = tls.NoClientCert
+	default:
+		return nil, fmt.Errorf("invalid tls-auth-clients '%s', must be yes, optional or no", authClients)
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("tls-cert-file and tls-key-file are required to accept TLS connections")
+	}
+	if c.clientAuth != tls.NoClientCert && caCertFile == "" {
+		return nil, errors.New("tls-ca-cert-file is required to authenticate the TLS clients")
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	// The configuration of each connection is the one loaded last.
+	c.serverConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return c.current.Load(), nil
+		},
+	}
+	return c, nil
+}
+
+// load reads the certificate files, and swaps the configuration once they
+// all are valid, so that a failed reload leaves the previous one in use.
+func (c *tlsCertificates) load() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if c.caCertFile != "" {
+		pem, err := os.ReadFile(c.caCertFile)
+		if err != nil {
+			return fmt.Errorf("failed to load the TLS CA certificates: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("failed to load the TLS CA certificates: no certificate found in %s", c.caCertFile)
+		}
+	}
+
+	c.current.Store(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   c.clientAuth,
+		ClientCAs:    clientCAs,
+	})
+	return nil
+}
+
+// ReloadTLS reloads the TLS certificates from their files, the new
+// connections being accepted with them. It is a no-op if TLS is disabled.
+func (s *Server) ReloadTLS() error {
+	if s.tlsCerts == nil {
+		return nil
+	}
+	if err := s.tlsCerts.load(); err != nil {
+		return err
+	}
+	slog.Info("reloaded TLS certificates", slog.String("cert_file", s.tlsCerts.certFile))
+	return nil
+}
+
+// serveTLS reads the commands of a TLS connection. Its records are decrypted
+// by crypto/tls, hence the connection is read on a goroutine of its own
+// rather than by the event loop, and is closed once idle for longer than the
+// timeout in the same way.
+func (s *Server) serveTLS(thread *IOThread) {
+	w := thread.serverWire
+	err := w.handshake(tlsHandshakeTimeout)
+	scratch := make([]byte, readBufferSize)
+	for err == nil {
+		timeout := time.Duration(config.Config.Timeout) * time.Second
+		if timeout > 0 {
+			_ = w.conn.SetReadDeadline(time.Now().Add(idleCheckInterval))
+		}
+
+		var cmds []*wire.Command
+		cmds, err = w.readConn(scratch)
+		if thread.receive(cmds) {
+			s.jobs <- thread
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			if thread.expire(time.Now(), timeout) {
+				s.stopIOThread(thread)
+				return
+			}
+			err = nil
+		}
+	}
+
+	if thread.close(err) {
+		s.stopIOThread(thread)
+	}
+}
+
+// maxTLSRejects bounds the rejected TLS clients being sent the reason of their
+// rejection, each of which takes a handshake. The clients rejected beyond it
+// are disconnected right away.
+const maxTLSRejects = 16
+
+// rejectTLS completes the TLS handshake of a rejected client, and sends it
+// the error err as the result of its first command before closing its
+// connection, the same way reject does for the other connections. The
+// connection is closed right away once the server starts shutting down.
+func (s *Server) rejectTLS(thread *IOThread, err error) {
+	defer s.ioThreadWg.Done()
+	defer func() { <-s.tlsRejects }()
+	defer func() { _ = thread.Stop() }()
+	w := thread.serverWire
+
+	rejected := make(chan struct{})
+	defer close(rejected)
+	go func() {
+		select {
+		case <-s.draining:
+			w.close()
+		case <-rejected:
+		}
+	}()
+	if hsErr := w.handshake(tlsHandshakeTimeout); hsErr != nil {
+		return
+	}
+	_ = w.conn.SetReadDeadline(time.Now().Add(tlsHandshakeTimeout))
+	scratch := make([]byte, readBufferSize)
+	for {
+		cmds, readErr := w.readConn(scratch)
+		if len(cmds) > 0 || readErr != nil {
+			break
+		}
+	}
+	rs := &wire.Result{Status: wire.Status_ERR, Message: err.Error()}
+	if sendErr := w.Send(s.ioCtx, rs); sendErr != nil {
+		slog.Debug("failed to notify the rejected client", slog.Uint64("conn_id", thread.ID), slog.Any("error", sendErr.Unwrap()))
+	}
+}
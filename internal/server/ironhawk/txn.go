@@ -0,0 +1,82 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"strings"
+
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// txn holds the commands queued by a client between MULTI and EXEC.
+type txn struct {
+	cmds []*wire.Command
+
+	// aborted is set when a command could not be queued,
+	// in which case EXEC discards the transaction.
+	aborted bool
+}
+
+func newTxnErrRes(err error) *wire.Result {
+	return &wire.Result{
+		Status:  wire.Status_ERR,
+		Message: err.Error(),
+	}
+}
+
+var txnQueuedRes = &wire.Result{
+	Status:  wire.Status_OK,
+	Message: "QUEUED",
+}
+
+// handleTxn queues the command if the client is in a transaction. It returns
+// the command to execute, which for EXEC carries the queued commands, or the
+// result to reply with if the command should not be executed.
+func (t *IOThread) handleTxn(c *wire.Command) (*wire.Command, *wire.Result) {
+	if t.txn == nil {
+		if c.Cmd == "EXEC" && len(c.Args) > 0 {
+			return nil, newTxnErrRes(errors.ErrWrongArgumentCount("EXEC"))
+		}
+		return c, nil
+	}
+
+	switch c.Cmd {
+	case "EXEC":
+		tx := t.txn
+		t.txn = nil
+		if len(c.Args) > 0 {
+			return nil, newTxnErrRes(errors.ErrWrongArgumentCount("EXEC"))
+		}
+		if tx.aborted {
+			return nil, newTxnErrRes(errors.ErrGeneral("EXECABORT Transaction discarded because of previous errors"))
+		}
+		if len(tx.cmds) == 0 {
+			return nil, cmd.EXECResNilRes.Rs
+		}
+		return cmd.NewEXECCommand(tx.cmds), nil
+	case "DISCARD":
+		t.txn = nil
+		return nil, cmd.DISCARDResOKRes.Rs
+	case "MULTI":
+		return nil, newTxnErrRes(errors.ErrGeneral("MULTI calls can not be nested"))
+	}
+
+	var err error
+	switch _, ok := cmd.CommandRegistry.CommandMetas[c.Cmd]; {
+	case !ok:
+		err = errors.ErrUnknownCmd(c.Cmd)
+	case c.Cmd == "HANDSHAKE" || strings.HasSuffix(c.Cmd, "WATCH") || strings.HasPrefix(c.Cmd, "WATCH.") ||
+		isPubSubCmd(c.Cmd):
+		err = errors.ErrGeneral(c.Cmd + " is not allowed in a transaction")
+	}
+	if err != nil {
+		t.txn.aborted = true
+		return nil, newTxnErrRes(err)
+	}
+
+	t.txn.cmds = append(t.txn.cmds, c)
+	return nil, txnQueuedRes
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// The operations of a diff frame, each followed by the member, or field, and
+// its score, or value, which is empty for the removed ones.
+const (
+	diffOpSet    = "+"
+	diffOpRemove = "-"
+)
+
+// watchDiff keeps the last result of a fingerprint watched in the DIFF mode,
+// against which the elements changed by the next result are computed. The
+// clients in the DIFF mode share it, hence a client starts from it and is
+// sent every diff computed afterwards.
+type watchDiff struct {
+	mu   sync.Mutex
+	last *wire.Result
+	// frames is the number of results since the subscription started.
+	frames int
+}
+
+// state returns the last result of the fingerprint, if any.
+func (d *watchDiff) state() *wire.Result {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.last
+}
+
+// init sets the first result of the fingerprint, unless it already has one.
+func (d *watchDiff) init(rs *wire.Result) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.last == nil {
+		d.last = rs
+	}
+}
+
+// size returns the size, in bytes, of the last result of the fingerprint.
+func (d *watchDiff) size() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.last == nil {
+		return 0
+	}
+	return proto.Size(d.last)
+}
+
+// next returns the frame to send for the new result of the fingerprint: the
+// whole result every watch-diff-full-frame-interval results, so that a client
+// having missed a diff resynchronizes, and else the elements changed since
+// the previous result.
+func (d *watchDiff) next(rs *wire.Result) *wire.Result {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prev := d.last
+	d.last = rs
+	d.frames++
+	interval := config.Config.WatchDiffFullFrameInterval
+	if prev == nil || (interval > 0 && d.frames%interval == 0) {
+		return rs
+	}
+	return newDiffFrame(prev, rs)
+}
+
+// newDiffFrame returns the elements set or removed by the result cur since the
+// result prev, as a flat list of operation, member and score triples sorted by
+// member. The message of the frame is the one of cur followed by "diff", and
+// the frame carries the sequence number of cur.
+func newDiffFrame(prev, cur *wire.Result) *wire.Result {
+	before, after := diffElements(prev), diffElements(cur)
+	members := make([]string, 0, len(after))
+	for member, value := range after {
+		if v, ok := before[member]; !ok || v != value {
+			members = append(members, member)
+		}
+	}
+	for member := range before {
+		if _, ok := after[member]; !ok {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+
+	ops := make([]string, 0, 3*len(members))
+	for _, member := range members {
+		if value, ok := after[member]; ok {
+			ops = append(ops, diffOpSet, member, value)
+		} else {
+			ops = append(ops, diffOpRemove, member, "")
+		}
+	}
+	frame := &wire.Result{
+		Status:        cur.Status,
+		Message:       cur.Message + " diff",
+		Fingerprint64: cur.Fingerprint64,
+		Response:      &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: ops}},
+	}
+	frame.ProtoReflect().SetUnknown(cur.ProtoReflect().GetUnknown())
+	return frame
+}
+
+// diffElements returns the members of the sorted set along with their score,
+// or the fields of the hash along with their value, of the result.
+func diffElements(rs *wire.Result) map[string]string {
+	elements := map[string]string{}
+	switch r := rs.Response.(type) {
+	case *wire.Result_ZRANGERes:
+		for _, e := range r.ZRANGERes.Elements {
+			elements[e.Member] = strconv.FormatInt(e.Score, 10)
+		}
+	case *wire.Result_HGETALLRes:
+		for _, e := range r.HGETALLRes.Elements {
+			elements[e.Key] = e.Value
+		}
+	}
+	return elements
+}
+
+// setDiff sets the DIFF mode of the subscription of the client to the fingerprint.
+func (w *WatchManager) setDiff(fp uint64, clientID string, diff bool) {
+	if !diff {
+		w.removeDiff(fp, clientID)
+		return
+	}
+	if _, ok := w.fpDiffClients[fp]; !ok {
+		w.fpDiffClients[fp] = make(map[string]bool)
+		w.fpDiffMap[fp] = &watchDiff{}
+	}
+	w.fpDiffClients[fp][clientID] = true
+}
+
+// removeDiff removes the client from the DIFF mode of the fingerprint, and
+// the last result of the fingerprint once no client is in the DIFF mode.
+func (w *WatchManager) removeDiff(fp uint64, clientID string) {
+	if !w.fpDiffClients[fp][clientID] {
+		return
+	}
+	delete(w.fpDiffClients[fp], clientID)
+	if len(w.fpDiffClients[fp]) == 0 {
+		delete(w.fpDiffClients, fp)
+		delete(w.fpDiffMap, fp)
+	}
+}
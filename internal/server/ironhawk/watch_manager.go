@@ -4,16 +4,25 @@
 package ironhawk
 
 import (
-	"context"
 	"log/slog"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/errors"
 	"github.com/dicedb/dice/internal/shardmanager"
+	"github.com/dicedb/dicedb-go/wire"
 )
 
+// isPatternWatchCmd reports whether the command watches the keys
+// matching a pattern rather than a key.
+func isPatternWatchCmd(c string) bool {
+	return c == "KEYS.WATCH"
+}
+
 type WatchManager struct {
 	mu                   sync.RWMutex
 	clientWatchThreadMap map[string]*IOThread
@@ -21,28 +30,98 @@ type WatchManager struct {
 	keyFPMap    map[string]map[uint64]bool
 	fpClientMap map[uint64]map[string]bool
 	fpCmdMap    map[uint64]*cmd.Cmd
+	// clientFPMap and fpKeysMap are the reverse indexes of fpClientMap
+	// and keyFPMap, so that removing the subscriptions of a client or a
+	// fingerprint does not go through the whole indexes.
+	clientFPMap map[string]map[uint64]bool
+	fpKeysMap   map[uint64][]string
+	// fpSeqMap holds the sequence number of the latest notification
+	// of each fingerprint.
+	fpSeqMap map[uint64]*watchSeq
+
+	// detachedClients holds the clients disconnected while having
+	// watch subscriptions, until they resume or the grace period elapses.
+	detachedClients map[string]*detachedClient
+
+	// patternTrie holds the fingerprints of the pattern watches.
+	patternTrie *patternTrie
+
+	// fpThrottleMap holds the throttles of the subscriptions
+	// created with a throttle interval, by fingerprint and client id.
+	fpThrottleMap map[uint64]map[string]*watchThrottle
+	stats         *watchStats
+
+	// fpDiffClients holds the clients subscribed in the DIFF mode, and
+	// fpDiffMap the last result of their fingerprints, by fingerprint.
+	fpDiffClients map[uint64]map[string]bool
+	fpDiffMap     map[uint64]*watchDiff
+
+	// fpPendingClients holds the clients whose subscription to the
+	// fingerprint is yet to be sent its current state. They are not
+	// notified of the changes until then, as the state includes them.
+	fpPendingClients map[uint64]map[string]bool
+
+	notifier *notifier
+
+	pubsub *pubSub
 }
 
 func NewWatchManager() *WatchManager {
-	return &WatchManager{
+	w := &WatchManager{
 		clientWatchThreadMap: map[string]*IOThread{},
 
 		keyFPMap:    map[string]map[uint64]bool{},
 		fpClientMap: map[uint64]map[string]bool{},
 		fpCmdMap:    map[uint64]*cmd.Cmd{},
+		clientFPMap: map[string]map[uint64]bool{},
+		fpKeysMap:   map[uint64][]string{},
+		fpSeqMap:    map[uint64]*watchSeq{},
+
+		detachedClients: map[string]*detachedClient{},
+
+		patternTrie: newPatternTrie(),
+
+		fpThrottleMap: map[uint64]map[string]*watchThrottle{},
+		stats:         &watchStats{},
+
+		fpDiffClients: map[uint64]map[string]bool{},
+		fpDiffMap:     map[uint64]*watchDiff{},
+
+		fpPendingClients: map[uint64]map[string]bool{},
+
+		pubsub: newPubSub(),
 	}
+	w.notifier = newNotifier(w)
+	return w
 }
 
-func (w *WatchManager) RegisterThread(t *IOThread) {
-	if t.Mode == "watch" {
-		// Only acquire lock if we are in "watch" mode.
-		w.mu.Lock()
-		defer w.mu.Unlock()
-		w.clientWatchThreadMap[t.ClientID] = t
+// RegisterThread completes the HANDSHAKE of the io-thread t with the client id
+// and the mode. A connection completes its HANDSHAKE once, and a client id
+// has at most one watch connection, so that the notifications of a client
+// are not sent to the watch connection of another one using the same id.
+func (w *WatchManager) RegisterThread(t *IOThread, clientID, mode string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t.ClientID != "" {
+		if t.ClientID == clientID && t.Mode == mode {
+			return nil
+		}
+		return errors.ErrGeneral("the connection has already completed its HANDSHAKE")
 	}
+	if mode == "watch" {
+		if cur := w.clientWatchThreadMap[clientID]; cur != nil && cur != t && cur.Mode == "watch" {
+			return errors.ErrGeneral("client id '" + clientID + "' already has a watch connection")
+		}
+		w.clientWatchThreadMap[clientID] = t
+	}
+	t.ClientID, t.Mode = clientID, mode
+	return nil
 }
 
-func (w *WatchManager) HandleWatch(c *cmd.Cmd, t *IOThread) {
+// HandleWatch subscribes the client to the .WATCH command c, with the
+// options given after the arguments of the command.
+func (w *WatchManager) HandleWatch(c *cmd.Cmd, t *IOThread, opts watchOptions) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
@@ -53,26 +132,140 @@ func (w *WatchManager) HandleWatch(c *cmd.Cmd, t *IOThread) {
 		slog.Any("fingerprint", fp),
 		slog.String("client_id", t.ClientID))
 
-	// For the key that will be watched through any .WATCH command
+	w.attachClient(t.ClientID)
+	w.subscribe(fp, c, t.ClientID)
+
+	if isPatternWatchCmd(c.C.Cmd) {
+		// The changes of the keys matching the pattern are sent on the watch
+		// connection of the client, hence the thread of the client is left as is.
+		w.patternTrie.insert(c.C.Args[0], fp)
+		return
+	}
+
+	// For every key read by the .WATCH command, as declared by its KeySpec
 	// Create an entry in the map that holds, key <--> [command fingerprint] as map
-	if _, ok := w.keyFPMap[key]; !ok {
-		w.keyFPMap[key] = make(map[uint64]bool)
+	if _, ok := w.fpKeysMap[fp]; !ok {
+		var keys []string
+		for _, key := range c.Keys() {
+			if _, ok := w.keyFPMap[key]; !ok {
+				w.keyFPMap[key] = make(map[uint64]bool)
+			}
+			if !w.keyFPMap[key][fp] {
+				w.keyFPMap[key][fp] = true
+				keys = append(keys, key)
+			}
+		}
+		w.fpKeysMap[fp] = keys
 	}
-	w.keyFPMap[key][fp] = true
 
+	w.setThrottle(fp, t.ClientID, opts.throttle)
+	w.setDiff(fp, t.ClientID, opts.diff)
+	w.setPending(fp, t.ClientID)
+
+	// The notifications are sent on the watch connection of the client, hence
+	// a .WATCH command sent on its command connection does not replace it.
+	if cur := w.clientWatchThreadMap[t.ClientID]; cur == nil || cur.Mode != "watch" {
+		w.clientWatchThreadMap[t.ClientID] = t
+	}
+}
+
+// subscribe adds the subscription of the client to the fingerprint of c.
+func (w *WatchManager) subscribe(fp uint64, c *cmd.Cmd, clientID string) {
 	// For the fingerprint
 	// Create an entry in the map that holds, fingerprint <--> [client id] as map
 	// This tells us which clients are subscribed to a particular fingerprint
 	if _, ok := w.fpClientMap[fp]; !ok {
 		w.fpClientMap[fp] = make(map[string]bool)
 	}
-	w.fpClientMap[fp][t.ClientID] = true
+	w.fpClientMap[fp][clientID] = true
+
+	// And the reverse one, so that the subscriptions of a client
+	// are removed without going through every fingerprint.
+	if _, ok := w.clientFPMap[clientID]; !ok {
+		w.clientFPMap[clientID] = make(map[uint64]bool)
+	}
+	w.clientFPMap[clientID][fp] = true
 
 	// Store the fingerprint <--> command mapping
 	// so that we understand what should we execute when the data changes
 	w.fpCmdMap[fp] = c
+	if _, ok := w.fpSeqMap[fp]; !ok {
+		w.fpSeqMap[fp] = &watchSeq{}
+	}
+}
+
+// unsubscribe removes the subscription of the client to the fingerprint,
+// and the fingerprint from the indexes once no client is subscribed to it.
+func (w *WatchManager) unsubscribe(fp uint64, clientID string) {
+	w.removeThrottle(fp, clientID)
+	w.removeDiff(fp, clientID)
+	w.removePending(fp, clientID)
+	delete(w.clientFPMap[clientID], fp)
+	if len(w.clientFPMap[clientID]) == 0 {
+		delete(w.clientFPMap, clientID)
+	}
+
+	// Multiple clients can unsubscribe from the same fingerprint
+	// So, we need to delete the one that is unsubscribing
+	delete(w.fpClientMap[fp], clientID)
+	if len(w.fpClientMap[fp]) > 0 {
+		return
+	}
+	delete(w.fpClientMap, fp)
+
+	if c := w.fpCmdMap[fp]; c != nil && isPatternWatchCmd(c.C.Cmd) {
+		w.patternTrie.remove(c.C.Args[0], fp)
+	}
+	for _, key := range w.fpKeysMap[fp] {
+		delete(w.keyFPMap[key], fp)
+		if len(w.keyFPMap[key]) == 0 {
+			delete(w.keyFPMap, key)
+		}
+	}
+	delete(w.fpKeysMap, fp)
+	delete(w.fpCmdMap, fp)
+	delete(w.fpSeqMap, fp)
+}
+
+// setThrottle sets the throttle interval of the subscription of the client to
+// the fingerprint, replacing the previous one along with its pending update.
+func (w *WatchManager) setThrottle(fp uint64, clientID string, throttle time.Duration) {
+	w.removeThrottle(fp, clientID)
+	if throttle <= 0 {
+		return
+	}
+	if _, ok := w.fpThrottleMap[fp]; !ok {
+		w.fpThrottleMap[fp] = make(map[string]*watchThrottle)
+	}
+	w.fpThrottleMap[fp][clientID] = newWatchThrottle(throttle, w)
+}
 
-	w.clientWatchThreadMap[t.ClientID] = t
+// setPending holds back the notifications of the subscription of the client
+// to the fingerprint until its state is sent.
+func (w *WatchManager) setPending(fp uint64, clientID string) {
+	if _, ok := w.fpPendingClients[fp]; !ok {
+		w.fpPendingClients[fp] = make(map[string]bool)
+	}
+	w.fpPendingClients[fp][clientID] = true
+}
+
+func (w *WatchManager) removePending(fp uint64, clientID string) {
+	delete(w.fpPendingClients[fp], clientID)
+	if len(w.fpPendingClients[fp]) == 0 {
+		delete(w.fpPendingClients, fp)
+	}
+}
+
+func (w *WatchManager) removeThrottle(fp uint64, clientID string) {
+	th := w.fpThrottleMap[fp][clientID]
+	if th == nil {
+		return
+	}
+	th.stop()
+	delete(w.fpThrottleMap[fp], clientID)
+	if len(w.fpThrottleMap[fp]) == 0 {
+		delete(w.fpThrottleMap, fp)
+	}
 }
 
 func (w *WatchManager) HandleUnwatch(c *cmd.Cmd, t *IOThread) {
@@ -88,91 +281,254 @@ func (w *WatchManager) HandleUnwatch(c *cmd.Cmd, t *IOThread) {
 	if err != nil {
 		return
 	}
+	if w.fpClientMap[fp][t.ClientID] {
+		w.unsubscribe(fp, t.ClientID)
+	}
+}
 
-	// Multiple clients can unsubscribe from the same fingerprint
-	// So, we need to delete the one that is unsubscribing
-	delete(w.fpClientMap[fp], t.ClientID)
+func (w *WatchManager) CleanupThreadWatchSubscriptions(t *IOThread) {
+	// The channel and pattern subscriptions are made from the command
+	// connection of the client, and end along with it.
+	w.pubsub.removeThread(t)
 
-	// If a fingerprint has no clients subscribed to it, delete the fingerprint from the map.
-	if len(w.fpClientMap[fp]) == 0 {
-		delete(w.fpClientMap, fp)
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-		// If we have deleted the fingerprint, delete the command from the map
-		delete(w.fpCmdMap, fp)
+	// Delete the mapping of Watch thread to client id, unless the
+	// client has already reconnected on another thread.
+	if w.clientWatchThreadMap[t.ClientID] == t {
+		delete(w.clientWatchThreadMap, t.ClientID)
 	}
 
-	// Delete the mapping where we have the key <--> [command fingerprint]
-	// This seems to be an O(n) operation.
-	// Downside of keeping this entry laying around is that
-	// If key k changes, then we may be iterating through the fingerprint that does not have any active watcher
-	// Hence we should do a lazy deletion.
-
-	// TODO: If the key gets deleted from the database
-	// delete the subscriptions against that key from all the places.
+	// The subscriptions of a client still connected on another thread
+	// are left as is, else they are kept for the client to resume them.
+	if _, ok := w.clientWatchThreadMap[t.ClientID]; ok {
+		return
+	}
+	grace := watchResumeGrace()
+	if grace <= 0 {
+		w.removeClientSubscriptions(t.ClientID)
+		return
+	}
+	w.detachClient(t.ClientID, grace)
 }
 
-func (w *WatchManager) CleanupThreadWatchSubscriptions(t *IOThread) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+// removeClientSubscriptions removes all the subscriptions of the client.
+func (w *WatchManager) removeClientSubscriptions(clientID string) {
+	for fp := range w.clientFPMap[clientID] {
+		w.unsubscribe(fp, clientID)
+	}
+}
 
-	// Delete the mapping of Watch thread to client id
-	delete(w.clientWatchThreadMap, t.ClientID)
+// NotifyWatchers hands the keys of the command to the notifier, which
+// notifies their watchers asynchronously. A .WATCH command hands its key
+// for its initial response, while the read-only commands do not change
+// the keys and hence are not handed.
+func (w *WatchManager) NotifyWatchers(c *cmd.Cmd, shardManager *shardmanager.ShardManager, t *IOThread) {
+	if strings.HasSuffix(c.C.Cmd, ".WATCH") {
+		if !isPatternWatchCmd(c.C.Cmd) {
+			w.notifier.submit(keyChange{c: c, key: c.Key(), t: t, shardManager: shardManager, fp: c.Fingerprint()})
+		}
+		return
+	}
+	if c.Meta.IsReadOnly {
+		return
+	}
 
-	// Delete all the subscriptions of the client from the fingerprint maps
-	// Note: this is an O(n) operation and hence if there are large number of clients, this might be expensive.
-	// We can do a lazy deletion of the fingerprint map if this becomes a problem.
-	for fp := range w.fpClientMap {
-		delete(w.fpClientMap[fp], t.ClientID)
-		if len(w.fpClientMap[fp]) == 0 {
-			delete(w.fpClientMap, fp)
+	seen := map[string]bool{}
+	for _, key := range c.Keys() {
+		if seen[key] {
+			continue
 		}
+		seen[key] = true
+		w.notifier.submit(keyChange{c: c, key: key, t: t, shardManager: shardManager})
 	}
 }
 
-func (w *WatchManager) NotifyWatchers(c *cmd.Cmd, shardManager *shardmanager.ShardManager, t *IOThread) {
-	// Use RLock instead as we are not really modifying any shared maps here.
+// notifyKey notifies the watchers of the key changed by the command, along
+// with the clients watching a pattern matching it, or sends the state of the
+// fingerprint of the key change to its client. It is called by the notifier
+// worker of the key.
+func (w *WatchManager) notifyKey(kc keyChange) {
+	if kc.fp != 0 {
+		w.sendState(kc)
+		return
+	}
+
+	w.mu.RLock()
+	fps := make([]uint64, 0, len(w.keyFPMap[kc.key]))
+	for fp := range w.keyFPMap[kc.key] {
+		fps = append(fps, fp)
+	}
+	w.mu.RUnlock()
+
+	for _, fp := range fps {
+		w.notifyKeyWatchers(kc, fp)
+	}
+
+	// The pattern watches are notified of the keys modified by the command.
 	w.mu.RLock()
 	defer w.mu.RUnlock()
+	w.notifyPatternWatchers(kc.c, kc.key)
+}
+
+// sendState queues the current state of the fingerprint to the io-thread of
+// the key change, as the initial response of its .WATCH command or when its
+// client resumes its subscriptions. The state carries the current sequence
+// number of the fingerprint. The client is notified of the changes from then
+// on, including the ones made while the state is read.
+func (w *WatchManager) sendState(kc keyChange) {
+	w.mu.Lock()
+	_c, s, d := w.fpCmdMap[kc.fp], w.fpSeqMap[kc.fp], w.fpDiffMap[kc.fp]
+	diff := w.fpDiffClients[kc.fp][kc.t.ClientID]
+	w.removePending(kc.fp, kc.t.ClientID)
+	w.mu.Unlock()
+	if _c == nil || s == nil {
+		return
+	}
+
+	// The state is read and queued ahead of or after a whole notification
+	// of the fingerprint, so that its sequence number matches its content.
+	s.Lock()
+	defer s.Unlock()
+
+	// A client in the DIFF mode starts from the last result of the
+	// fingerprint, against which the next diffs are computed.
+	if diff && d != nil {
+		if rs := d.state(); rs != nil {
+			w.enqueue(kc.t, rs)
+			return
+		}
+	}
+
+	r, err := notificationCmd(_c).Execute(kc.shardManager)
+	if err != nil {
+		slog.Error("failed to execute command as part of watch notification",
+			slog.Any("cmd", _c.String()),
+			slog.Any("error", err))
+		return
+	}
+
+	rs := withWatchSeq(r.Rs, s.n)
+	if diff && d != nil {
+		d.init(rs)
+	}
+	w.enqueue(kc.t, rs)
+}
 
-	key := c.Key()
-	for fp := range w.keyFPMap[key] {
+// notifyPatternWatchers queues the key along with the command that modified
+// it to the clients watching a pattern matching the key.
+func (w *WatchManager) notifyPatternWatchers(c *cmd.Cmd, key string) {
+	w.patternTrie.match(key, func(fp uint64) {
 		_c := w.fpCmdMap[fp]
 		if _c == nil {
-			// TODO: Not having a command for a fingerprint is a bug.
-			continue
+			return
 		}
-
-		r, err := _c.Execute(shardManager)
-		if err != nil {
-			slog.Error("failed to execute command as part of watch notification",
-				slog.Any("cmd", _c.String()),
-				slog.Any("error", err))
-			continue
+		// The key is matched the way KEYS matches the keys of the
+		// initial result of the watch.
+		if ok, err := path.Match(_c.C.Args[0], key); err != nil || !ok {
+			return
 		}
 
+		s := w.fpSeqMap[fp]
+		if s == nil {
+			return
+		}
+		s.Lock()
+		defer s.Unlock()
+		s.n++
+		rs := withWatchSeq(&wire.Result{
+			Status:        wire.Status_OK,
+			Fingerprint64: fp,
+			Response:      &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: []string{key, c.C.Cmd}}},
+		}, s.n)
 		for clientID := range w.fpClientMap[fp] {
 			thread := w.clientWatchThreadMap[clientID]
-			if thread == nil {
-				// if there is no thread against the client, delete the client from the map
-				delete(w.clientWatchThreadMap, clientID)
+			if thread == nil || thread.Mode != "watch" {
 				continue
 			}
+			w.enqueue(thread, rs)
+		}
+	})
+}
 
-			// If this is first time a client is connecting it'd be sending a .WATCH command
-			// in that case we don't need to notify all other clients subscribed to the key
-			if strings.HasSuffix(c.C.Cmd, ".WATCH") && t.ClientID != clientID {
-				continue
-			}
+// notificationCmd returns a copy of the command of a fingerprint to execute for
+// a notification. The notifier workers of the different keys of a fingerprint
+// execute its command concurrently, and executing a command modifies it.
+func notificationCmd(c *cmd.Cmd) *cmd.Cmd {
+	return &cmd.Cmd{
+		C:        c.C,
+		ClientID: c.ClientID,
+		Mode:     c.Mode,
+		Meta:     c.Meta,
+	}
+}
 
-			err := thread.serverWire.Send(context.Background(), r.Rs)
-			if err != nil {
-				slog.Error("failed to write response to thread",
-					slog.Any("client_id", thread.ClientID),
-					slog.String("mode", thread.Mode),
-					slog.Any("error", err))
-			}
+// watchSeq is the sequence number of the notifications of a fingerprint. Its
+// lock is held while a notification of the fingerprint is produced and queued:
+// the keys of a fingerprint are notified by different workers, and without it
+// an older result could be given a newer sequence number.
+type watchSeq struct {
+	sync.Mutex
+	n uint64
+}
+
+// notifyKeyWatchers executes the command of the fingerprint and queues its
+// result to the clients subscribed to it, or the elements it changed to the
+// clients in the DIFF mode. The command is executed without holding the lock,
+// so that subscribing is not held back by the execution.
+func (w *WatchManager) notifyKeyWatchers(kc keyChange, fp uint64) {
+	w.mu.RLock()
+	_c, s, d := w.fpCmdMap[fp], w.fpSeqMap[fp], w.fpDiffMap[fp]
+	w.mu.RUnlock()
+	if _c == nil || s == nil {
+		// TODO: Not having a command for a fingerprint is a bug.
+		return
+	}
+
+	// The result is executed, numbered and queued as a whole, so that the
+	// sequence numbers of the notifications follow the order of their states.
+	s.Lock()
+	defer s.Unlock()
+
+	r, err := notificationCmd(_c).Execute(kc.shardManager)
+	if err != nil {
+		slog.Error("failed to execute command as part of watch notification",
+			slog.Any("cmd", _c.String()),
+			slog.Any("error", err))
+		return
+	}
+
+	s.n++
+	rs := withWatchSeq(r.Rs, s.n)
+	var diffRs *wire.Result
+	if d != nil {
+		diffRs = d.next(rs)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for clientID := range w.fpClientMap[fp] {
+		thread := w.clientWatchThreadMap[clientID]
+		if thread == nil {
+			// The client is disconnected, and may resume
+			// its subscriptions within the grace period.
+			continue
+		}
+		if w.fpPendingClients[fp][clientID] {
+			continue
 		}
 
-		slog.Debug("notifying watchers for key", slog.String("key", key), slog.Int("watchers", len(w.fpClientMap[fp])))
+		if w.fpDiffClients[fp][clientID] && diffRs != nil {
+			w.enqueue(thread, diffRs)
+			continue
+		}
+		if th := w.fpThrottleMap[fp][clientID]; th != nil {
+			th.notify(thread, rs)
+			continue
+		}
+		w.enqueue(thread, rs)
 	}
+
+	slog.Debug("notifying watchers for key", slog.String("key", kc.key), slog.Int("watchers", len(w.fpClientMap[fp])))
 }
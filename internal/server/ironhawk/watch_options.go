@@ -0,0 +1,109 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/errors"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// diffWatchCmds are the .WATCH commands supporting the DIFF option.
+var diffWatchCmds = map[string]bool{
+	"ZRANGE.WATCH":  true,
+	"HGETALL.WATCH": true,
+}
+
+// watchOptions are the options of a watch subscription, given after
+// the arguments of the .WATCH command.
+type watchOptions struct {
+	// throttle is the minimum interval between two notifications.
+	throttle time.Duration
+	// diff sends the elements changed since the previous notification
+	// rather than the whole result.
+	diff bool
+}
+
+// watchOptionsStart returns the index of the first argument of the .WATCH
+// command that may be an option, that is the one after its last key. The
+// arguments of a command whose keys run to the end of its arguments are all
+// keys, hence such a command takes no option.
+func watchOptionsStart(c *wire.Command) int {
+	meta, ok := cmd.CommandRegistry.CommandMetas[c.Cmd]
+	if !ok {
+		return len(c.Args)
+	}
+	ks := meta.KeySpec
+	switch {
+	case ks.Step <= 0:
+		return 1
+	case ks.Last < 0:
+		return len(c.Args)
+	default:
+		return ks.Last + 1
+	}
+}
+
+// parseWatchOptions removes the trailing THROTTLE and DIFF options from the
+// arguments of the .WATCH command and returns them. Only the arguments after
+// the keys of the command are options, so that a key named like an option is
+// watched. The THROTTLE interval is given as a duration, like 100ms, or as a
+// number of milliseconds, and defaults to the interval of the server.
+func parseWatchOptions(c *wire.Command) (watchOptions, error) {
+	var opts watchOptions
+	throttled := false
+	start := watchOptionsStart(c)
+	for {
+		n := len(c.Args)
+		if n-1 >= start && strings.EqualFold(c.Args[n-1], "DIFF") && !opts.diff {
+			if !diffWatchCmds[c.Cmd] {
+				return opts, errors.ErrGeneral("DIFF is not supported by " + c.Cmd)
+			}
+			opts.diff = true
+			c.Args = c.Args[:n-1]
+			continue
+		}
+		if n-2 >= start && strings.EqualFold(c.Args[n-2], "THROTTLE") && !throttled {
+			interval, err := parseWatchThrottle(c.Args[n-1])
+			if err != nil {
+				return opts, err
+			}
+			opts.throttle, throttled = interval, true
+			c.Args = c.Args[:n-2]
+			continue
+		}
+		break
+	}
+
+	// The diffs are computed against the previous notification, hence
+	// the notifications of a subscription in the DIFF mode are not coalesced.
+	if opts.diff {
+		if throttled && opts.throttle > 0 {
+			return opts, errors.ErrGeneral("DIFF cannot be combined with THROTTLE")
+		}
+		return opts, nil
+	}
+	if !throttled {
+		opts.throttle = time.Duration(config.Config.WatchThrottleMs) * time.Millisecond
+	}
+	return opts, nil
+}
+
+func parseWatchThrottle(v string) (time.Duration, error) {
+	var interval time.Duration
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		interval = time.Duration(ms) * time.Millisecond
+	} else if interval, err = time.ParseDuration(v); err != nil {
+		return 0, errors.ErrGeneral("invalid THROTTLE interval '" + v + "'")
+	}
+	if interval < 0 {
+		return 0, errors.ErrGeneral("THROTTLE interval is negative")
+	}
+	return interval, nil
+}
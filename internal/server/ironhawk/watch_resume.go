@@ -0,0 +1,134 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/shardmanager"
+	"github.com/dicedb/dicedb-go/wire"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// detachedClient is a client disconnected while having watch subscriptions,
+// which are removed once the grace period elapses unless the client resumes
+// them by reconnecting with the same client id.
+type detachedClient struct {
+	timer *time.Timer
+}
+
+// WatchSeqField is the number of the protobuf field carrying the sequence
+// number of the fingerprint in the results of watch subscriptions. It is not
+// part of the wire.Result message, so the clients that do not know it skip it,
+// and is far from its fields so that it does not clash with the ones added later.
+const WatchSeqField protowire.Number = 1000
+
+// withWatchSeq returns a copy of the result of a watch subscription carrying
+// the sequence number of its fingerprint in the WatchSeqField field.
+// A client missed updates when the sequence number of a notification is
+// greater than the one of the previous notification plus one.
+func withWatchSeq(rs *wire.Result, seq uint64) *wire.Result {
+	out := &wire.Result{
+		Status:        rs.Status,
+		Message:       rs.Message,
+		Fingerprint64: rs.Fingerprint64,
+		Response:      rs.Response,
+	}
+	field := protowire.AppendTag(nil, WatchSeqField, protowire.VarintType)
+	out.ProtoReflect().SetUnknown(protowire.AppendVarint(field, seq))
+	return out
+}
+
+// WatchSeq returns the sequence number carried by the result of a watch
+// subscription, and false if it does not carry one.
+func WatchSeq(rs *wire.Result) (uint64, bool) {
+	b := rs.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return 0, false
+		}
+		b = b[n:]
+		if num == WatchSeqField && typ == protowire.VarintType {
+			seq, n := protowire.ConsumeVarint(b)
+			return seq, n >= 0
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return 0, false
+		}
+		b = b[n:]
+	}
+	return 0, false
+}
+
+// detachClient keeps the subscriptions of the disconnected client for the
+// grace period. The pending updates of its throttled subscriptions are dropped
+// as the latest state is sent when the client resumes.
+func (w *WatchManager) detachClient(clientID string, grace time.Duration) {
+	if len(w.clientFPMap[clientID]) == 0 {
+		return
+	}
+	for fp := range w.clientFPMap[clientID] {
+		if th := w.fpThrottleMap[fp][clientID]; th != nil {
+			th.stop()
+		}
+	}
+
+	w.attachClient(clientID)
+	d := &detachedClient{}
+	d.timer = time.AfterFunc(grace, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		// The client may have resumed while the timer fired.
+		if w.detachedClients[clientID] != d {
+			return
+		}
+		delete(w.detachedClients, clientID)
+		slog.Debug("watch subscriptions of the client expired", slog.String("client_id", clientID))
+		w.removeClientSubscriptions(clientID)
+	})
+	w.detachedClients[clientID] = d
+}
+
+// attachClient cancels the expiry of the subscriptions of the client, if it
+// was disconnected.
+func (w *WatchManager) attachClient(clientID string) {
+	if d := w.detachedClients[clientID]; d != nil {
+		d.timer.Stop()
+		delete(w.detachedClients, clientID)
+	}
+}
+
+// ResumeSubscriptions resumes the subscriptions of the client of the watch
+// thread, sending it the latest state of each subscription along with the
+// current sequence number of its fingerprint.
+func (w *WatchManager) ResumeSubscriptions(t *IOThread, shardManager *shardmanager.ShardManager) {
+	if t.Mode != "watch" {
+		return
+	}
+
+	w.mu.Lock()
+	w.attachClient(t.ClientID)
+	changes := make([]keyChange, 0, len(w.clientFPMap[t.ClientID]))
+	for fp := range w.clientFPMap[t.ClientID] {
+		if c := w.fpCmdMap[fp]; c != nil {
+			w.setPending(fp, t.ClientID)
+			changes = append(changes, keyChange{c: c, key: c.Key(), t: t, shardManager: shardManager, fp: fp})
+		}
+	}
+	w.mu.Unlock()
+
+	// The states are sent by the notifier workers of the keys,
+	// so that they are ordered with the notifications of the keys.
+	for _, kc := range changes {
+		w.notifier.submit(kc)
+	}
+}
+
+func watchResumeGrace() time.Duration {
+	return time.Duration(config.Config.WatchResumeGraceMs) * time.Millisecond
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// watchStats counts the notifications of the watch subscriptions.
+type watchStats struct {
+	// sent is the number of notifications sent to the clients.
+	sent atomic.Int64
+	// coalesced is the number of updates of throttled subscriptions
+	// that were replaced by a later update before being sent.
+	coalesced atomic.Int64
+	// dropped is the number of notifications dropped from the queue
+	// of a client not keeping up with them.
+	dropped atomic.Int64
+	// disconnected is the number of clients disconnected for not
+	// keeping up with their notifications.
+	disconnected atomic.Int64
+	// pubsubDropped is the number of published messages that could not
+	// be sent to a subscriber, for it having no watch connection or its
+	// watch connection failing.
+	pubsubDropped atomic.Int64
+}
+
+// Approximate sizes, in bytes, of a map entry and of a string header,
+// used to estimate the memory held by the watch indexes.
+const (
+	mapEntrySize  = 48
+	stringHdrSize = 16
+)
+
+// Stats returns the statistics of the watch subscriptions as
+// a flat list of name and value pairs.
+func (w *WatchManager) Stats() *cmd.CmdRes {
+	w.mu.RLock()
+	subscriptions, throttled := 0, 0
+	for _, clients := range w.fpClientMap {
+		subscriptions += len(clients)
+	}
+	for _, clients := range w.fpThrottleMap {
+		throttled += len(clients)
+	}
+	fingerprints, keys, clients := len(w.fpClientMap), len(w.keyFPMap), len(w.clientFPMap)
+	memory := w.indexMemory()
+	w.mu.RUnlock()
+
+	stats := []string{
+		"subscriptions", strconv.Itoa(subscriptions),
+		"throttled_subscriptions", strconv.Itoa(throttled),
+		"fingerprints", strconv.Itoa(fingerprints),
+		"watched_keys", strconv.Itoa(keys),
+		"watching_clients", strconv.Itoa(clients),
+		"index_memory_bytes", strconv.Itoa(memory),
+		"notifications_sent", strconv.FormatInt(w.stats.sent.Load(), 10),
+		"notifications_coalesced", strconv.FormatInt(w.stats.coalesced.Load(), 10),
+		"notifications_dropped", strconv.FormatInt(w.stats.dropped.Load(), 10),
+		"slow_watchers_disconnected", strconv.FormatInt(w.stats.disconnected.Load(), 10),
+		"pubsub_messages_dropped", strconv.FormatInt(w.stats.pubsubDropped.Load(), 10),
+	}
+	return newWatchListRes(stats)
+}
+
+// indexMemory returns an estimate of the memory, in bytes, held by the
+// indexes of the watch subscriptions. The caller must hold w.mu.
+func (w *WatchManager) indexMemory() int {
+	n := 0
+	for key, fps := range w.keyFPMap {
+		n += mapEntrySize + stringHdrSize + len(key) + len(fps)*mapEntrySize
+	}
+	for _, clients := range w.fpClientMap {
+		n += mapEntrySize + len(clients)*(mapEntrySize+stringHdrSize)
+	}
+	for clientID, fps := range w.clientFPMap {
+		n += mapEntrySize + stringHdrSize + len(clientID) + len(fps)*mapEntrySize
+	}
+	for _, keys := range w.fpKeysMap {
+		n += mapEntrySize + len(keys)*stringHdrSize
+	}
+	for _, c := range w.fpCmdMap {
+		n += 2*mapEntrySize + len(c.C.Cmd)
+		for _, arg := range c.C.Args {
+			n += stringHdrSize + len(arg)
+		}
+	}
+	n += len(w.fpSeqMap) * 2 * mapEntrySize
+	for clientID := range w.detachedClients {
+		n += 2*mapEntrySize + stringHdrSize + len(clientID)
+	}
+	n += w.patternTrie.size()
+	for _, clients := range w.fpDiffClients {
+		n += mapEntrySize + len(clients)*(mapEntrySize+stringHdrSize)
+	}
+	// The clients in the DIFF mode keep the last result of their
+	// fingerprint, which is as large as the result itself.
+	for _, d := range w.fpDiffMap {
+		n += 2*mapEntrySize + d.size()
+	}
+	return n
+}
+
+// WatchConnectionCount returns the number of clients having a watch connection.
+func (w *WatchManager) WatchConnectionCount() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	n := 0
+	for _, t := range w.clientWatchThreadMap {
+		if t.Mode == "watch" {
+			n++
+		}
+	}
+	return n
+}
+
+// List returns the live subscriptions as a flat list of the fingerprint,
+// the command and the number of subscribed clients of each fingerprint,
+// sorted by command.
+func (w *WatchManager) List() *cmd.CmdRes {
+	type subscription struct {
+		fp      uint64
+		c       string
+		clients int
+	}
+
+	w.mu.RLock()
+	subscriptions := make([]subscription, 0, len(w.fpClientMap))
+	for fp, clients := range w.fpClientMap {
+		if c := w.fpCmdMap[fp]; c != nil {
+			subscriptions = append(subscriptions, subscription{fp: fp, c: c.String(), clients: len(clients)})
+		}
+	}
+	w.mu.RUnlock()
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].c != subscriptions[j].c {
+			return subscriptions[i].c < subscriptions[j].c
+		}
+		return subscriptions[i].fp < subscriptions[j].fp
+	})
+	list := make([]string, 0, 3*len(subscriptions))
+	for _, s := range subscriptions {
+		list = append(list, strconv.FormatUint(s.fp, 10), s.c, strconv.Itoa(s.clients))
+	}
+	return newWatchListRes(list)
+}
+
+func newWatchListRes(values []string) *cmd.CmdRes {
+	return &cmd.CmdRes{
+		Rs: &wire.Result{
+			Status:   wire.Status_OK,
+			Message:  "OK",
+			Response: &wire.Result_KEYSRes{KEYSRes: &wire.KEYSRes{Keys: values}},
+		},
+	}
+}
@@ -3,9 +3,18 @@
 
 package shard
 
-import "github.com/dicedb/dice/internal/shardthread"
+import (
+	"sync"
+
+	"github.com/dicedb/dice/internal/shardthread"
+)
 
 type Shard struct {
 	ID     int
 	Thread *shardthread.ShardThread
+
+	// Commands operating on a single key hold the read lock of the shard
+	// owning the key, while commands spanning multiple keys hold the write
+	// lock of every shard they touch so that they are applied atomically.
+	sync.RWMutex
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 
@@ -82,3 +83,46 @@ func (manager *ShardManager) ShardCount() int8 {
 func (manager *ShardManager) Shards() []*shard.Shard {
 	return manager.shards
 }
+
+// LockKeys locks the shards owning the keys and returns the function
+// that unlocks them. A shard owning more than one of the keys is locked once.
+func (manager *ShardManager) LockKeys(exclusive bool, keys ...string) (unlock func()) {
+	shards := make([]*shard.Shard, 0, len(keys))
+	for _, key := range keys {
+		sh := manager.GetShardForKey(key)
+		if !slices.Contains(shards, sh) {
+			shards = append(shards, sh)
+		}
+	}
+	return lockShards(exclusive, shards)
+}
+
+// LockAll exclusively locks all the shards and returns the function that unlocks them.
+func (manager *ShardManager) LockAll() (unlock func()) {
+	return lockShards(true, slices.Clone(manager.shards))
+}
+
+// lockShards locks the shards in the order of their IDs so that concurrent
+// callers locking overlapping sets of shards do not deadlock.
+func lockShards(exclusive bool, shards []*shard.Shard) func() {
+	slices.SortFunc(shards, func(a, b *shard.Shard) int {
+		return a.ID - b.ID
+	})
+
+	for _, sh := range shards {
+		if exclusive {
+			sh.Lock()
+		} else {
+			sh.RLock()
+		}
+	}
+	return func() {
+		for _, sh := range shards {
+			if exclusive {
+				sh.Unlock()
+			} else {
+				sh.RUnlock()
+			}
+		}
+	}
+}
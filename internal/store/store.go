@@ -6,6 +6,7 @@ package store
 import (
 	"path"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dicedb/dice/internal/common"
@@ -55,6 +56,15 @@ type Store struct {
 	cmdWatchChan     chan CmdWatchEvent
 	evictionStrategy EvictionStrategy
 	ShardID          int
+
+	// version is the last version assigned to a mutated object. It is
+	// shared by all the keys of the store so that the version of a key
+	// keeps increasing even if the key is deleted and created again. It is
+	// atomic as the commands on different keys of the store run concurrently.
+	version atomic.Uint64
+
+	// waiters holds the clients blocked on the keys of the store.
+	waiters waitQueues
 }
 
 func NewStore(cmdWatchChan chan CmdWatchEvent, evictionStrategy EvictionStrategy, shardID int) *Store {
@@ -128,6 +138,7 @@ func (store *Store) putHelper(k string, obj *object.Obj, opts ...PutOption) {
 	}
 
 	obj.LastAccessedAt = time.Now().UnixMilli()
+	store.BumpVersion(obj)
 	currentObject, ok := store.store.Get(k)
 	if ok {
 		v, ok1 := store.expires.Get(currentObject)
@@ -150,12 +161,20 @@ func (store *Store) putHelper(k string, obj *object.Obj, opts ...PutOption) {
 
 	store.store.Put(k, obj)
 	store.evictionStrategy.OnAccess(k, obj, AccessSet)
+	store.SignalKeyReady(k)
 
 	if store.cmdWatchChan != nil {
 		store.notifyWatchManager(options.PutCmd, k)
 	}
 }
 
+// BumpVersion assigns a new version to the object. Put does this for
+// the objects it stores, commands that modify the value of an object
+// in place must call it themselves.
+func (store *Store) BumpVersion(obj *object.Obj) {
+	obj.Version = store.version.Add(1)
+}
+
 // getHelper is a helper function to get the object from the store. It also updates the last accessed time if touch is true.
 func (store *Store) getHelper(k string, touch bool) *object.Obj {
 	var ok bool
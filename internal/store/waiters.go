@@ -0,0 +1,112 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package store
+
+import (
+	"slices"
+	"sync"
+)
+
+// Waiter is a client blocked until one of the keys it waits on is pushed into.
+type Waiter struct {
+	ready chan struct{}
+}
+
+func NewWaiter() *Waiter {
+	return &Waiter{ready: make(chan struct{}, 1)}
+}
+
+// Ready returns the channel that receives a value when one of the keys
+// the waiter waits on may be served. The key is not reserved for the waiter,
+// it has to check the key again and wait again if someone else got to it first.
+func (w *Waiter) Ready() <-chan struct{} {
+	return w.ready
+}
+
+func (w *Waiter) wake() {
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+}
+
+// waitQueues holds the waiters of every key in the order they started waiting.
+// The waiter at the head of the queue of a key is the only one woken when the
+// key is pushed into, which serves the blocked clients in FIFO order.
+type waitQueues struct {
+	mu     sync.Mutex
+	queues map[string][]*Waiter
+}
+
+// AddWaiter appends the waiter to the queue of the key. A waiter already
+// in the queue keeps its position.
+func (store *Store) AddWaiter(k string, w *Waiter) {
+	q := &store.waiters
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.queues == nil {
+		q.queues = map[string][]*Waiter{}
+	}
+	if !slices.Contains(q.queues[k], w) {
+		q.queues[k] = append(q.queues[k], w)
+	}
+}
+
+// RemoveWaiter removes the waiter from the queue of the key. If the waiter
+// was at the head of the queue, the next waiter is woken so that it does not
+// miss the push that may have been meant for the removed one.
+func (store *Store) RemoveWaiter(k string, w *Waiter) {
+	q := &store.waiters
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := q.queues[k]
+	i := slices.Index(waiters, w)
+	if i < 0 {
+		return
+	}
+	waiters = slices.Delete(waiters, i, i+1)
+	if len(waiters) == 0 {
+		delete(q.queues, k)
+		return
+	}
+	q.queues[k] = waiters
+	if i == 0 {
+		waiters[0].wake()
+	}
+}
+
+// IsNextWaiter reports whether the waiter is the next one to be served for
+// the key, that is if it is at the head of the queue of the key. A nil waiter
+// is the next one only if no one waits on the key.
+func (store *Store) IsNextWaiter(k string, w *Waiter) bool {
+	q := &store.waiters
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := q.queues[k]
+	return len(waiters) == 0 || waiters[0] == w
+}
+
+// SignalKeyReady wakes the waiter at the head of the queue of the key. It is
+// called when a value is stored against the key and must be called by the
+// commands pushing into an existing value in place.
+func (store *Store) SignalKeyReady(k string) {
+	q := &store.waiters
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if waiters := q.queues[k]; len(waiters) > 0 {
+		waiters[0].wake()
+	}
+}
+
+// BroadcastKeyReady wakes all the waiters of the key. It is called when the
+// value stored against the key can be served to every waiter, as the entries
+// added to a stream which are read without being consumed.
+func (store *Store) BroadcastKeyReady(k string) {
+	q := &store.waiters
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, w := range q.queues[k] {
+		w.wake()
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package types
+
+import (
+	"container/list"
+)
+
+// List is a list of strings that can be pushed to and popped from both ends.
+type List struct {
+	l *list.List
+}
+
+func NewList() *List {
+	return &List{l: list.New()}
+}
+
+// LPush inserts the values at the head of the list one after the other,
+// so the last value ends up as the first element of the list.
+func (l *List) LPush(values ...string) {
+	for _, v := range values {
+		l.l.PushFront(v)
+	}
+}
+
+// RPush inserts the values at the tail of the list.
+func (l *List) RPush(values ...string) {
+	for _, v := range values {
+		l.l.PushBack(v)
+	}
+}
+
+// LPop removes and returns the first element of the list.
+// The bool return value is false if the list is empty.
+func (l *List) LPop() (string, bool) {
+	e := l.l.Front()
+	if e == nil {
+		return "", false
+	}
+	return l.l.Remove(e).(string), true
+}
+
+// RPop removes and returns the last element of the list.
+// The bool return value is false if the list is empty.
+func (l *List) RPop() (string, bool) {
+	e := l.l.Back()
+	if e == nil {
+		return "", false
+	}
+	return l.l.Remove(e).(string), true
+}
+
+func (l *List) Len() int {
+	return l.l.Len()
+}
+
+// Range returns the elements between the 0-based start and stop indexes,
+// both inclusive. Negative indexes are relative to the end of the list,
+// -1 being the last element.
+func (l *List) Range(start, stop int) []string {
+	n := l.l.Len()
+	if start < 0 {
+		start = max(start+n, 0)
+	}
+	if stop < 0 {
+		stop += n
+	}
+	stop = min(stop, n-1)
+	if start > stop {
+		return []string{}
+	}
+
+	values := make([]string, 0, stop-start+1)
+	i := 0
+	for e := l.l.Front(); e != nil && i <= stop; e = e.Next() {
+		if i >= start {
+			values = append(values, e.Value.(string))
+		}
+		i++
+	}
+	return values
+}
+
+// Values returns all the elements of the list from the head to the tail.
+func (l *List) Values() []string {
+	return l.Range(0, -1)
+}
@@ -20,4 +20,7 @@ const (
 	KEEPTTL Param = "KEEPTTL"
 
 	PERSIST Param = "PERSIST"
+
+	IFVERSION Param = "IFVERSION"
+	IFEQ      Param = "IFEQ"
 )
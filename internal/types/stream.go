@@ -0,0 +1,448 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package types
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/btree"
+)
+
+var (
+	ErrInvalidStreamID  = errors.New("invalid stream ID specified as stream command argument")
+	ErrStreamIDZero     = errors.New("the ID specified in XADD must be greater than 0-0")
+	ErrStreamIDTooSmall = errors.New(
+		"the ID specified in XADD is equal or smaller than the target stream top item")
+)
+
+// StreamID identifies an entry of a stream. IDs are made of the time the entry
+// was added at, in milliseconds, and a sequence number telling apart the
+// entries added within the same millisecond.
+type StreamID struct {
+	Ms  uint64
+	Seq uint64
+}
+
+// MaxStreamID is the greatest possible ID.
+var MaxStreamID = StreamID{Ms: math.MaxUint64, Seq: math.MaxUint64}
+
+// ParseStreamID parses an ID given as ms-seq. The sequence number may be
+// omitted, in which case it is set to missingSeq.
+func ParseStreamID(s string, missingSeq uint64) (StreamID, error) {
+	msPart, seqPart, hasSeq := strings.Cut(s, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	if !hasSeq {
+		return StreamID{Ms: ms, Seq: missingSeq}, nil
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	return StreamID{Ms: ms, Seq: seq}, nil
+}
+
+func (id StreamID) String() string {
+	return strconv.FormatUint(id.Ms, 10) + "-" + strconv.FormatUint(id.Seq, 10)
+}
+
+// Compare returns -1, 0 or 1 if the ID is respectively lower than,
+// equal to or greater than o.
+func (id StreamID) Compare(o StreamID) int {
+	switch {
+	case id.Ms < o.Ms || (id.Ms == o.Ms && id.Seq < o.Seq):
+		return -1
+	case id == o:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Next returns the ID following id. The bool return value is
+// false if id is the greatest possible ID.
+func (id StreamID) Next() (StreamID, bool) {
+	switch {
+	case id.Seq < math.MaxUint64:
+		return StreamID{Ms: id.Ms, Seq: id.Seq + 1}, true
+	case id.Ms < math.MaxUint64:
+		return StreamID{Ms: id.Ms + 1}, true
+	default:
+		return id, false
+	}
+}
+
+// Prev returns the ID preceding id. The bool return value is
+// false if id is 0-0.
+func (id StreamID) Prev() (StreamID, bool) {
+	switch {
+	case id.Seq > 0:
+		return StreamID{Ms: id.Ms, Seq: id.Seq - 1}, true
+	case id.Ms > 0:
+		return StreamID{Ms: id.Ms - 1, Seq: math.MaxUint64}, true
+	default:
+		return id, false
+	}
+}
+
+// StreamEntry is an entry of a stream, holding its fields and values
+// one after the other.
+type StreamEntry struct {
+	ID     StreamID
+	Fields []string
+}
+
+// PendingEntry is an entry delivered to a consumer of a group
+// and not acknowledged yet.
+type PendingEntry struct {
+	ID       StreamID
+	Consumer string
+	// DeliveryTime is the time the entry was last delivered at,
+	// in milliseconds since epoch.
+	DeliveryTime int64
+	// DeliveryCount is the number of times the entry was delivered.
+	DeliveryCount int64
+}
+
+// StreamConsumer is a consumer of a group.
+type StreamConsumer struct {
+	Name string
+	// SeenTime is the time the consumer last read or claimed
+	// entries at, in milliseconds since epoch.
+	SeenTime int64
+}
+
+// StreamGroup is a consumer group of a stream. The group delivers every entry
+// of the stream to one of its consumers, and keeps the delivered entries in its
+// pending entries list until they are acknowledged.
+type StreamGroup struct {
+	Name string
+	// LastID is the ID of the last entry delivered to the consumers.
+	LastID StreamID
+
+	consumers map[string]*StreamConsumer
+	pending   *btree.BTreeG[*PendingEntry]
+}
+
+// Stream is an append-only log of entries ordered by their ID.
+type Stream struct {
+	// LastID is the ID of the last entry added to the stream,
+	// even if the entry was since trimmed.
+	LastID StreamID
+
+	entries *btree.BTreeG[*StreamEntry]
+	groups  map[string]*StreamGroup
+}
+
+func NewStream() *Stream {
+	return &Stream{
+		entries: btree.NewG(32, func(a, b *StreamEntry) bool {
+			return a.ID.Compare(b.ID) < 0
+		}),
+		groups: map[string]*StreamGroup{},
+	}
+}
+
+func (s *Stream) Len() int {
+	return s.entries.Len()
+}
+
+// NextID returns the ID of an entry added at the time now, given in
+// milliseconds since epoch. The bool return value is false if no ID greater
+// than the last one can be generated.
+func (s *Stream) NextID(now int64) (StreamID, bool) {
+	if now > 0 && uint64(now) > s.LastID.Ms {
+		return StreamID{Ms: uint64(now)}, true
+	}
+	return s.LastID.Next()
+}
+
+// Add appends the entry to the stream. The ID of the entry must be greater
+// than the ID of the last entry ever added to the stream.
+func (s *Stream) Add(id StreamID, fields []string) error {
+	if id == (StreamID{}) {
+		return ErrStreamIDZero
+	}
+	if id.Compare(s.LastID) <= 0 {
+		return ErrStreamIDTooSmall
+	}
+	s.entries.ReplaceOrInsert(&StreamEntry{ID: id, Fields: fields})
+	s.LastID = id
+	return nil
+}
+
+// Get returns the entry with the ID, or nil if there is none.
+func (s *Stream) Get(id StreamID) *StreamEntry {
+	e, _ := s.entries.Get(&StreamEntry{ID: id})
+	return e
+}
+
+// Range returns the entries whose ID is between start and end, both
+// inclusive, in ascending order or in descending order if reverse is true.
+// At most count entries are returned, unless count is 0.
+func (s *Stream) Range(start, end StreamID, count int, reverse bool) []*StreamEntry {
+	entries := []*StreamEntry{}
+	if start.Compare(end) > 0 {
+		return entries
+	}
+	iter := func(e *StreamEntry) bool {
+		entries = append(entries, e)
+		return count <= 0 || len(entries) < count
+	}
+
+	if reverse {
+		s.entries.DescendLessOrEqual(&StreamEntry{ID: end}, func(e *StreamEntry) bool {
+			return e.ID.Compare(start) >= 0 && iter(e)
+		})
+	} else {
+		s.entries.AscendGreaterOrEqual(&StreamEntry{ID: start}, func(e *StreamEntry) bool {
+			return e.ID.Compare(end) <= 0 && iter(e)
+		})
+	}
+	return entries
+}
+
+// After returns at most count entries, unless count is 0, whose ID is
+// greater than id.
+func (s *Stream) After(id StreamID, count int) []*StreamEntry {
+	start, ok := id.Next()
+	if !ok {
+		return []*StreamEntry{}
+	}
+	return s.Range(start, MaxStreamID, count, false)
+}
+
+// Entries returns all the entries of the stream in ascending order.
+func (s *Stream) Entries() []*StreamEntry {
+	return s.Range(StreamID{}, MaxStreamID, 0, false)
+}
+
+// TrimMaxLen removes the oldest entries of the stream until it holds at most
+// maxLen entries, removing at most limit entries unless limit is 0. It
+// returns the number of removed entries.
+func (s *Stream) TrimMaxLen(maxLen, limit int) int {
+	n := 0
+	for s.entries.Len() > maxLen && (limit <= 0 || n < limit) {
+		s.entries.DeleteMin()
+		n++
+	}
+	return n
+}
+
+// TrimMinID removes the entries whose ID is lower than minID, removing
+// at most limit entries unless limit is 0. It returns the number of
+// removed entries.
+func (s *Stream) TrimMinID(minID StreamID, limit int) int {
+	n := 0
+	for limit <= 0 || n < limit {
+		e, ok := s.entries.Min()
+		if !ok || e.ID.Compare(minID) >= 0 {
+			break
+		}
+		s.entries.DeleteMin()
+		n++
+	}
+	return n
+}
+
+// Group returns the consumer group with the name, or nil if there is none.
+func (s *Stream) Group(name string) *StreamGroup {
+	return s.groups[name]
+}
+
+// CreateGroup creates a consumer group delivering the entries added after
+// lastID. The bool return value is false if the group already exists.
+func (s *Stream) CreateGroup(name string, lastID StreamID) (*StreamGroup, bool) {
+	if g, ok := s.groups[name]; ok {
+		return g, false
+	}
+	g := &StreamGroup{
+		Name:      name,
+		LastID:    lastID,
+		consumers: map[string]*StreamConsumer{},
+		pending: btree.NewG(32, func(a, b *PendingEntry) bool {
+			return a.ID.Compare(b.ID) < 0
+		}),
+	}
+	s.groups[name] = g
+	return g, true
+}
+
+// DestroyGroup removes the consumer group. It returns false if the group
+// does not exist.
+func (s *Stream) DestroyGroup(name string) bool {
+	if _, ok := s.groups[name]; !ok {
+		return false
+	}
+	delete(s.groups, name)
+	return true
+}
+
+// Groups returns the consumer groups of the stream, sorted by name.
+func (s *Stream) Groups() []*StreamGroup {
+	groups := make([]*StreamGroup, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// Consumer returns the consumer with the name, or nil if there is none.
+func (g *StreamGroup) Consumer(name string) *StreamConsumer {
+	return g.consumers[name]
+}
+
+// CreateConsumer creates the consumer if it does not exist yet. The bool
+// return value is false if the consumer already exists.
+func (g *StreamGroup) CreateConsumer(name string, now int64) (*StreamConsumer, bool) {
+	if c, ok := g.consumers[name]; ok {
+		return c, false
+	}
+	c := &StreamConsumer{Name: name, SeenTime: now}
+	g.consumers[name] = c
+	return c, true
+}
+
+// DeleteConsumer removes the consumer along with its pending entries and
+// returns the number of pending entries it had.
+func (g *StreamGroup) DeleteConsumer(name string) int {
+	if _, ok := g.consumers[name]; !ok {
+		return 0
+	}
+	var ids []StreamID
+	g.pending.Ascend(func(pe *PendingEntry) bool {
+		if pe.Consumer == name {
+			ids = append(ids, pe.ID)
+		}
+		return true
+	})
+	for _, id := range ids {
+		g.pending.Delete(&PendingEntry{ID: id})
+	}
+	delete(g.consumers, name)
+	return len(ids)
+}
+
+// Consumers returns the consumers of the group, sorted by name.
+func (g *StreamGroup) Consumers() []*StreamConsumer {
+	consumers := make([]*StreamConsumer, 0, len(g.consumers))
+	for _, c := range g.consumers {
+		consumers = append(consumers, c)
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Name < consumers[j].Name })
+	return consumers
+}
+
+// ReadNew delivers to the consumer at most count entries, unless count is 0,
+// that were never delivered to the group. The entries are added to the
+// pending entries list, unless noAck is true.
+func (g *StreamGroup) ReadNew(s *Stream, consumer string, count int, noAck bool, now int64) []*StreamEntry {
+	c, _ := g.CreateConsumer(consumer, now)
+	c.SeenTime = now
+
+	entries := s.After(g.LastID, count)
+	for _, e := range entries {
+		g.LastID = e.ID
+		if !noAck {
+			g.pending.ReplaceOrInsert(&PendingEntry{
+				ID:            e.ID,
+				Consumer:      consumer,
+				DeliveryTime:  now,
+				DeliveryCount: 1,
+			})
+		}
+	}
+	return entries
+}
+
+// ReadPending delivers again to the consumer at most count entries, unless
+// count is 0, pending for it whose ID is greater than after. The entries
+// removed from the stream since they were delivered are returned without fields.
+func (g *StreamGroup) ReadPending(s *Stream, consumer string, after StreamID, count int, now int64) []*StreamEntry {
+	c, _ := g.CreateConsumer(consumer, now)
+	c.SeenTime = now
+
+	entries := []*StreamEntry{}
+	g.pending.AscendGreaterOrEqual(&PendingEntry{ID: after}, func(pe *PendingEntry) bool {
+		if pe.ID == after || pe.Consumer != consumer {
+			return true
+		}
+		if e := s.Get(pe.ID); e != nil {
+			pe.DeliveryTime = now
+			pe.DeliveryCount++
+			entries = append(entries, e)
+		} else {
+			entries = append(entries, &StreamEntry{ID: pe.ID})
+		}
+		return count <= 0 || len(entries) < count
+	})
+	return entries
+}
+
+// Ack removes the entry from the pending entries list. It returns false
+// if the entry is not pending.
+func (g *StreamGroup) Ack(id StreamID) bool {
+	_, ok := g.pending.Delete(&PendingEntry{ID: id})
+	return ok
+}
+
+// Pending returns the pending entry with the ID, or nil if there is none.
+func (g *StreamGroup) Pending(id StreamID) *PendingEntry {
+	pe, _ := g.pending.Get(&PendingEntry{ID: id})
+	return pe
+}
+
+// PendingRange returns at most count pending entries, unless count is 0,
+// whose ID is between start and end, both inclusive.
+func (g *StreamGroup) PendingRange(start, end StreamID, count int) []*PendingEntry {
+	entries := []*PendingEntry{}
+	g.pending.AscendGreaterOrEqual(&PendingEntry{ID: start}, func(pe *PendingEntry) bool {
+		if pe.ID.Compare(end) > 0 {
+			return false
+		}
+		entries = append(entries, pe)
+		return count <= 0 || len(entries) < count
+	})
+	return entries
+}
+
+// PendingLen returns the number of pending entries of the group.
+func (g *StreamGroup) PendingLen() int {
+	return g.pending.Len()
+}
+
+// AddPending adds the entry to the pending entries list, replacing the
+// pending entry with the same ID if any.
+func (g *StreamGroup) AddPending(pe *PendingEntry) {
+	g.pending.ReplaceOrInsert(pe)
+}
+
+// Claim transfers the ownership of the pending entry with the ID to the
+// consumer, if it has been idle for at least minIdle milliseconds. The pending
+// entry is removed if the entry was removed from the stream, in which case the
+// returned entry is nil and the bool return value is true. The delivery count
+// of the claimed entry is left to the caller.
+func (g *StreamGroup) Claim(s *Stream, id StreamID, consumer string, minIdle, now int64) (*PendingEntry, bool) {
+	pe := g.Pending(id)
+	if pe == nil || now-pe.DeliveryTime < minIdle {
+		return nil, false
+	}
+	if s.Get(id) == nil {
+		g.pending.Delete(pe)
+		return nil, true
+	}
+
+	c, _ := g.CreateConsumer(consumer, now)
+	c.SeenTime = now
+	pe.Consumer = consumer
+	pe.DeliveryTime = now
+	return pe, true
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package wal
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// codec identifies how the payload of a WAL entry is encoded on disk.
+// It is stored in the top 4 bits of the size field of the entry header
+// so that segments written before compression was introduced (where
+// these bits are always zero) are read back as uncompressed entries.
+type codec uint8
+
+const (
+	codecNone codec = iota
+	codecSnappy
+	codecZstd
+)
+
+const (
+	// codecShift is the position of the codec bits in the size field.
+	codecShift = 28
+	// maxEntrySize is the largest payload that can be addressed by the
+	// remaining 28 bits of the size field (256 MB).
+	maxEntrySize = 1<<codecShift - 1
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func parseCodec(name string) (codec, error) {
+	switch name {
+	case "", "none":
+		return codecNone, nil
+	case "snappy":
+		return codecSnappy, nil
+	case "zstd":
+		return codecZstd, nil
+	default:
+		return codecNone, fmt.Errorf("unsupported wal compression: %s", name)
+	}
+}
+
+// packSize combines the payload size and codec into the header size field.
+func packSize(size uint32, c codec) uint32 {
+	return uint32(c)<<codecShift | size
+}
+
+// unpackSize splits the header size field into the payload size and codec.
+func unpackSize(v uint32) (uint32, codec) {
+	return v & maxEntrySize, codec(v >> codecShift)
+}
+
+// compress encodes b with the codec c and appends the result to dst.
+func compress(dst, b []byte, c codec) []byte {
+	switch c {
+	case codecSnappy:
+		return snappy.Encode(dst[:cap(dst)], b)
+	case codecZstd:
+		return zstdEncoder.EncodeAll(b, dst[:0])
+	default:
+		return append(dst[:0], b...)
+	}
+}
+
+// decompress decodes b which was encoded with the codec c.
+func decompress(b []byte, c codec) ([]byte, error) {
+	switch c {
+	case codecNone:
+		return b, nil
+	case codecSnappy:
+		return snappy.Decode(nil, b)
+	case codecZstd:
+		return zstdDecoder.DecodeAll(b, nil)
+	default:
+		return nil, fmt.Errorf("unknown wal entry codec: %d", c)
+	}
+}
@@ -32,9 +32,13 @@ func init() {
 	stopCh = make(chan struct{})
 }
 
-// TeardownWAL stops the WAL and closes the WAL instance.
+// TeardownWAL stops the WAL and closes the WAL instance, flushing
+// and syncing the buffered entries to disk.
 func TeardownWAL() {
 	close(stopCh)
+	if DefaultWAL != nil {
+		DefaultWAL.Stop()
+	}
 }
 
 // SetupWAL initializes the WAL based on the configuration.
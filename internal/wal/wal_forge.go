@@ -30,12 +30,15 @@ const (
 	segmentPrefix = "seg-"
 )
 
-var bb []byte
+var bb, cb []byte
 
 func init() {
 	// Pre-allocate a buffer to avoid re-allocating it
 	// This will hold one WAL Forge Entry Before it is written to the buffer
 	bb = make([]byte, 10*1024)
+
+	// Scratch buffer that holds the compressed WAL element
+	cb = make([]byte, 10*1024)
 }
 
 type walForge struct {
@@ -52,6 +55,11 @@ type walForge struct {
 
 	maxSegmentSizeBytes uint32
 
+	// Codec used to compress WAL elements whose size is at least
+	// compressionMinSize bytes. Smaller elements are written as is.
+	codec              codec
+	compressionMinSize int
+
 	bufferSyncTicker      *time.Ticker
 	segmentRotationTicker *time.Ticker
 
@@ -62,6 +70,12 @@ type walForge struct {
 
 func newWalForge() *walForge {
 	ctx, cancel := context.WithCancel(context.Background())
+
+	c, err := parseCodec(config.Config.WALCompression)
+	if err != nil {
+		slog.Warn("falling back to uncompressed wal entries", slog.Any("error", err))
+	}
+
 	return &walForge{
 		ctx:    ctx,
 		cancel: cancel,
@@ -70,6 +84,9 @@ func newWalForge() *walForge {
 		segmentRotationTicker: time.NewTicker(time.Duration(config.Config.WALSegmentRotationTimeSec) * time.Second),
 
 		maxSegmentSizeBytes: uint32(config.Config.WALMaxSegmentSizeMB) * 1024 * 1024,
+
+		codec:              c,
+		compressionMinSize: config.Config.WALCompressionMinSizeBytes,
 	}
 }
 
@@ -128,9 +145,10 @@ func (wl *walForge) LogCommand(c *wire.Command) error {
 	}
 
 	// TODO: This logic changes as we change the LSN format
-	wl.lsn += 1
+	// The LSN is taken by the entry only once it is accepted, so that
+	// a rejected entry does not leave a gap in the LSNs.
 	el := &w.Element{
-		Lsn:         wl.lsn,
+		Lsn:         wl.lsn + 1,
 		Timestamp:   time.Now().UnixNano(),
 		ElementType: w.ElementType_ELEMENT_TYPE_COMMAND,
 		Payload:     b,
@@ -142,6 +160,26 @@ func (wl *walForge) LogCommand(c *wire.Command) error {
 		return err
 	}
 
+	// Compress the element if it is large enough for the compression
+	// to be worth it. The codec is recorded in the entry header so that
+	// the replay knows how to decode it.
+	// A large element is compressed into a one time allocation rather
+	// than the shared buffer, the same way as the entry below.
+	ec := codecNone
+	if wl.codec != codecNone && len(b) >= wl.compressionMinSize {
+		buf := cb
+		if len(b) > cap(buf) {
+			buf = nil
+		}
+		if compressed := compress(buf, b, wl.codec); len(compressed) < len(b) {
+			b, ec = compressed, wl.codec
+		}
+	}
+
+	if len(b) > maxEntrySize {
+		return fmt.Errorf("wal entry too large, %d > %d", len(b), maxEntrySize)
+	}
+
 	// Wrap the element with Checksum and Size
 	// and keep it ready to be written to the segment file through the buffer
 	// We call this WAL Entry.
@@ -149,28 +187,29 @@ func (wl *walForge) LogCommand(c *wire.Command) error {
 	if err := wl.rotateLogIfNeeded(entrySize); err != nil {
 		return err
 	}
-
-	// If the entry size is greater than the buffer size, we need to
-	// create a new buffer.
-	if entrySize > uint32(cap(bb)) {
-		// TODO: In this case, we can do a one time creation of a new buffer
-		// and proceed rather than using the existing buffer.
-		panic(fmt.Errorf("buffer too small, %d > %d", entrySize, len(bb)))
+	wl.lsn = el.Lsn
+
+	// If the entry does not fit in the pre-allocated buffer, we do a one
+	// time allocation for it instead of growing the shared buffer, so that
+	// a single large entry does not pin the memory forever.
+	buf := bb
+	if entrySize > uint32(cap(buf)) {
+		buf = make([]byte, entrySize)
 	}
 
-	bb = bb[:8+len(b)]
+	buf = buf[:entrySize]
 	chk := crc32.ChecksumIEEE(b)
 
 	// Write header and payload
-	binary.LittleEndian.PutUint32(bb[0:4], chk)
-	binary.LittleEndian.PutUint32(bb[4:8], uint32(len(b)))
-	copy(bb[8:], b)
+	binary.LittleEndian.PutUint32(buf[0:4], chk)
+	binary.LittleEndian.PutUint32(buf[4:8], packSize(uint32(len(b)), ec))
+	copy(buf[8:], b)
 
 	// TODO: Check if we need to handle the error here,
 	// from my initial understanding, we should not be
 	// handling the error here because it would never happen.
 	// Have not tested this yet.
-	_, _ = wl.csWriter.Write(bb)
+	_, _ = wl.csWriter.Write(buf)
 
 	wl.csSize += entrySize
 	return nil
@@ -308,6 +347,7 @@ func (wl *walForge) segments() ([]string, error) {
 // This method is thread safe.
 func (wl *walForge) ReplayCommand(cb func(*wire.Command) error) error {
 	var crc, entrySize uint32
+	var c codec
 	var el w.Element
 
 	// Buffers to hold the header and the element bytes
@@ -328,7 +368,7 @@ func (wl *walForge) ReplayCommand(cb func(*wire.Command) error) error {
 		}
 
 		reader := bufio.NewReader(file)
-		// Format: CRC32 (4 bytes) | Size of WAL entry (4 bytes) | WAL data
+		// Format: CRC32 (4 bytes) | Codec (4 bits) + Size of WAL entry (28 bits) | WAL data
 
 		// TODO: Replace this infinite loop with a more elegant solution
 		for {
@@ -345,7 +385,12 @@ func (wl *walForge) ReplayCommand(cb func(*wire.Command) error) error {
 				return fmt.Errorf("error reading WAL: %w", err)
 			}
 			crc = binary.LittleEndian.Uint32(bb1h[0:4])
-			entrySize = binary.LittleEndian.Uint32(bb1h[4:8])
+			entrySize, c = unpackSize(binary.LittleEndian.Uint32(bb1h[4:8]))
+
+			// Grow the buffer if the entry does not fit in it
+			if entrySize > uint32(cap(bb1ElementBytes)) {
+				bb1ElementBytes = make([]byte, entrySize)
+			}
 
 			if _, err := io.ReadFull(reader, bb1ElementBytes[:entrySize]); err != nil {
 				file.Close()
@@ -370,8 +415,14 @@ func (wl *walForge) ReplayCommand(cb func(*wire.Command) error) error {
 				return fmt.Errorf("CRC32 mismatch: expected %d, got %d", crc, expectedCRC)
 			}
 
+			eb, err := decompress(bb1ElementBytes[:entrySize], c)
+			if err != nil {
+				file.Close()
+				return fmt.Errorf("error decompressing WAL entry: %w", err)
+			}
+
 			// Unmarshal the WAL entry to get the payload
-			if err := proto.Unmarshal(bb1ElementBytes[:entrySize], &el); err != nil {
+			if err := proto.Unmarshal(eb, &el); err != nil {
 				file.Close()
 				return fmt.Errorf("error unmarshaling WAL entry: %w", err)
 			}
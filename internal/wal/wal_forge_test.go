@@ -0,0 +1,77 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package wal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWalForge(t *testing.T, compression string) *walForge {
+	t.Helper()
+	config.ForceInit(&config.DiceDBConfig{
+		WALDir:         t.TempDir(),
+		WALCompression: compression,
+	})
+	wl := newWalForge()
+	assert.NoError(t, wl.Init())
+	return wl
+}
+
+func replayAll(t *testing.T, wl *walForge) []*wire.Command {
+	t.Helper()
+	var cmds []*wire.Command
+	err := wl.ReplayCommand(func(c *wire.Command) error {
+		cmds = append(cmds, c)
+		return nil
+	})
+	assert.NoError(t, err)
+	return cmds
+}
+
+func TestWALForgeLargeEntries(t *testing.T) {
+	for _, compression := range []string{"none", "snappy", "zstd"} {
+		t.Run(compression, func(t *testing.T) {
+			wl := newTestWalForge(t, compression)
+
+			large := strings.Repeat("v", 256*1024)
+			cmds := []*wire.Command{
+				{Cmd: "SET", Args: []string{"k1", "v1"}},
+				{Cmd: "SET", Args: []string{"k2", large}},
+				{Cmd: "SET", Args: []string{"k3", "v3"}},
+			}
+			for _, c := range cmds {
+				assert.NoError(t, wl.LogCommand(c))
+			}
+			wl.Stop()
+
+			replayed := replayAll(t, wl)
+			assert.Len(t, replayed, len(cmds))
+			for i, c := range cmds {
+				assert.Equal(t, c.Cmd, replayed[i].Cmd)
+				assert.Equal(t, c.Args, replayed[i].Args)
+			}
+
+			// The large entry did not grow the shared buffers.
+			assert.Equal(t, 10*1024, cap(bb))
+			assert.Equal(t, 10*1024, cap(cb))
+		})
+	}
+}
+
+func TestWALForgeSizeFieldBackwardCompatible(t *testing.T) {
+	// Entries written before compression was introduced have the codec
+	// bits set to zero and must be read back as uncompressed.
+	size, c := unpackSize(10 * 1024)
+	assert.Equal(t, uint32(10*1024), size)
+	assert.Equal(t, codecNone, c)
+
+	size, c = unpackSize(packSize(1234, codecZstd))
+	assert.Equal(t, uint32(1234), size)
+	assert.Equal(t, codecZstd, c)
+}
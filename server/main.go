@@ -34,6 +34,12 @@ func printConfiguration() {
 	slog.Info("running with", slog.Int("total_commands", cmd.Total()))
 	slog.Info("running with", slog.String("engine", config.Config.Engine))
 	slog.Info("running with", slog.Int("port", config.Config.Port))
+	if config.Config.UnixSocket != "" {
+		slog.Info("running with", slog.String("unix_socket", config.Config.UnixSocket))
+	}
+	if config.Config.TLSPort > 0 {
+		slog.Info("running with", slog.Int("tls_port", config.Config.TLSPort))
+	}
 	slog.Info("running on", slog.Int("cores", runtime.NumCPU()))
 
 	// Conditionally add the number of shards to be used for DiceDB
@@ -42,6 +48,12 @@ func printConfiguration() {
 		numShards = config.Config.NumShards
 	}
 	slog.Info("running with", slog.Int("shards", numShards))
+
+	numIOThreads := runtime.NumCPU()
+	if config.Config.NumIOThreads > 0 {
+		numIOThreads = config.Config.NumIOThreads
+	}
+	slog.Info("running with", slog.Int("io_threads", numIOThreads))
 }
 
 func printBanner() {
@@ -128,9 +140,11 @@ func Start() {
 	ironhawkServer := ironhawk.NewServer(shardManager, ioThreadManager, watchManager)
 
 	// Restore the database from WAL logs
+	replayed := 0
 	if config.Config.EnableWAL {
 		slog.Info("restoring database from WAL")
 		callback := func(cd *wire.Command) error {
+			replayed++
 			cmdTemp := cmd.Cmd{
 				C:        cd,
 				IsReplay: true,
@@ -147,6 +161,23 @@ func Start() {
 		slog.Info("database restored from WAL")
 	}
 
+	// Load the keys from the Redis RDB file, if any, into the empty keyspace.
+	// The loaded keys are logged to the WAL, hence once it holds any command
+	// the keyspace is restored from it, and loading the file again would
+	// revert the writes made since.
+	if config.Config.RDBFile != "" {
+		if replayed > 0 {
+			slog.Info("skipping rdb file, the keyspace was restored from the WAL",
+				slog.String("path", config.Config.RDBFile))
+		} else {
+			slog.Info("loading rdb file", slog.String("path", config.Config.RDBFile))
+			if err := loadRDB(config.Config.RDBFile, shardManager); err != nil {
+				slog.Error("error loading rdb file", slog.Any("error", err))
+				os.Exit(1)
+			}
+		}
+	}
+
 	slog.Info("ready to accept connections")
 	serverWg.Add(1)
 	go runServer(ctx, &serverWg, ironhawkServer, serverErrCh)
@@ -158,6 +189,24 @@ func Start() {
 		cancel()
 	}()
 
+	// Reload the TLS certificates on SIGHUP, so that they are renewed
+	// without a restart.
+	hups := make(chan os.Signal, 1)
+	signal.Notify(hups, syscall.SIGHUP)
+	defer signal.Stop(hups)
+	go func() {
+		for {
+			select {
+			case <-hups:
+				if err := ironhawkServer.ReloadTLS(); err != nil {
+					slog.Error("failed to reload TLS certificates, keeping the previous ones", slog.Any("error", err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		serverWg.Wait()
 		close(serverErrCh) // Close the channel when both servers are done
@@ -173,8 +222,15 @@ func Start() {
 
 	close(sigs)
 
+	// The connections are drained by now, hence the checkpoint and the WAL
+	// hold the writes of every command that completed.
+	if config.Config.ShutdownCheckpointFile != "" {
+		writeCheckpoint(config.Config.ShutdownCheckpointFile, shardManager)
+	}
+
 	if config.Config.EnableWAL {
 		wal.TeardownWAL()
+		slog.Info("wal flushed and synced")
 	}
 
 	cancel()
@@ -199,6 +255,19 @@ func runServer(ctx context.Context, wg *sync.WaitGroup, srv *ironhawk.Server, er
 		slog.Debug("bye.")
 	}
 }
+
+// writeCheckpoint exports the keyspace to the file at path, which can be
+// loaded back with IMPORT.
+func writeCheckpoint(path string, shardManager *shardmanager.ShardManager) {
+	slog.Info("writing checkpoint", slog.String("path", path))
+	c := &cmd.Cmd{C: &wire.Command{Cmd: "EXPORT", Args: []string{path}}}
+	if _, err := c.Execute(shardManager); err != nil {
+		slog.Error("failed to write checkpoint", slog.String("path", path), slog.Any("error", err))
+		return
+	}
+	slog.Info("checkpoint written", slog.String("path", path))
+}
+
 func startProfiling() (func(), error) {
 	// Start CPU profiling
 	cpuFile, err := os.Create("cpu.prof")
@@ -0,0 +1,135 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"time"
+
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dice/internal/object"
+	"github.com/dicedb/dice/internal/rdb"
+	"github.com/dicedb/dice/internal/shardmanager"
+	dstore "github.com/dicedb/dice/internal/store"
+	"github.com/dicedb/dice/internal/types"
+)
+
+// loadRDB loads the keys from the Redis RDB file at path into the
+// shards that own them, logging them to the WAL. Keys from all the
+// databases of the file are loaded into the single DiceDB keyspace.
+func loadRDB(path string, sm *shardmanager.ShardManager) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var loaded, expired, skipped, lossy int
+	now := time.Now().UnixMilli()
+	dbs := map[int]struct{}{}
+
+	p := rdb.NewParser(f)
+	err = p.Parse(func(e *rdb.Entry) error {
+		dbs[e.DB] = struct{}{}
+		if e.ExpiresAt > 0 && e.ExpiresAt <= now {
+			expired++
+			return nil
+		}
+
+		s := sm.GetShardForKey(e.Key).Thread.Store()
+		obj, isLossy := newObjFromRDBEntry(s, e)
+		if obj == nil {
+			skipped++
+			slog.Debug("skipping rdb key with unsupported type",
+				slog.String("key", e.Key), slog.String("type", e.Kind.String()))
+			return nil
+		}
+		if isLossy {
+			lossy++
+		}
+
+		s.Put(e.Key, obj)
+		if e.ExpiresAt > 0 {
+			s.SetUnixTimeExpiry(obj, e.ExpiresAt)
+		}
+		if err := cmd.LogRestore(e.Key, obj, e.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to log key %s to the WAL: %w", e.Key, err)
+		}
+		loaded++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error loading %s after %d keys: %w", path, loaded, err)
+	}
+
+	if len(dbs) > 1 {
+		slog.Warn("rdb file has multiple databases, all of them were loaded into a single keyspace",
+			slog.Int("databases", len(dbs)))
+	}
+	if skipped > 0 {
+		slog.Warn("skipped rdb keys with types not supported by DiceDB", slog.Int("keys", skipped))
+	}
+	if lossy > 0 {
+		slog.Warn("rounded non-integer sorted set scores towards zero", slog.Int("keys", lossy))
+	}
+	slog.Info("loaded rdb file",
+		slog.String("path", path),
+		slog.Int("version", p.Version()),
+		slog.Int("keys", loaded),
+		slog.Int("expired", expired))
+	return nil
+}
+
+// newObjFromRDBEntry converts the entry into a DiceDB object. It returns nil
+// if the type is not supported and whether the conversion lost precision.
+func newObjFromRDBEntry(s *dstore.Store, e *rdb.Entry) (*object.Obj, bool) {
+	switch e.Kind {
+	case rdb.KindString:
+		return cmd.CreateObjectFromValue(s, e.Value.(string), -1), false
+
+	case rdb.KindList:
+		l := types.NewList()
+		l.RPush(e.Value.([]string)...)
+		return s.NewObj(l, -1, object.ObjTypeDequeue), false
+
+	case rdb.KindHash:
+		return s.NewObj(cmd.SSMap(e.Value.(map[string]string)), -1, object.ObjTypeSSMap), false
+
+	case rdb.KindSet:
+		members := e.Value.([]string)
+		set := make(map[string]struct{}, len(members))
+		for _, m := range members {
+			set[m] = struct{}{}
+		}
+		return s.NewObj(set, -1, object.ObjTypeSet), false
+
+	case rdb.KindZSet:
+		// Sorted set scores are integers in DiceDB.
+		lossy := false
+		ss := types.NewSortedSet()
+		for _, m := range e.Value.([]rdb.ZMember) {
+			var score int64
+			switch {
+			case math.IsNaN(m.Score):
+				lossy = true
+				continue
+			case m.Score >= math.MaxInt64:
+				score = math.MaxInt64
+			case m.Score <= math.MinInt64:
+				score = math.MinInt64
+			default:
+				score = int64(m.Score)
+			}
+			if float64(score) != m.Score {
+				lossy = true
+			}
+			_, _ = ss.ZADD([]int64{score}, []string{m.Member}, nil)
+		}
+		return s.NewObj(ss, -1, object.ObjTypeSortedSet), lossy
+	}
+	return nil, false
+}
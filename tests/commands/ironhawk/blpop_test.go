@@ -0,0 +1,138 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBLPOP(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "BLPOP and BRPOP on non-empty lists do not block",
+			commands:       []string{"RPUSH q2 a b c", "BLPOP q1 q2 0", "BRPOP q1 q2 0"},
+			expected:       []interface{}{3, []string{"q2", "a"}, []string{"q2", "c"}},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:           "BLPOP returns an empty list once the timeout expires",
+			commands:       []string{"BLPOP q3 0.1", "BZPOPMIN z3 0.1", "BLMOVE q3 q4 LEFT LEFT 0.1"},
+			expected:       []interface{}{[]string{}, []string{}, ""},
+			valueExtractor: []ValueExtractorFn{extractValueLRANGE, extractValueLRANGE, extractValueLPOP},
+		},
+		{
+			name:           "BZPOPMIN and BZPOPMAX on non-empty sorted sets do not block",
+			commands:       []string{"ZADD z1 10 m1 20 m2 30 m3", "BZPOPMIN z0 z1 0", "BZPOPMAX z1 0"},
+			expected:       []interface{}{3, []string{"z1", "m1", "10"}, []string{"z1", "m3", "30"}},
+			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:     "BLPOP with invalid arguments",
+			commands: []string{"BLPOP q5", "BLPOP q5 -1", "BLPOP q5 abc", "SET s5 v5", "BLPOP s5 0"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'BLPOP' command"),
+				errors.New("timeout is negative"),
+				errors.New("timeout is not a float or out of range"),
+				"OK",
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil, extractValueSET, nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+// fireAsync fires the command from a new connection and returns the channel
+// receiving its result, waiting a bit for the command to reach the server.
+func fireAsync(t *testing.T, args ...string) (*dicedb.Client, <-chan *wire.Result) {
+	t.Helper()
+	client := getLocalConnection()
+	ch := make(chan *wire.Result, 1)
+	go func() {
+		ch <- client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}()
+	time.Sleep(100 * time.Millisecond)
+	return client, ch
+}
+
+func receiveResult(t *testing.T, ch <-chan *wire.Result) *wire.Result {
+	t.Helper()
+	select {
+	case res := <-ch:
+		return res
+	case <-time.After(2 * time.Second):
+		t.Fatal("blocked command was not woken up")
+		return nil
+	}
+}
+
+func TestBLPOPBlocking(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	fire := func(args ...string) *wire.Result {
+		return client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}
+	fire("FLUSHDB")
+
+	t.Run("BLPOP is woken up by a push", func(t *testing.T) {
+		c1, ch := fireAsync(t, "BLPOP", "bq1", "bq2", "0")
+		defer c1.Close()
+
+		fire("RPUSH", "bq2", "a")
+		assert.Equal(t, []string{"bq2", "a"}, receiveResult(t, ch).GetKEYSRes().Keys)
+	})
+
+	t.Run("blocked clients are served in FIFO order", func(t *testing.T) {
+		c1, ch1 := fireAsync(t, "BLPOP", "bq3", "0")
+		defer c1.Close()
+		c2, ch2 := fireAsync(t, "BRPOP", "bq3", "0")
+		defer c2.Close()
+
+		fire("RPUSH", "bq3", "a", "b", "c")
+		assert.Equal(t, []string{"bq3", "a"}, receiveResult(t, ch1).GetKEYSRes().Keys)
+		assert.Equal(t, []string{"bq3", "c"}, receiveResult(t, ch2).GetKEYSRes().Keys)
+		assert.Equal(t, []string{"b"}, fire("LRANGE", "bq3", "0", "-1").GetKEYSRes().Keys)
+	})
+
+	t.Run("disconnected clients stop waiting", func(t *testing.T) {
+		c1, _ := fireAsync(t, "BLPOP", "bq4", "0")
+		c2, ch2 := fireAsync(t, "BLPOP", "bq4", "0")
+		defer c2.Close()
+
+		c1.Close()
+		time.Sleep(100 * time.Millisecond)
+		fire("RPUSH", "bq4", "a")
+		assert.Equal(t, []string{"bq4", "a"}, receiveResult(t, ch2).GetKEYSRes().Keys)
+		assert.Equal(t, int64(0), fire("LLEN", "bq4").GetINCRRes().Value)
+	})
+
+	t.Run("BLMOVE is woken up by a push", func(t *testing.T) {
+		c1, ch := fireAsync(t, "BLMOVE", "bq5", "bq6", "LEFT", "RIGHT", "0")
+		defer c1.Close()
+
+		fire("LPUSH", "bq5", "a")
+		assert.Equal(t, "a", receiveResult(t, ch).GetGETRes().Value)
+		assert.Equal(t, []string{"a"}, fire("LRANGE", "bq6", "0", "-1").GetKEYSRes().Keys)
+	})
+
+	t.Run("BZPOPMIN and BZPOPMAX are woken up by ZADD", func(t *testing.T) {
+		c1, ch1 := fireAsync(t, "BZPOPMIN", "bz1", "0")
+		defer c1.Close()
+		c2, ch2 := fireAsync(t, "BZPOPMAX", "bz1", "0")
+		defer c2.Close()
+
+		fire("ZADD", "bz1", "10", "m1", "20", "m2")
+		assert.Equal(t, []string{"bz1", "m1", "10"}, receiveResult(t, ch1).GetKEYSRes().Keys)
+		assert.Equal(t, []string{"bz1", "m2", "20"}, receiveResult(t, ch2).GetKEYSRes().Keys)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueCOPY(res *wire.Result) interface{} {
+	return res.GetEXPIRERes().IsChanged
+}
+
+func TestCOPY(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "COPY to a new key",
+			commands:       []string{"DEL k2", "SET k1 v1 PX 99500", "COPY k1 k2", "GET k1", "GET k2", "TTL k2"},
+			expected:       []interface{}{0, "OK", true, "v1", "v1", int64(99)},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueSET, extractValueCOPY, extractValueGET, extractValueGET, extractValueTTL},
+		},
+		{
+			name:           "COPY to an existing key",
+			commands:       []string{"SET k1 v1", "SET k2 v2", "COPY k1 k2", "GET k2", "COPY k1 k2 REPLACE", "GET k2"},
+			expected:       []interface{}{"OK", "OK", false, "v2", true, "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, extractValueCOPY, extractValueGET, extractValueCOPY, extractValueGET},
+		},
+		{
+			name:           "COPY creates an independent value",
+			commands:       []string{"DEL h2", "HSET h1 f1 v1", "COPY h1 h2", "HSET h1 f1 v2", "HGET h2 f1"},
+			expected:       []interface{}{0, int64(1), true, int64(0), "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueHSET, extractValueCOPY, extractValueHSET, extractValueHGET},
+		},
+		{
+			name:           "COPY non-existent key",
+			commands:       []string{"COPY nokey k3"},
+			expected:       []interface{}{false},
+			valueExtractor: []ValueExtractorFn{extractValueCOPY},
+		},
+		{
+			name:     "COPY to the same key",
+			commands: []string{"COPY k1 k1"},
+			expected: []interface{}{
+				errors.New("source and destination objects are the same"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "COPY with invalid option",
+			commands: []string{"COPY k1 k2 KEEP"},
+			expected: []interface{}{
+				errors.New("invalid syntax for 'COPY' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
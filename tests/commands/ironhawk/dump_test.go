@@ -0,0 +1,62 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueDUMP(res *wire.Result) interface{} {
+	return res.GetGETRes().Value
+}
+
+func TestDUMP(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "DUMP string value",
+			commands:       []string{"SET k1 v1", "DUMP k1"},
+			expected:       []interface{}{"OK", "CgAAAAACdjHNvlLwmJpbMg=="},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueDUMP},
+		},
+		{
+			name:           "DUMP integer value",
+			commands:       []string{"SET k1 42", "DUMP k1"},
+			expected:       []interface{}{"OK", "CgUAAAAAAAAAKu2LzWxp13Rp"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueDUMP},
+		},
+		{
+			name:           "DUMP hash value",
+			commands:       []string{"HSET k2 f v", "DUMP k2"},
+			expected:       []interface{}{int64(1), "CgcAAAAAAAAAAQAAAAFmAAAAAXZh6Ifmuxhg0w=="},
+			valueExtractor: []ValueExtractorFn{extractValueHSET, extractValueDUMP},
+		},
+		{
+			name:           "DUMP sorted set value",
+			commands:       []string{"ZADD k3 1 a 2 b", "DUMP k3"},
+			expected:       []interface{}{int64(2), "CggAAAAAAAAAAgAAAAFhAAAAAAAAAAEAAAABYgAAAAAAAAACyu7hAVV7U+M="},
+			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueDUMP},
+		},
+		{
+			name:           "DUMP non-existent key",
+			commands:       []string{"DUMP nokey"},
+			expected:       []interface{}{""},
+			valueExtractor: []ValueExtractorFn{extractValueDUMP},
+		},
+		{
+			name:     "DUMP with wrong number of arguments",
+			commands: []string{"DUMP"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'DUMP' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+// evalTestCase is used instead of TestCase as the scripts contain spaces.
+type evalTestCase struct {
+	name           string
+	commands       [][]string
+	expected       []interface{}
+	valueExtractor []ValueExtractorFn
+}
+
+func runEvalTestcases(t *testing.T, testCases []evalTestCase) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	client.Fire(&wire.Command{Cmd: "FLUSHDB"})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, args := range tc.commands {
+				result := client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+				assertEqualResult(t, tc.expected[i], result, tc.valueExtractor[i])
+			}
+		})
+	}
+}
+
+func extractValueEVALInt(res *wire.Result) interface{} {
+	return res.GetINCRRes().Value
+}
+
+func extractValueEVALString(res *wire.Result) interface{} {
+	return res.GetGETRes().Value
+}
+
+func extractValueEVALList(res *wire.Result) interface{} {
+	return res.GetKEYSRes().Keys
+}
+
+func TestEVAL(t *testing.T) {
+	runEvalTestcases(t, []evalTestCase{
+		{
+			name: "EVAL returns the keys and arguments",
+			commands: [][]string{
+				{"EVAL", "return {KEYS[1], KEYS[2], ARGV[1]}", "2", "k1", "k2", "a1"},
+			},
+			expected:       []interface{}{[]string{"k1", "k2", "a1"}},
+			valueExtractor: []ValueExtractorFn{extractValueEVALList},
+		},
+		{
+			name: "EVAL calls commands",
+			commands: [][]string{
+				{"EVAL", "dice.call('SET', KEYS[1], ARGV[1]) return dice.call('INCRBY', KEYS[1], 5) * 2", "1", "counter", "10"},
+				{"GET", "counter"},
+				{"EVAL", "return dice.call('GET', KEYS[1])", "1", "counter"},
+				{"EVAL", "return dice.call('SET', KEYS[1], 'v')", "1", "k1"},
+			},
+			expected:       []interface{}{30, "15", "15", "OK"},
+			valueExtractor: []ValueExtractorFn{extractValueEVALInt, extractValueGET, extractValueEVALString, extractValueEVALString},
+		},
+		{
+			name: "EVAL converts responses with multiple fields to tables",
+			commands: [][]string{
+				{"ZADD", "z1", "10", "m1", "20", "m2"},
+				{"EVAL", "local r = dice.call('ZPOPMIN', KEYS[1]) return {r[1].member, r[1].score}", "1", "z1"},
+			},
+			expected:       []interface{}{2, []string{"m1", "10"}},
+			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueEVALList},
+		},
+		{
+			name: "EVAL returns errors",
+			commands: [][]string{
+				{"SET", "s1", "v1"},
+				{"EVAL", "return dice.call('INCR', KEYS[1])", "1", "s1"},
+				{"EVAL", "local r = dice.pcall('INCR', KEYS[1]) return r.err", "1", "s1"},
+				{"EVAL", "return dice.error_reply('custom error')", "0"},
+				{"EVAL", "return dice.status_reply('DONE')", "0"},
+			},
+			expected: []interface{}{
+				"OK",
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+				"wrongtype operation against a key holding the wrong kind of value",
+				errors.New("custom error"),
+				"DONE",
+			},
+			valueExtractor: []ValueExtractorFn{extractValueSET, nil, extractValueEVALString, nil, func(res *wire.Result) interface{} {
+				return res.Message
+			}},
+		},
+		{
+			name: "EVAL rejects keys that are not declared",
+			commands: [][]string{
+				{"EVAL", "return dice.call('GET', 'undeclared')", "0"},
+				{"EVAL", "return dice.call('GET', 'undeclared')", "1", "declared"},
+			},
+			expected: []interface{}{
+				errors.New("script tried accessing key 'undeclared' that is not declared in KEYS"),
+				errors.New("script tried accessing key 'undeclared' that is not declared in KEYS"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil},
+		},
+		{
+			name: "EVAL math.random returns the same sequence on every run",
+			commands: [][]string{
+				{"EVAL", "return {math.random(1000), math.random(1000)}", "0"},
+				{"EVAL", "return {math.random(1000), math.random(1000)}", "0"},
+			},
+			expected:       []interface{}{[]string{"275", "515"}, []string{"275", "515"}},
+			valueExtractor: []ValueExtractorFn{extractValueEVALList, extractValueEVALList},
+		},
+		{
+			name: "EVAL rejects commands not allowed from scripts",
+			commands: [][]string{
+				{"EVAL", "return dice.call('FLUSHDB')", "0"},
+				{"EVAL", "return dice.call('EVAL', 'return 1', '0')", "0"},
+			},
+			expected: []interface{}{
+				errors.New("command 'FLUSHDB' is not allowed from scripts"),
+				errors.New("command 'EVAL' is not allowed from scripts"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil},
+		},
+		{
+			name: "EVAL does not expose the os and io libraries",
+			commands: [][]string{
+				{"EVAL", "return {type(os), type(io), type(dofile), type(string.len)}", "0"},
+			},
+			expected:       []interface{}{[]string{"nil", "nil", "nil", "function"}},
+			valueExtractor: []ValueExtractorFn{extractValueEVALList},
+		},
+		{
+			name: "EVAL does not expose the functions escaping the sandbox",
+			commands: [][]string{
+				{"EVAL", "return {type(loadstring), type(setfenv), type(_printregs)}", "0"},
+			},
+			expected:       []interface{}{[]string{"nil", "nil", "nil"}},
+			valueExtractor: []ValueExtractorFn{extractValueEVALList},
+		},
+		{
+			name: "EVAL aborts scripts exceeding the time limit",
+			commands: [][]string{
+				{"EVAL", "while true do end", "0"},
+			},
+			expected:       []interface{}{errors.New("script exceeded the time limit of 5000 ms")},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name: "EVAL with invalid arguments",
+			commands: [][]string{
+				{"EVAL", "return 1"},
+				{"EVAL", "return 1", "-1"},
+				{"EVAL", "return 1", "2", "k1"},
+				{"EVAL", "return (", "0"},
+			},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'EVAL' command"),
+				errors.New("number of keys can't be negative or non-integer"),
+				errors.New("number of keys can't be greater than number of args"),
+				errors.New("error compiling script: script at EOF:   syntax error"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil, nil},
+		},
+	})
+}
+
+func TestEVALSHA(t *testing.T) {
+	const sha = "098e0f0d1448c0a81dafe820f66d460eb09263da"
+	runEvalTestcases(t, []evalTestCase{
+		{
+			name: "SCRIPT LOAD, EXISTS and EVALSHA",
+			commands: [][]string{
+				{"SCRIPT", "LOAD", "return ARGV[1]"},
+				{"SCRIPT", "EXISTS", sha, "ffffffffffffffffffffffffffffffffffffffff"},
+				{"EVALSHA", sha, "0", "hello"},
+			},
+			expected:       []interface{}{sha, []string{"1", "0"}, "hello"},
+			valueExtractor: []ValueExtractorFn{extractValueEVALString, extractValueEVALList, extractValueEVALString},
+		},
+		{
+			name: "SCRIPT FLUSH removes the scripts",
+			commands: [][]string{
+				{"SCRIPT", "LOAD", "return ARGV[1]"},
+				{"SCRIPT", "FLUSH"},
+				{"EVALSHA", sha, "0", "hello"},
+			},
+			expected: []interface{}{
+				sha, "OK",
+				errors.New("NOSCRIPT no matching script, use SCRIPT LOAD"),
+			},
+			valueExtractor: []ValueExtractorFn{extractValueEVALString, func(res *wire.Result) interface{} {
+				return res.Message
+			}, nil},
+		},
+		{
+			name: "SCRIPT with an invalid subcommand",
+			commands: [][]string{
+				{"SCRIPT", "KILL"},
+			},
+			expected:       []interface{}{errors.New("invalid syntax for 'SCRIPT' command")},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	})
+}
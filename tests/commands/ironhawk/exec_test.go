@@ -0,0 +1,94 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func extractValueMULTI(res *wire.Result) interface{} {
+	return res.GetMessage()
+}
+
+// extractValueEXEC decodes the results of the queued commands. Failed commands
+// are returned as their error message and the GET commands as their value.
+func extractValueEXEC(res *wire.Result) interface{} {
+	values := []string{}
+	for _, s := range res.GetKEYSRes().GetKeys() {
+		r := &wire.Result{}
+		if err := protojson.Unmarshal([]byte(s), r); err != nil {
+			return err
+		}
+		switch {
+		case r.Status == wire.Status_ERR:
+			values = append(values, "ERR "+r.Message)
+		case r.GetGETRes() != nil:
+			values = append(values, r.GetGETRes().Value)
+		default:
+			values = append(values, r.Message)
+		}
+	}
+	return values
+}
+
+func TestMULTIEXEC(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "EXEC executes the queued commands",
+			commands:       []string{"MULTI", "SET k1 v1", "SET k2 v2", "GET k1", "EXEC", "GET k2"},
+			expected:       []interface{}{"OK", "QUEUED", "QUEUED", "QUEUED", []string{"OK", "OK", "v1"}, "v2"},
+			valueExtractor: []ValueExtractorFn{extractValueMULTI, extractValueMULTI, extractValueMULTI, extractValueMULTI, extractValueEXEC, extractValueGET},
+		},
+		{
+			name:           "EXEC continues after a failed command",
+			commands:       []string{"MULTI", "HSET k1 f v", "SET k3 v3", "EXEC", "GET k3"},
+			expected:       []interface{}{"OK", "QUEUED", "QUEUED", []string{"ERR wrongtype operation against a key holding the wrong kind of value", "OK"}, "v3"},
+			valueExtractor: []ValueExtractorFn{extractValueMULTI, extractValueMULTI, extractValueMULTI, extractValueEXEC, extractValueGET},
+		},
+		{
+			name:     "EXEC is aborted after a command could not be queued",
+			commands: []string{"MULTI", "SET k4 v4", "NOTACOMMAND k4", "EXEC", "GET k4"},
+			expected: []interface{}{
+				"OK", "QUEUED",
+				errors.New("ERROR unknown command 'NOTACOMMAND'"),
+				errors.New("EXECABORT Transaction discarded because of previous errors"),
+				"",
+			},
+			valueExtractor: []ValueExtractorFn{extractValueMULTI, extractValueMULTI, nil, nil, extractValueGET},
+		},
+		{
+			name:           "DISCARD throws away the queued commands",
+			commands:       []string{"MULTI", "SET k5 v5", "DISCARD", "GET k5"},
+			expected:       []interface{}{"OK", "QUEUED", "OK", ""},
+			valueExtractor: []ValueExtractorFn{extractValueMULTI, extractValueMULTI, extractValueMULTI, extractValueGET},
+		},
+		{
+			name:     "MULTI cannot be nested",
+			commands: []string{"MULTI", "MULTI", "DISCARD"},
+			expected: []interface{}{
+				"OK",
+				errors.New("MULTI calls can not be nested"),
+				"OK",
+			},
+			valueExtractor: []ValueExtractorFn{extractValueMULTI, nil, extractValueMULTI},
+		},
+		{
+			name:     "EXEC and DISCARD without MULTI",
+			commands: []string{"EXEC", "DISCARD"},
+			expected: []interface{}{
+				errors.New("EXEC without MULTI"),
+				errors.New("DISCARD without MULTI"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dicedb/dice/internal/cmd"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func extractValueEXPORT(result *wire.Result) interface{} {
+	return result.GetMessage()
+}
+
+func TestEXPORT(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	path := filepath.Join(t.TempDir(), "dicedb.jsonl")
+
+	testCases := []TestCase{
+		{
+			name: "EXPORT and IMPORT all the types",
+			commands: []string{
+				"SET k1 v1", "SET k2 42", "SET k3 4.5 EX 100", "HSET k4 f1 v1 f2 v2", "ZADD k5 10 m1 20 m2",
+				"EXPORT " + path,
+				"FLUSHDB",
+				"IMPORT " + path,
+				"GET k1", "GET k2", "GET k3", "HGET k4 f2", "ZCARD k5", "TTL k3",
+			},
+			expected: []interface{}{
+				"OK", "OK", "OK", 2, 2,
+				"OK",
+				"OK",
+				"OK",
+				"v1", "42", "4.500000", "v2", 2, int64(99),
+			},
+			valueExtractor: []ValueExtractorFn{
+				extractValueSET, extractValueSET, extractValueSET, extractValueHSET, extractValueZADD,
+				extractValueEXPORT,
+				extractValueFLUSHDB,
+				extractValueIMPORT,
+				extractValueGET, extractValueGET, extractValueGET, extractValueHGET, extractValueZCARD, extractValueTTL,
+			},
+		},
+		{
+			name:     "EXPORT with wrong number of arguments",
+			commands: []string{"EXPORT"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'EXPORT' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestIMPORTWALReplay(t *testing.T) {
+	replay := setupOwnWAL(t)
+	port, shutdown := runOwnServer(t)
+	client, err := dicedb.NewClient("localhost", port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dicedb.jsonl")
+	for _, c := range []*wire.Command{
+		{Cmd: "SET", Args: []string{"wal-k1", "v1"}},
+		{Cmd: "EXPORT", Args: []string{path}},
+		{Cmd: "FLUSHDB"},
+		{Cmd: "IMPORT", Args: []string{path}},
+		{Cmd: "SET", Args: []string{"wal-k2", "v2"}},
+	} {
+		res := client.Fire(c)
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	client.Close()
+	assert.Nil(t, <-shutdown())
+
+	// The imported keys are restored from the WAL rather than from the
+	// file, which the replayed EXPORT does not write again.
+	assert.Nil(t, os.Remove(path))
+	shardManager := replay()
+	for key, value := range map[string]string{"wal-k1": "v1", "wal-k2": "v2"} {
+		res, err := (&cmd.Cmd{C: &wire.Command{Cmd: "GET", Args: []string{key}}}).Execute(shardManager)
+		assert.Nil(t, err)
+		assert.Equal(t, value, res.Rs.GetGETRes().Value, key)
+	}
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testLibCounters = "#!lua name=counters\n" +
+		"dice.register_function('incrtwice', function(keys, args)\n" +
+		"  dice.call('INCRBY', keys[1], args[1])\n" +
+		"  return dice.call('INCRBY', keys[1], args[1])\n" +
+		"end)\n" +
+		"dice.register_function{function_name='peek', callback=function(keys)\n" +
+		"  return dice.call('GET', keys[1])\n" +
+		"end, flags={'no-writes'}}\n" +
+		"dice.register_function{function_name='sneaky', callback=function(keys)\n" +
+		"  return dice.call('SET', keys[1], 'x')\n" +
+		"end, flags={'no-writes'}}\n"
+	testLibOther = "#!lua name=other\n" +
+		"dice.register_function('hello', function(keys, args) return 'hello ' .. args[1] end)\n"
+)
+
+func extractValueFUNCTIONMessage(res *wire.Result) interface{} {
+	return res.Message
+}
+
+func TestFUNCTION(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	client.Fire(&wire.Command{Cmd: "FUNCTION", Args: []string{"FLUSH"}})
+
+	runEvalTestcases(t, []evalTestCase{
+		{
+			name: "FUNCTION LOAD and FCALL",
+			commands: [][]string{
+				{"FUNCTION", "LOAD", testLibCounters},
+				{"FCALL", "incrtwice", "1", "fc1", "5"},
+				{"FCALL", "peek", "1", "fc1"},
+				{"FCALL_RO", "peek", "1", "fc1"},
+			},
+			expected:       []interface{}{"counters", 10, "10", "10"},
+			valueExtractor: []ValueExtractorFn{extractValueEVALString, extractValueEVALInt, extractValueEVALString, extractValueEVALString},
+		},
+		{
+			name: "FUNCTION LOAD of an existing library",
+			commands: [][]string{
+				{"FUNCTION", "LOAD", testLibCounters},
+				{"FUNCTION", "LOAD", "REPLACE", testLibCounters},
+				{"FUNCTION", "LOAD", "#!lua name=dup\ndice.register_function('peek', function() return 1 end)"},
+			},
+			expected: []interface{}{
+				errors.New("library 'counters' already exists"),
+				"counters",
+				errors.New("function 'peek' already exists in library 'counters'"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, extractValueEVALString, nil},
+		},
+		{
+			name: "FUNCTION LOAD of invalid libraries",
+			commands: [][]string{
+				{"FUNCTION", "LOAD", "return 1"},
+				{"FUNCTION", "LOAD", "#!lua name=empty\nlocal x = 1"},
+				{"FUNCTION", "LOAD", "#!lua name=calls\ndice.call('PING')"},
+				{"FUNCTION", "LOAD", "#!lua name=flags\ndice.register_function{function_name='f', callback=function() end, flags={'bad'}}"},
+			},
+			expected: []interface{}{
+				errors.New("missing or invalid library shebang, expected '#!lua name=<library name>'"),
+				errors.New("no functions registered"),
+				errors.New("commands can not be called while loading a library"),
+				errors.New("flags:2: unknown flag 'bad' given to dice.register_function"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil, nil},
+		},
+		{
+			name: "FCALL_RO only calls read-only functions",
+			commands: [][]string{
+				{"FCALL_RO", "incrtwice", "1", "fc1", "5"},
+				{"FCALL", "sneaky", "1", "fc1"},
+				{"FCALL_RO", "sneaky", "1", "fc1"},
+				{"FCALL", "nofunc", "0"},
+			},
+			expected: []interface{}{
+				errors.New("can not call a function without the no-writes flag using FCALL_RO"),
+				errors.New("write commands are not allowed from read-only functions"),
+				errors.New("write commands are not allowed from read-only functions"),
+				errors.New("function not found"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil, nil},
+		},
+		{
+			name: "FUNCTION LIST",
+			commands: [][]string{
+				{"FUNCTION", "LOAD", testLibOther},
+				{"FUNCTION", "LIST"},
+				{"FUNCTION", "LIST", "LIBRARYNAME", "oth*", "WITHCODE"},
+			},
+			expected: []interface{}{
+				"other",
+				[]string{
+					`{"library_name":"counters","functions":[{"name":"incrtwice","flags":[]},{"name":"peek","flags":["no-writes"]},{"name":"sneaky","flags":["no-writes"]}]}`,
+					`{"library_name":"other","functions":[{"name":"hello","flags":[]}]}`,
+				},
+				[]string{
+					`{"library_name":"other","functions":[{"name":"hello","flags":[]}],"library_code":"#!lua name=other\ndice.register_function('hello', function(keys, args) return 'hello ' .. args[1] end)\n"}`,
+				},
+			},
+			valueExtractor: []ValueExtractorFn{extractValueEVALString, extractValueEVALList, extractValueEVALList},
+		},
+		{
+			name: "FUNCTION DELETE",
+			commands: [][]string{
+				{"FUNCTION", "DELETE", "other"},
+				{"FCALL", "hello", "0", "world"},
+				{"FUNCTION", "DELETE", "other"},
+			},
+			expected: []interface{}{
+				"OK",
+				errors.New("function not found"),
+				errors.New("library not found"),
+			},
+			valueExtractor: []ValueExtractorFn{extractValueFUNCTIONMessage, nil, nil},
+		},
+	})
+}
+
+func TestFUNCTIONDumpRestore(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	fire := func(args ...string) *wire.Result {
+		return client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}
+
+	fire("FUNCTION", "FLUSH")
+	assert.Equal(t, "other", fire("FUNCTION", "LOAD", testLibOther).GetGETRes().Value)
+	payload := fire("FUNCTION", "DUMP").GetGETRes().Value
+
+	res := fire("FUNCTION", "RESTORE", payload)
+	assert.Equal(t, "library 'other' already exists", res.Message)
+
+	assert.Equal(t, wire.Status_OK, fire("FUNCTION", "RESTORE", payload, "REPLACE").Status)
+	assert.Equal(t, wire.Status_OK, fire("FUNCTION", "LOAD", testLibCounters).Status)
+	assert.Equal(t, wire.Status_OK, fire("FUNCTION", "RESTORE", payload, "FLUSH").Status)
+
+	assert.Equal(t, "hello world", fire("FCALL", "hello", "0", "world").GetGETRes().Value)
+	assert.Equal(t, "function not found", fire("FCALL", "peek", "1", "k").Message)
+
+	res = fire("FUNCTION", "RESTORE", "bm90IGEgcGF5bG9hZA==")
+	assert.Equal(t, "payload version or checksum are wrong", res.Message)
+
+	fire("FUNCTION", "FLUSH")
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLocalWire returns a connection on which no HANDSHAKE has been sent.
+func newLocalWire(t *testing.T) *dicedb.ClientWire {
+	t.Helper()
+	w, err := dicedb.NewClientWire(32*1024*1024, "localhost", config.Config.Port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// fireWire sends the command on the connection and returns its response.
+func fireWire(t *testing.T, w *dicedb.ClientWire, c *wire.Command) *wire.Result {
+	t.Helper()
+	if err := w.Send(c); err != nil {
+		t.Fatal(err)
+	}
+	res, err := w.Receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestHANDSHAKE(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "HANDSHAKE with one argument",
+			commands: []string{"HANDSHAKE client"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'HANDSHAKE' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "HANDSHAKE with an invalid execution mode",
+			commands: []string{"HANDSHAKE client replica"},
+			expected: []interface{}{
+				errors.New("invalid execution_mode 'replica', must be command or watch"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "HANDSHAKE again with another client id",
+			commands: []string{"HANDSHAKE another-client command"},
+			expected: []interface{}{
+				errors.New("the connection has already completed its HANDSHAKE"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestHANDSHAKEConnectionIDs(t *testing.T) {
+	clientID := uniqueClientID("handshaking-client")
+	first := newLocalWire(t)
+	defer first.Close()
+	second := newLocalWire(t)
+	defer second.Close()
+
+	handshake := &wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, "command"}}
+	firstRes := fireWire(t, first, handshake)
+	secondRes := fireWire(t, second, handshake)
+	assert.Equal(t, wire.Status_OK, firstRes.Status, firstRes.Message)
+	assert.Equal(t, wire.Status_OK, secondRes.Status, secondRes.Message)
+	assert.True(t, strings.HasPrefix(firstRes.Message, "OK conn_id="), firstRes.Message)
+	assert.NotEqual(t, firstRes.Message, secondRes.Message)
+
+	// Sending the same HANDSHAKE again is a no-op.
+	res := fireWire(t, first, handshake)
+	assert.Equal(t, firstRes.Message, res.Message)
+}
+
+func TestHANDSHAKERequiredBeforeWatch(t *testing.T) {
+	w := newLocalWire(t)
+	defer w.Close()
+
+	res := fireWire(t, w, &wire.Command{Cmd: "GET.WATCH", Args: []string{"k"}})
+	assert.Equal(t, wire.Status_ERR, res.Status)
+	assert.Equal(t, "HANDSHAKE is required before GET.WATCH", res.Message)
+}
+
+func TestHANDSHAKEDuplicateWatchConnection(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	clientID := uniqueClientID("shared-watcher")
+	key := uniqueClientID("shared")
+
+	watcher := getLocalWatchWire(clientID)
+	defer watcher.Close()
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "GET.WATCH", Args: []string{key}}))
+	assert.NotNil(t, receiveWatch(watcher, time.Second))
+
+	// A second watch connection with the same client id would receive the
+	// notifications of the first one, hence it is rejected.
+	other := newLocalWire(t)
+	defer other.Close()
+	res := fireWire(t, other, &wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, "watch"}})
+	assert.Equal(t, wire.Status_ERR, res.Status)
+	assert.Equal(t, "client id '"+clientID+"' already has a watch connection", res.Message)
+
+	// A .WATCH command sent on a command connection of the client is responded
+	// to on it, but does not take the notifications from its watch connection.
+	command := newLocalWire(t)
+	defer command.Close()
+	res = fireWire(t, command, &wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, "command"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	res = fireWire(t, command, &wire.Command{Cmd: "GET.WATCH", Args: []string{key}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	res = writer.Fire(&wire.Command{Cmd: "SET", Args: []string{key, "v"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	res = receiveWatch(watcher, time.Second)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "v", res.GetGETRes().Value)
+	}
+
+	// Once the watch connection is closed, the client id can establish another.
+	watcher.Close()
+	time.Sleep(100 * time.Millisecond)
+	res = fireWire(t, other, &wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, "watch"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+}
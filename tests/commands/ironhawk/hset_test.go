@@ -41,6 +41,23 @@ func TestHSET(t *testing.T) {
 			},
 			valueExtractor: []ValueExtractorFn{nil, nil},
 		},
+		{
+			name: "Set Hash with IFEQ",
+			commands: []string{"HSET h1 f1 v1", "HSET h1 IFEQ v1 f1 v2", "HSET h1 IFEQ v1 f1 v3", "HGET h1 f1",
+				"HSET h1 IFEQ v2 f2 v2", "HSET h1 IFEQ v2 f1 v3 f2 v3"},
+			expected: []interface{}{1, 0,
+				errors.New("precondition failed"), "v2",
+				errors.New("precondition failed"),
+				errors.New("invalid syntax for 'HSET' command"),
+			},
+			valueExtractor: []ValueExtractorFn{extractValueHSET, extractValueHSET, nil, extractValueHGET, nil, nil},
+		},
+		{
+			name:           "Set Hash fields named like options",
+			commands:       []string{"HSET h2 IFEQ v1", "HGET h2 IFEQ"},
+			expected:       []interface{}{1, "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueHSET, extractValueHGET},
+		},
 	}
 	runTestcases(t, client, testCases)
 }
@@ -0,0 +1,55 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueIMPORT(result *wire.Result) interface{} {
+	return result.GetMessage()
+}
+
+func TestIMPORT(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	path := filepath.Join(t.TempDir(), "dicedb.jsonl")
+
+	testCases := []TestCase{
+		{
+			name:           "IMPORT a previously exported file",
+			commands:       []string{"SET k1 v1", "EXPORT " + path, "DEL k1", "IMPORT " + path, "GET k1"},
+			expected:       []interface{}{"OK", "OK", 1, "OK", "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueEXPORT, extractValueDEL, extractValueIMPORT, extractValueGET},
+		},
+		{
+			name:           "IMPORT does not replace existing keys by default",
+			commands:       []string{"SET k1 v2", "IMPORT " + path, "GET k1", "IMPORT " + path + " REPLACE", "GET k1"},
+			expected:       []interface{}{"OK", "OK", "v2", "OK", "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueIMPORT, extractValueGET, extractValueIMPORT, extractValueGET},
+		},
+		{
+			name:     "IMPORT with invalid option",
+			commands: []string{"IMPORT " + path + " KEEP"},
+			expected: []interface{}{
+				errors.New("invalid syntax for 'IMPORT' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "IMPORT a file that does not exist",
+			commands: []string{"IMPORT " + path + ".missing"},
+			expected: []interface{}{
+				errors.New("open " + path + ".missing: no such file or directory"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
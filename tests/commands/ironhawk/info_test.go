@@ -0,0 +1,140 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// infoClients returns the clients section of INFO as a map.
+func infoClients(t *testing.T, client *dicedb.Client) map[string]string {
+	t.Helper()
+	res := client.Fire(&wire.Command{Cmd: "INFO", Args: []string{"clients"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	values := res.GetKEYSRes().Keys
+	info := map[string]string{}
+	for i := 0; i+1 < len(values); i += 2 {
+		info[values[i]] = values[i+1]
+	}
+	return info
+}
+
+func TestINFO(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "INFO with too many arguments",
+			commands: []string{"INFO clients server"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'INFO' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "INFO with an unsupported section",
+			commands: []string{"INFO keyspace"},
+			expected: []interface{}{
+				errors.New("unsupported INFO section 'keyspace'"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+
+	info := infoClients(t, client)
+	assert.NotEqual(t, "0", info["connected_clients"])
+	assert.Equal(t, "20000", info["maxclients"])
+	assert.Equal(t, "0", info["timeout"])
+	assert.Equal(t, "300", info["tcp_keepalive"])
+
+	res := client.Fire(&wire.Command{Cmd: "INFO"})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	assert.Equal(t, "connected_clients", res.GetKEYSRes().Keys[0])
+}
+
+func TestINFOMaxClients(t *testing.T) {
+	defer func(n int) { config.Config.MaxClients = n }(config.Config.MaxClients)
+	config.Config.MaxClients = 2
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	first, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer first.Close()
+	second, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+
+	// The connection over the limit is responded to with an error.
+	_, err = dicedb.NewClient("localhost", port)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "could not complete the handshake: maximum number of clients reached", err.Error())
+	}
+
+	info := infoClients(t, first)
+	assert.Equal(t, "2", info["connected_clients"])
+	assert.Equal(t, "2", info["maxclients"])
+	assert.Equal(t, "1", info["rejected_connections"])
+
+	// Once a client disconnects, another one can connect.
+	second.Close()
+	time.Sleep(100 * time.Millisecond)
+	third, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer third.Close()
+}
+
+func TestINFOIdleTimeout(t *testing.T) {
+	defer func(n int) { config.Config.Timeout = n }(config.Config.Timeout)
+	config.Config.Timeout = 1
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	handshake := func(mode string) *dicedb.ClientWire {
+		w, err := dicedb.NewClientWire(32*1024*1024, "localhost", port)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := fireWire(t, w, &wire.Command{Cmd: "HANDSHAKE", Args: []string{uniqueClientID("idle-" + mode), mode}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		return w
+	}
+	idle := handshake("command")
+	defer idle.Close()
+	watcher := handshake("watch")
+	defer watcher.Close()
+	active := handshake("command")
+	defer active.Close()
+
+	// The active connection sends a command more often than the timeout.
+	for i := 0; i < 5; i++ {
+		time.Sleep(500 * time.Millisecond)
+		res := fireWire(t, active, &wire.Command{Cmd: "PING"})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+
+	// The idle command-mode connection is closed, whereas the watch-mode one
+	// is kept open.
+	assert.Nil(t, idle.Send(&wire.Command{Cmd: "PING"}))
+	_, wireErr := idle.Receive()
+	assert.NotNil(t, wireErr)
+	res := fireWire(t, watcher, &wire.Command{Cmd: "PING"})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	client, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer client.Close()
+	info := infoClients(t, client)
+	assert.Equal(t, "1", info["timeout"])
+	assert.Equal(t, "1", info["timed_out_clients"])
+	assert.Equal(t, "1", info["watch_clients"])
+}
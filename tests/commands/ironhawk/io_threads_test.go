@@ -0,0 +1,70 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIOThreadsPipelinedCommands(t *testing.T) {
+	w := newLocalWire(t)
+	defer w.Close()
+	key := uniqueClientID("pipelined")
+
+	// The commands are sent without waiting for their results, hence they
+	// span several turns of the io-thread on the pool, and are executed
+	// in the order they were sent.
+	n := 200
+	for i := 0; i < n; i++ {
+		assert.Nil(t, w.Send(&wire.Command{Cmd: "INCR", Args: []string{key}}))
+	}
+	for i := 1; i <= n; i++ {
+		res, err := w.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, int64(i), res.GetINCRRes().Value)
+	}
+}
+
+func TestIOThreadsBlockingCommandsDoNotHoldThePool(t *testing.T) {
+	defer func(n int) { config.Config.NumIOThreads = n }(config.Config.NumIOThreads)
+	config.Config.NumIOThreads = 1
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	blocked, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer blocked.Close()
+	client, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	resCh := make(chan *wire.Result, 1)
+	go func() {
+		resCh <- blocked.Fire(&wire.Command{Cmd: "BLPOP", Args: []string{"pool-list", "0"}})
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// The only worker of the pool is free to execute the commands of the
+	// other clients while BLPOP waits.
+	for i := 0; i < 10; i++ {
+		res := client.Fire(&wire.Command{Cmd: "SET", Args: []string{"pool-key", strconv.Itoa(i)}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	res := client.Fire(&wire.Command{Cmd: "RPUSH", Args: []string{"pool-list", "a"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	assert.Equal(t, []string{"pool-list", "a"}, receiveResult(t, resCh).GetKEYSRes().Keys)
+
+	// The client that was blocked is served by the pool again.
+	res = blocked.Fire(&wire.Command{Cmd: "GET", Args: []string{"pool-key"}})
+	assert.Equal(t, "9", res.GetGETRes().Value)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKEYSWATCH(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "KEYS.WATCH returns the keys matching the pattern",
+			commands:       []string{"SET cart:1 a", "SET user:1 b", "KEYS.WATCH cart:*"},
+			expected:       []interface{}{"OK", "OK", []string{"cart:1"}},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, extractValueLRANGE},
+		},
+		{
+			name:     "KEYS.WATCH with invalid arguments",
+			commands: []string{"KEYS.WATCH", "KEYS.WATCH a b"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'KEYS.WATCH' command"),
+				errors.New("wrong number of arguments for 'KEYS.WATCH' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestKEYSWATCHNotifications(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	// The watcher is not closed, as closing a client having a watch
+	// connection makes its watch goroutine close the channel twice.
+	watcher := getLocalConnection()
+
+	ch, err := watcher.WatchCh()
+	assert.Nil(t, err)
+
+	write := func(args ...string) {
+		res := writer.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	write("FLUSHDB")
+
+	res := watcher.Fire(&wire.Command{Cmd: "KEYS.WATCH", Args: []string{"cart:*"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	fp := res.Fingerprint64
+	res = watcher.Fire(&wire.Command{Cmd: "KEYS.WATCH", Args: []string{"c?rt:2"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	t.Run("changes to the matching keys are notified", func(t *testing.T) {
+		write("SET", "cart:1", "book")
+		assert.Equal(t, []string{"cart:1", "SET"}, receiveMessage(t, ch))
+		write("SET", "user:1", "alice")
+		write("DEL", "cart:1")
+		assert.Equal(t, []string{"cart:1", "DEL"}, receiveMessage(t, ch))
+	})
+
+	t.Run("every matching pattern is notified", func(t *testing.T) {
+		write("SET", "cart:2", "pen")
+		received := [][]string{receiveMessage(t, ch), receiveMessage(t, ch)}
+		assert.Equal(t, [][]string{{"cart:2", "SET"}, {"cart:2", "SET"}}, received)
+
+		write("RENAME", "cart:2", "cart:3")
+		received = [][]string{receiveMessage(t, ch), receiveMessage(t, ch), receiveMessage(t, ch)}
+		assert.ElementsMatch(t, [][]string{
+			{"cart:2", "RENAME"},
+			{"cart:2", "RENAME"},
+			{"cart:3", "RENAME"},
+		}, received)
+	})
+
+	t.Run("unwatched patterns are not notified", func(t *testing.T) {
+		res := watcher.Fire(&wire.Command{Cmd: "UNWATCH", Args: []string{strconv.FormatUint(fp, 10)}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+		write("SET", "cart:4", "cap")
+		write("SET", "cart:2", "cap")
+		assert.Equal(t, []string{"cart:2", "SET"}, receiveMessage(t, ch))
+		select {
+		case res := <-ch:
+			t.Fatalf("unexpected notification %v", res.GetKEYSRes().Keys)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
+
+func TestKEYSWATCHPatternSyntax(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	watcher := getLocalConnection()
+
+	ch, err := watcher.WatchCh()
+	assert.Nil(t, err)
+
+	write := func(args ...string) {
+		res := writer.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	write("FLUSHDB")
+
+	// The notifications match the keys the way the initial result does.
+	write("SET", "bag:1", "a")
+	write("SET", "bag:3", "b")
+	write("SET", "dir/a/b", "c")
+	res := watcher.Fire(&wire.Command{Cmd: "KEYS.WATCH", Args: []string{"bag:[12]"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	assert.Equal(t, []string{"bag:1"}, res.GetKEYSRes().Keys)
+	res = watcher.Fire(&wire.Command{Cmd: "KEYS.WATCH", Args: []string{"dir/*"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	assert.Empty(t, res.GetKEYSRes().Keys)
+
+	write("SET", "bag:3", "d")
+	write("SET", "dir/a/b", "e")
+	write("SET", "bag:2", "f")
+	assert.Equal(t, []string{"bag:2", "SET"}, receiveMessage(t, ch))
+	write("SET", "dir/a", "g")
+	assert.Equal(t, []string{"dir/a", "SET"}, receiveMessage(t, ch))
+	select {
+	case res := <-ch:
+		t.Fatalf("unexpected notification %v", res.GetKEYSRes().Keys)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
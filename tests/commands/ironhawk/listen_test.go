@@ -0,0 +1,139 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// fireConn sends the command on a connection the dicedb client cannot dial,
+// such as a unix domain socket, and returns its response.
+func fireConn(t *testing.T, conn net.Conn, c *wire.Command) *wire.Result {
+	t.Helper()
+	rs, err := roundTrip(conn, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+// roundTrip sends the command on the connection and reads its response.
+func roundTrip(conn net.Conn, c *wire.Command) (*wire.Result, error) {
+	msg, err := proto.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(msg)))
+	if _, err := conn.Write(append(buf, msg...)); err != nil {
+		return nil, err
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(conn, prefix); err != nil {
+		return nil, err
+	}
+	msg = make([]byte, binary.BigEndian.Uint32(prefix))
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		return nil, err
+	}
+	rs := &wire.Result{}
+	if err := proto.Unmarshal(msg, rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func TestListenMultipleHosts(t *testing.T) {
+	defer func(host string) { config.Config.Host = host }(config.Config.Host)
+	config.Config.Host = "127.0.0.1, ::1"
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	for _, host := range []string{"127.0.0.1", "[::1]"} {
+		client, err := dicedb.NewClientWire(32*1024*1024, host, port)
+		if !assert.Nil(t, err, host) {
+			continue
+		}
+		res := fireWire(t, client, &wire.Command{Cmd: "SET", Args: []string{"listen-" + host, "v"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		client.Close()
+	}
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	defer func(path, perm string) {
+		config.Config.UnixSocket, config.Config.UnixSocketPerm = path, perm
+	}(config.Config.UnixSocket, config.Config.UnixSocketPerm)
+	path := filepath.Join(t.TempDir(), "dicedb.sock")
+	config.Config.UnixSocket = path
+	config.Config.UnixSocketPerm = "770"
+
+	// A socket left behind by a server that did not shut down cleanly is
+	// replaced.
+	stale, err := net.Listen("unix", path)
+	assert.Nil(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	_, shutdown := runOwnServer(t)
+
+	fi, err := os.Stat(path)
+	if assert.Nil(t, err) {
+		assert.Equal(t, os.ModeSocket|0o770, fi.Mode())
+	}
+
+	conn, err := net.Dial("unix", path)
+	if assert.Nil(t, err) {
+		res := fireConn(t, conn, &wire.Command{Cmd: "HANDSHAKE", Args: []string{uniqueClientID("sidecar"), "command"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		res = fireConn(t, conn, &wire.Command{Cmd: "SET", Args: []string{"listen-unix", "v"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		res = fireConn(t, conn, &wire.Command{Cmd: "GET", Args: []string{"listen-unix"}})
+		assert.Equal(t, "v", res.GetGETRes().Value)
+		conn.Close()
+	}
+
+	assert.Nil(t, <-shutdown())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestListenInvalidAddresses(t *testing.T) {
+	defer func(host, path string) {
+		config.Config.Host, config.Config.UnixSocket = host, path
+	}(config.Config.Host, config.Config.UnixSocket)
+
+	config.Config.Host = "localhost"
+	_, shutdown := runOwnServer(t)
+	err := <-shutdown()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid IP address: localhost", err.Error())
+	}
+
+	// A file at the unix socket path is not removed unless it is a socket.
+	config.Config.Host = "127.0.0.1"
+	path := filepath.Join(t.TempDir(), "dicedb.sock")
+	assert.Nil(t, os.WriteFile(path, []byte("data"), 0o600))
+	config.Config.UnixSocket = path
+	_, shutdown = runOwnServer(t)
+	err = <-shutdown()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "failed to listen on "+path+": the file exists and is not a socket", err.Error())
+	}
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func extractValueLPUSH(res *wire.Result) interface{} {
+	return res.GetINCRRes().Value
+}
+
+func extractValueLPOP(res *wire.Result) interface{} {
+	return res.GetGETRes().Value
+}
+
+func extractValueLRANGE(res *wire.Result) interface{} {
+	return res.GetKEYSRes().Keys
+}
+
+func TestLPUSH(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "LPUSH and RPUSH",
+			commands:       []string{"LPUSH l1 b a", "RPUSH l1 c d", "LRANGE l1 0 -1", "LLEN l1"},
+			expected:       []interface{}{2, 4, []string{"a", "b", "c", "d"}, 4},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLPUSH, extractValueLRANGE, extractValueLPUSH},
+		},
+		{
+			name:           "LRANGE with negative and out of range indexes",
+			commands:       []string{"RPUSH l2 a b c d", "LRANGE l2 -2 -1", "LRANGE l2 1 100", "LRANGE l2 3 1", "LRANGE nolist 0 -1"},
+			expected:       []interface{}{4, []string{"c", "d"}, []string{"b", "c", "d"}, []string{}, []string{}},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLRANGE, extractValueLRANGE, extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:           "LPOP and RPOP delete the list once it is empty",
+			commands:       []string{"RPUSH l3 a b", "LPOP l3", "RPOP l3", "EXISTS l3", "LPOP l3"},
+			expected:       []interface{}{2, "a", "b", 0, ""},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLPOP, extractValueLPOP, extractValueEXISTS, extractValueLPOP},
+		},
+		{
+			name:           "LMOVE between lists",
+			commands:       []string{"RPUSH l4 a b c", "LMOVE l4 l5 LEFT RIGHT", "LMOVE l4 l4 RIGHT LEFT", "LRANGE l4 0 -1", "LRANGE l5 0 -1"},
+			expected:       []interface{}{3, "a", "c", []string{"c", "b"}, []string{"a"}},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLPOP, extractValueLPOP, extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:     "list commands on the wrong type",
+			commands: []string{"SET s1 v1", "LPUSH s1 a", "LPOP s1", "RPUSH l6 a", "LMOVE l6 s1 LEFT LEFT", "LLEN l6"},
+			expected: []interface{}{
+				"OK",
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+				1,
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+				1,
+			},
+			valueExtractor: []ValueExtractorFn{extractValueSET, nil, nil, extractValueLPUSH, nil, extractValueLPUSH},
+		},
+		{
+			name:     "list commands with invalid arguments",
+			commands: []string{"LPUSH l7", "LRANGE l7 a 1", "LMOVE l7 l8 UP LEFT"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'LPUSH' command"),
+				errors.New("value is not an integer or out of range"),
+				errors.New("invalid syntax for 'LMOVE' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestLPUSHConcurrent(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	client.Fire(&wire.Command{Cmd: "DEL", Args: []string{"lc"}})
+
+	// The pushes of clients on the same list are all kept.
+	const clients, pushes = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := getLocalConnection()
+			defer c.Close()
+			cmd := "LPUSH"
+			if i%2 == 1 {
+				cmd = "RPUSH"
+			}
+			for j := 0; j < pushes; j++ {
+				c.Fire(&wire.Command{Cmd: cmd, Args: []string{"lc", strconv.Itoa(j)}})
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int64(clients*pushes), client.Fire(&wire.Command{Cmd: "LLEN", Args: []string{"lc"}}).GetINCRRes().Value)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMGET(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "MGET returns the values in the order of the keys",
+			commands:       []string{"SET k1 v1", "SET k2 2", "MGET k2 k3 k1"},
+			expected:       []interface{}{"OK", "OK", []string{"2", "", "v1"}},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, extractValueLRANGE},
+		},
+		{
+			name:           "MGET returns an empty string for the keys not holding a string",
+			commands:       []string{"LPUSH l a", "MGET l"},
+			expected:       []interface{}{1, []string{""}},
+			valueExtractor: []ValueExtractorFn{extractValueLPUSH, extractValueLRANGE},
+		},
+		{
+			name:     "MGET without keys",
+			commands: []string{"MGET"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'MGET' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMGETWATCH(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "MGET.WATCH without keys",
+			commands: []string{"MGET.WATCH"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'MGET.WATCH' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestMGETWATCHNotifications(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	watcher := getLocalWatchWire(uniqueClientID("mget-watcher"))
+	defer watcher.Close()
+
+	set := func(key, value string) {
+		res := writer.Fire(&wire.Command{Cmd: "SET", Args: []string{key, value}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	receive := func() []string {
+		res := receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return nil
+		}
+		return res.GetKEYSRes().Keys
+	}
+
+	writer.Fire(&wire.Command{Cmd: "FLUSHDB"})
+	set("cart:1", "book")
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "MGET.WATCH", Args: []string{"cart:1", "cart:2", "cart:3"}}))
+	assert.Equal(t, []string{"book", "", ""}, receive())
+
+	// A change to any of the keys is notified.
+	set("cart:3", "pen")
+	assert.Equal(t, []string{"book", "", "pen"}, receive())
+	set("cart:2", "cap")
+	assert.Equal(t, []string{"book", "cap", "pen"}, receive())
+	res := writer.Fire(&wire.Command{Cmd: "DEL", Args: []string{"cart:1"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	assert.Equal(t, []string{"", "cap", "pen"}, receive())
+
+	// The changes to the other keys are not.
+	set("cart:4", "mug")
+	assert.Nil(t, receiveWatch(watcher, 200*time.Millisecond))
+}
+
+func TestMGETWATCHConcurrentChanges(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	watcher := getLocalWatchWire(uniqueClientID("mget-concurrent-watcher"))
+	defer watcher.Close()
+
+	keys := []string{"order:1", "order:2", "order:3", "order:4"}
+	writer.Fire(&wire.Command{Cmd: "FLUSHDB"})
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "MGET.WATCH", Args: keys}))
+	last := watchSeq(t, receiveWatch(watcher, time.Second))
+
+	// The keys of the fingerprint are changed concurrently, hence
+	// notified by different workers.
+	const changes = 50
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			client := getLocalConnection()
+			defer client.Close()
+			for i := 1; i <= changes; i++ {
+				client.Fire(&wire.Command{Cmd: "SET", Args: []string{key, strconv.Itoa(i)}})
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	// The notifications are numbered in the order of their states,
+	// the last one carrying the final values of the keys.
+	var values []string
+	for {
+		res := receiveWatch(watcher, time.Second)
+		if res == nil {
+			break
+		}
+		seq := watchSeq(t, res)
+		assert.Greater(t, seq, last)
+		last = seq
+		values = res.GetKEYSRes().Keys
+	}
+	final := strconv.Itoa(changes)
+	assert.Equal(t, []string{final, final, final, final}, values)
+}
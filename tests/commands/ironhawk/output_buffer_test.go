@@ -0,0 +1,68 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOutputBufferSlowClient(t *testing.T) {
+	defer func(threads, limit int) {
+		config.Config.NumIOThreads, config.Config.ClientOutputBufferLimitBytes = threads, limit
+	}(config.Config.NumIOThreads, config.Config.ClientOutputBufferLimitBytes)
+	config.Config.NumIOThreads = 1
+	config.Config.ClientOutputBufferLimitBytes = 4 * 1024 * 1024
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+	addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+
+	client, err := net.Dial("tcp", addr)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer client.Close()
+	res := fireConn(t, client, &wire.Command{Cmd: "SET", Args: []string{"big", strings.Repeat("x", 256*1024)}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	// A client pipelining commands without reading their results
+	// does not hold back the io-thread executing them.
+	slow, err := net.Dial("tcp", addr)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer slow.Close()
+	msg, err := proto.Marshal(&wire.Command{Cmd: "GET", Args: []string{"big"}})
+	assert.Nil(t, err)
+	var pipeline []byte
+	for i := 0; i < 128; i++ {
+		pipeline = binary.BigEndian.AppendUint32(pipeline, uint32(len(msg)))
+		pipeline = append(pipeline, msg...)
+	}
+	_, err = slow.Write(pipeline)
+	assert.Nil(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	res, err = roundTrip(client, &wire.Command{Cmd: "PING"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+
+	// The results it did not read exceed the limit, hence it is disconnected.
+	_ = slow.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.Copy(io.Discard, slow)
+	var netErr net.Error
+	assert.False(t, errors.As(err, &netErr) && netErr.Timeout(), "the slow client was not disconnected")
+}
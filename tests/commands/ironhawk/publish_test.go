@@ -0,0 +1,143 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func extractValuePUBLISH(res *wire.Result) interface{} {
+	return res.GetINCRRes().Value
+}
+
+func TestPUBLISH(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "SUBSCRIBE and UNSUBSCRIBE return the number of subscriptions",
+			commands:       []string{"SUBSCRIBE c1 c2", "PSUBSCRIBE p*", "UNSUBSCRIBE c1", "UNSUBSCRIBE", "PUNSUBSCRIBE"},
+			expected:       []interface{}{2, 3, 2, 1, 0},
+			valueExtractor: []ValueExtractorFn{extractValuePUBLISH, extractValuePUBLISH, extractValuePUBLISH, extractValuePUBLISH, extractValuePUBLISH},
+		},
+		{
+			name:           "PUBSUB CHANNELS and NUMSUB",
+			commands:       []string{"SUBSCRIBE news.a news.b sports", "PUBSUB CHANNELS", "PUBSUB CHANNELS news.*", "PUBSUB NUMSUB news.a weather", "UNSUBSCRIBE"},
+			expected:       []interface{}{3, []string{"news.a", "news.b", "sports"}, []string{"news.a", "news.b"}, []string{"news.a", "1", "weather", "0"}, 0},
+			valueExtractor: []ValueExtractorFn{extractValuePUBLISH, extractValueLRANGE, extractValueLRANGE, extractValueLRANGE, extractValuePUBLISH},
+		},
+		{
+			name:           "PUBLISH without a watch connection is not delivered",
+			commands:       []string{"SUBSCRIBE c3", "PUBLISH c3 hello", "UNSUBSCRIBE"},
+			expected:       []interface{}{1, 0, 0},
+			valueExtractor: []ValueExtractorFn{extractValuePUBLISH, extractValuePUBLISH, extractValuePUBLISH},
+		},
+		{
+			name:     "Pub/Sub commands with invalid arguments",
+			commands: []string{"PUBLISH c4", "SUBSCRIBE", "PUBSUB", "PUBSUB UNKNOWN", "MULTI", "SUBSCRIBE c4", "DISCARD"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'PUBLISH' command"),
+				errors.New("wrong number of arguments for 'SUBSCRIBE' command"),
+				errors.New("wrong number of arguments for 'PUBSUB' command"),
+				errors.New("invalid syntax for 'PUBSUB' command"),
+				"OK",
+				errors.New("SUBSCRIBE is not allowed in a transaction"),
+				"OK",
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil, nil, extractValueMULTI, nil, extractValueMULTI},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func receiveMessage(t *testing.T, ch <-chan *wire.Result) []string {
+	t.Helper()
+	select {
+	case res := <-ch:
+		return res.GetKEYSRes().Keys
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not delivered")
+		return nil
+	}
+}
+
+func TestPUBLISHDelivery(t *testing.T) {
+	publisher := getLocalConnection()
+	defer publisher.Close()
+	// The subscriber is not closed, as closing a client having a watch
+	// connection makes its watch goroutine close the channel twice.
+	subscriber := getLocalConnection()
+
+	ch, err := subscriber.WatchCh()
+	assert.Nil(t, err)
+
+	fire := func(args ...string) *wire.Result {
+		return subscriber.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}
+	publish := func(channel, message string) int64 {
+		return publisher.Fire(&wire.Command{Cmd: "PUBLISH", Args: []string{channel, message}}).GetINCRRes().Value
+	}
+
+	t.Run("messages are delivered on the watch connection", func(t *testing.T) {
+		assert.Equal(t, int64(1), fire("SUBSCRIBE", "chat").GetINCRRes().Value)
+		assert.Equal(t, int64(1), publish("chat", "hello"))
+		assert.Equal(t, []string{"message", "chat", "hello"}, receiveMessage(t, ch))
+		assert.Equal(t, int64(0), publish("other", "hello"))
+	})
+
+	t.Run("pattern subscriptions receive the matching channels", func(t *testing.T) {
+		assert.Equal(t, int64(2), fire("PSUBSCRIBE", "ch?t").GetINCRRes().Value)
+		assert.Equal(t, int64(2), publish("chat", "hi"))
+		assert.Equal(t, int64(1), publish("chit", "hey"))
+
+		// The messages of the channel and of the pattern are delivered in any order.
+		received := [][]string{receiveMessage(t, ch), receiveMessage(t, ch), receiveMessage(t, ch)}
+		assert.ElementsMatch(t, [][]string{
+			{"message", "chat", "hi"},
+			{"pmessage", "ch?t", "chat", "hi"},
+			{"pmessage", "ch?t", "chit", "hey"},
+		}, received)
+	})
+
+	t.Run("unsubscribed clients stop receiving messages", func(t *testing.T) {
+		assert.Equal(t, int64(1), fire("UNSUBSCRIBE", "chat").GetINCRRes().Value)
+		assert.Equal(t, int64(0), fire("PUNSUBSCRIBE").GetINCRRes().Value)
+		assert.Equal(t, int64(0), publish("chat", "bye"))
+	})
+}
+
+func TestPUBLISHNoOutputBufferLimit(t *testing.T) {
+	defer func(limit int) { config.Config.PubSubOutputBufferLimitBytes = limit }(config.Config.PubSubOutputBufferLimitBytes)
+	config.Config.PubSubOutputBufferLimitBytes = 0
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	publisher, err := dicedb.NewClient("localhost", port)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer publisher.Close()
+	subscriber, err := dicedb.NewClient("localhost", port)
+	if !assert.Nil(t, err) {
+		return
+	}
+	ch, err := subscriber.WatchCh()
+	assert.Nil(t, err)
+
+	// A limit of 0 does not disconnect the subscribers.
+	assert.Equal(t, int64(1), subscriber.Fire(&wire.Command{Cmd: "SUBSCRIBE", Args: []string{"chat"}}).GetINCRRes().Value)
+	for _, message := range []string{"hello", "world"} {
+		res := publisher.Fire(&wire.Command{Cmd: "PUBLISH", Args: []string{"chat", message}})
+		assert.Equal(t, int64(1), res.GetINCRRes().Value, res.Message)
+		assert.Equal(t, []string{"message", "chat", message}, receiveMessage(t, ch))
+	}
+}
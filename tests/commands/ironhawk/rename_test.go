@@ -0,0 +1,64 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueRENAME(res *wire.Result) interface{} {
+	return res.GetMessage()
+}
+
+func TestRENAME(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "RENAME existing key",
+			commands:       []string{"SET k1 v1", "RENAME k1 k2", "GET k1", "GET k2"},
+			expected:       []interface{}{"OK", "OK", "", "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueRENAME, extractValueGET, extractValueGET},
+		},
+		{
+			name:           "RENAME overwrites the new key",
+			commands:       []string{"DEL h1", "SET k1 v1", "HSET h1 f v", "RENAME k1 h1", "GET h1"},
+			expected:       []interface{}{0, "OK", int64(1), "OK", "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueSET, extractValueHSET, extractValueRENAME, extractValueGET},
+		},
+		{
+			name:           "RENAME keeps the expiry",
+			commands:       []string{"SET k1 v1 PX 99500", "RENAME k1 k3", "TTL k3"},
+			expected:       []interface{}{"OK", "OK", int64(99)},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueRENAME, extractValueTTL},
+		},
+		{
+			name:           "RENAME sorted set across keys",
+			commands:       []string{"ZADD z1 1 a 2 b", "RENAME z1 z2", "ZCARD z1", "ZCARD z2"},
+			expected:       []interface{}{int64(2), "OK", int64(0), int64(2)},
+			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueRENAME, extractValueZCARD, extractValueZCARD},
+		},
+		{
+			name:     "RENAME non-existent key",
+			commands: []string{"RENAME nokey k4"},
+			expected: []interface{}{
+				errors.New("no such key"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "RENAME with wrong number of arguments",
+			commands: []string{"RENAME k1"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'RENAME' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueRENAMENX(res *wire.Result) interface{} {
+	return res.GetEXPIRERes().IsChanged
+}
+
+func TestRENAMENX(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "RENAMENX to a new key",
+			commands:       []string{"DEL k2", "SET k1 v1", "RENAMENX k1 k2", "GET k2"},
+			expected:       []interface{}{0, "OK", true, "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueSET, extractValueRENAMENX, extractValueGET},
+		},
+		{
+			name:           "RENAMENX to an existing key",
+			commands:       []string{"SET k1 v1", "SET k2 v2", "RENAMENX k1 k2", "GET k1", "GET k2"},
+			expected:       []interface{}{"OK", "OK", false, "v1", "v2"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, extractValueRENAMENX, extractValueGET, extractValueGET},
+		},
+		{
+			name:     "RENAMENX non-existent key",
+			commands: []string{"RENAMENX nokey k3"},
+			expected: []interface{}{
+				errors.New("no such key"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
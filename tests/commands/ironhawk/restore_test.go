@@ -0,0 +1,82 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+)
+
+func extractValueRESTORE(res *wire.Result) interface{} {
+	return res.GetMessage()
+}
+
+func TestRESTORE(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "RESTORE string value",
+			commands:       []string{"DEL k1", "RESTORE k1 0 CgAAAAACdjHNvlLwmJpbMg==", "GET k1", "TTL k1"},
+			expected:       []interface{}{0, "OK", "v1", int64(-1)},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueRESTORE, extractValueGET, extractValueTTL},
+		},
+		{
+			name:           "RESTORE sorted set value with ttl",
+			commands:       []string{"DEL k2", "RESTORE k2 99500 CggAAAAAAAAAAgAAAAFhAAAAAAAAAAEAAAABYgAAAAAAAAACyu7hAVV7U+M=", "ZCARD k2", "TTL k2"},
+			expected:       []interface{}{0, "OK", int64(2), int64(99)},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueRESTORE, extractValueZCARD, extractValueTTL},
+		},
+		{
+			name:           "RESTORE hash value with absolute ttl in the past",
+			commands:       []string{"DEL k3", "RESTORE k3 1000 CgcAAAAAAAAAAQAAAAFmAAAAAXZh6Ifmuxhg0w== ABSTTL", "HGET k3 f"},
+			expected:       []interface{}{0, "OK", ""},
+			valueExtractor: []ValueExtractorFn{extractValueDEL, extractValueRESTORE, extractValueHGET},
+		},
+		{
+			name:     "RESTORE existing key without REPLACE",
+			commands: []string{"SET k4 v4", "RESTORE k4 0 CgAAAAACdjHNvlLwmJpbMg==", "GET k4"},
+			expected: []interface{}{
+				"OK",
+				errors.New("key exists"),
+				"v4",
+			},
+			valueExtractor: []ValueExtractorFn{extractValueSET, nil, extractValueGET},
+		},
+		{
+			name:           "RESTORE existing key with REPLACE",
+			commands:       []string{"SET k4 v4", "RESTORE k4 0 CgAAAAACdjHNvlLwmJpbMg== REPLACE", "GET k4"},
+			expected:       []interface{}{"OK", "OK", "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueRESTORE, extractValueGET},
+		},
+		{
+			name:     "RESTORE with invalid checksum",
+			commands: []string{"RESTORE k5 0 CgAAAAACdjHNvlLwmJpbMw=="},
+			expected: []interface{}{
+				errors.New("payload version or checksum are wrong"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "RESTORE with invalid ttl",
+			commands: []string{"RESTORE k5 -1 CgAAAAACdjHNvlLwmJpbMg=="},
+			expected: []interface{}{
+				errors.New("invalid expire time in 'RESTORE' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+		{
+			name:     "RESTORE with wrong number of arguments",
+			commands: []string{"RESTORE k5 0"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'RESTORE' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
@@ -139,6 +139,16 @@ func TestSET(t *testing.T) {
 				extractValueSET, extractValueSET, extractValueGET,
 				nil, nil, nil},
 		},
+		{
+			name:     "SET with IFEQ",
+			commands: []string{"SET k 43", "SET k 44 IFEQ 43", "SET k 45 IFEQ 43", "GET k", "SET knew v IFEQ v", "SET k 46 IFEQ"},
+			expected: []interface{}{"OK", "OK",
+				errors.New("precondition failed"), "44",
+				errors.New("precondition failed"),
+				errors.New("invalid syntax for 'SET' command"),
+			},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, nil, extractValueGET, nil, nil},
+		},
 		{
 			name:     "SET with no keys or arguments",
 			commands: []string{"SET"},
@@ -11,8 +11,10 @@ import (
 	"net"
 	"os"
 	"sync"
+	"testing"
 	"time"
 
+	"github.com/dicedb/dice/internal/cmd"
 	"github.com/dicedb/dice/internal/server/ironhawk"
 	"github.com/dicedb/dice/internal/shardmanager"
 	"github.com/dicedb/dice/internal/wal"
@@ -20,6 +22,7 @@ import (
 	"github.com/dicedb/dice/config"
 	derrors "github.com/dicedb/dice/internal/errors"
 	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
 )
 
 //nolint:unused
@@ -31,6 +34,22 @@ func getLocalConnection() *dicedb.Client {
 	return client
 }
 
+// getLocalWatchWire returns a connection in the watch mode for the client id,
+// on which the .WATCH commands are sent and their notifications received.
+func getLocalWatchWire(clientID string) *dicedb.ClientWire {
+	w, err := dicedb.NewClientWire(32*1024*1024, "localhost", config.Config.Port)
+	if err != nil {
+		panic(err)
+	}
+	if err := w.Send(&wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, "watch"}}); err != nil {
+		panic(err)
+	}
+	if _, err := w.Receive(); err != nil {
+		panic(err)
+	}
+	return w
+}
+
 func ClosePublisherSubscribers(publisher net.Conn, subscribers []net.Conn) error {
 	if err := publisher.Close(); err != nil {
 		return fmt.Errorf("error closing publisher connection: %v", err)
@@ -111,7 +130,7 @@ func RunTestServer(wg *sync.WaitGroup) {
 	gec := make(chan error)
 	shardManager := shardmanager.NewShardManager(1, gec)
 	ioThreadManager := ironhawk.NewIOThreadManager()
-	watchManager := &ironhawk.WatchManager{}
+	watchManager := ironhawk.NewWatchManager()
 	wal.SetupWAL()
 
 	testServer := ironhawk.NewServer(shardManager, ioThreadManager, watchManager)
@@ -150,3 +169,69 @@ func RunTestServer(wg *sync.WaitGroup) {
 		}
 	}()
 }
+
+// runOwnServer runs a server of its own on the next port, configured as the
+// test requires, so that shutting it down leaves the test server running.
+// It returns the function that shuts it down and waits for Run to return.
+func runOwnServer(t *testing.T) (port int, shutdown func() <-chan error) {
+	t.Helper()
+	_, port, shutdown = startOwnServer(t)
+	return port, shutdown
+}
+
+// startOwnServer is runOwnServer, also returning the server.
+func startOwnServer(t *testing.T) (srv *ironhawk.Server, port int, shutdown func() <-chan error) {
+	t.Helper()
+	port = config.Config.Port + 1
+	defer func(p int) { config.Config.Port = p }(config.Config.Port)
+	config.Config.Port = port
+
+	shardManager := shardmanager.NewShardManager(1, make(chan error, 1))
+	srv = ironhawk.NewServer(shardManager, ironhawk.NewIOThreadManager(), ironhawk.NewWatchManager())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Run(ctx)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	return srv, port, func() <-chan error {
+		cancel()
+		return errCh
+	}
+}
+
+// setupOwnWAL logs the commands executed by the servers of the test to a WAL
+// of its own. It returns the function that stops the WAL and replays it into
+// a new shard manager, as the server does on restart.
+func setupOwnWAL(t *testing.T) (replay func() *shardmanager.ShardManager) {
+	t.Helper()
+	dir := config.Config.WALDir
+	config.Config.WALDir = t.TempDir()
+	wal.SetupWAL()
+	t.Cleanup(func() {
+		config.Config.WALDir = dir
+		if wal.DefaultWAL != nil {
+			wal.DefaultWAL.Stop()
+			wal.DefaultWAL = nil
+		}
+	})
+
+	return func() *shardmanager.ShardManager {
+		t.Helper()
+		wl := wal.DefaultWAL
+		wl.Stop()
+		wal.DefaultWAL = nil
+
+		shardManager := shardmanager.NewShardManager(1, make(chan error, 1))
+		err := wl.ReplayCommand(func(c *wire.Command) error {
+			_, err := (&cmd.Cmd{C: c, IsReplay: true}).Execute(shardManager)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return shardManager
+	}
+}
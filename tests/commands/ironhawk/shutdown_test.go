@@ -0,0 +1,74 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownNotifiesWatchers(t *testing.T) {
+	port, shutdown := runOwnServer(t)
+
+	watcher, err := dicedb.NewClientWire(32*1024*1024, "localhost", port)
+	assert.Nil(t, err)
+	defer watcher.Close()
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "HANDSHAKE", Args: []string{uniqueClientID("shutdown-watcher"), "watch"}}))
+	_, err = watcher.Receive()
+	assert.Nil(t, err)
+
+	errCh := shutdown()
+	res := receiveWatch(watcher, time.Second)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, wire.Status_ERR, res.Status)
+		assert.Equal(t, "server is shutting down", res.Message)
+	}
+
+	select {
+	case err := <-errCh:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestShutdownCutsOffBlockedCommands(t *testing.T) {
+	defer func(ms int) { config.Config.ShutdownTimeoutMs = ms }(config.Config.ShutdownTimeoutMs)
+	config.Config.ShutdownTimeoutMs = 200
+	port, shutdown := runOwnServer(t)
+
+	client, err := dicedb.NewClient("localhost", port)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	// The in-flight BLPOP waits until the shutdown timeout, and is then
+	// responded to as if it timed out.
+	resCh := make(chan *wire.Result, 1)
+	go func() {
+		resCh <- client.Fire(&wire.Command{Cmd: "BLPOP", Args: []string{"shutdown-list", "0"}})
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	errCh := shutdown()
+	select {
+	case err := <-errCh:
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+
+	select {
+	case res := <-resCh:
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	case <-time.After(time.Second):
+		t.Fatal("BLPOP was not responded to")
+	}
+}
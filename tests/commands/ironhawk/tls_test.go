@@ -0,0 +1,303 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCert is a certificate generated for a test, along with its key.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCert returns a certificate with the serial number, signed by the
+// CA ca, or self-signed and able to sign the others if ca is nil. The server
+// certificates are valid for localhost.
+func newTestCert(t *testing.T, ca *testCert, serial int64, server bool) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "dicedb-test-" + strconv.FormatInt(serial, 10)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		tmpl.DNSNames = []string{"localhost"}
+		tmpl.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	parent, signer := tmpl, key
+	if ca == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		tmpl.ExtKeyUsage = nil
+	} else {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write writes the certificate and its key to certFile and keyFile, in PEM.
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if keyFile == "" {
+		return
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+// tlsFiles holds the paths of the certificate files of a test server.
+type tlsFiles struct {
+	cert, key, ca string
+}
+
+// setupTLS generates a CA and a server certificate it signs, and configures
+// the server started next to accept TLS connections with them on the port
+// after the one of runOwnServer. The configuration is restored once the
+// test completes.
+func setupTLS(t *testing.T, authClients string) (ca *testCert, files tlsFiles, tlsPort int) {
+	t.Helper()
+	dir := t.TempDir()
+	files = tlsFiles{
+		cert: filepath.Join(dir, "server.crt"),
+		key:  filepath.Join(dir, "server.key"),
+		ca:   filepath.Join(dir, "ca.crt"),
+	}
+	ca = newTestCert(t, nil, 1, false)
+	ca.write(t, files.ca, "")
+	newTestCert(t, ca, 2, true).write(t, files.cert, files.key)
+
+	prev := *config.Config
+	t.Cleanup(func() {
+		config.Config.TLSPort, config.Config.TLSCertFile, config.Config.TLSKeyFile = prev.TLSPort, prev.TLSCertFile, prev.TLSKeyFile
+		config.Config.TLSCACertFile, config.Config.TLSAuthClients = prev.TLSCACertFile, prev.TLSAuthClients
+	})
+	tlsPort = config.Config.Port + 2
+	config.Config.TLSPort = tlsPort
+	config.Config.TLSCertFile, config.Config.TLSKeyFile = files.cert, files.key
+	config.Config.TLSCACertFile, config.Config.TLSAuthClients = files.ca, authClients
+	return ca, files, tlsPort
+}
+
+// dialTLS returns a TLS connection to the port, trusting the certificates
+// signed by ca, and presenting the client certificates, if any.
+func dialTLS(ca *testCert, port int, certs ...tls.Certificate) (*tls.Conn, error) {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	return tls.Dial("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)), &tls.Config{
+		RootCAs:      roots,
+		Certificates: certs,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
+
+func TestTLS(t *testing.T) {
+	ca, _, tlsPort := setupTLS(t, "no")
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	conn, err := dialTLS(ca, tlsPort)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	res := fireConn(t, conn, &wire.Command{Cmd: "HANDSHAKE", Args: []string{uniqueClientID("tls-client"), "command"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	res = fireConn(t, conn, &wire.Command{Cmd: "SET", Args: []string{"tls-key", "v"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	res = fireConn(t, conn, &wire.Command{Cmd: "GET", Args: []string{"tls-key"}})
+	assert.Equal(t, "v", res.GetGETRes().Value)
+
+	// The plaintext port does not speak TLS, and the TLS port does not
+	// accept plaintext commands.
+	_, err = dialTLS(ca, port)
+	assert.NotNil(t, err)
+	plain, err := net.Dial("tcp", net.JoinHostPort("localhost", strconv.Itoa(tlsPort)))
+	if assert.Nil(t, err) {
+		_, err = roundTrip(plain, &wire.Command{Cmd: "GET", Args: []string{"tls-key"}})
+		assert.NotNil(t, err)
+		plain.Close()
+	}
+}
+
+func TestTLSAuthClients(t *testing.T) {
+	ca, _, tlsPort := setupTLS(t, "yes")
+	_, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	// The client certificate is verified once the client sends its
+	// first command, with TLS 1.3.
+	conn, err := dialTLS(ca, tlsPort)
+	if err == nil {
+		_, err = roundTrip(conn, &wire.Command{Cmd: "PING"})
+		conn.Close()
+	}
+	assert.NotNil(t, err)
+
+	// A certificate the CA did not sign is refused as well.
+	conn, err = dialTLS(ca, tlsPort, newTestCert(t, nil, 3, false).tlsCertificate())
+	if err == nil {
+		_, err = roundTrip(conn, &wire.Command{Cmd: "PING"})
+		conn.Close()
+	}
+	assert.NotNil(t, err)
+
+	conn, err = dialTLS(ca, tlsPort, newTestCert(t, ca, 4, false).tlsCertificate())
+	if assert.Nil(t, err) {
+		res := fireConn(t, conn, &wire.Command{Cmd: "PING"})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		conn.Close()
+	}
+}
+
+func TestTLSMaxClients(t *testing.T) {
+	defer func(n int) { config.Config.MaxClients = n }(config.Config.MaxClients)
+	config.Config.MaxClients = 1
+	ca, _, tlsPort := setupTLS(t, "no")
+	_, shutdown := runOwnServer(t)
+	addr := net.JoinHostPort("localhost", strconv.Itoa(tlsPort))
+
+	first, err := dialTLS(ca, tlsPort)
+	if !assert.Nil(t, err) {
+		<-shutdown()
+		return
+	}
+	defer first.Close()
+	res := fireConn(t, first, &wire.Command{Cmd: "PING"})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	// The connection over the limit is responded to with an error.
+	conn, err := dialTLS(ca, tlsPort)
+	if assert.Nil(t, err) {
+		res, err := roundTrip(conn, &wire.Command{Cmd: "PING"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "maximum number of clients reached", res.Message)
+		}
+		conn.Close()
+	}
+
+	// The clients stalling their handshake hold the rejections back,
+	// hence the connections beyond them are closed right away.
+	for i := 0; i < 64; i++ {
+		stalled, err := net.Dial("tcp", addr)
+		if assert.Nil(t, err) {
+			defer stalled.Close()
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	conn, err = dialTLS(ca, tlsPort)
+	if err == nil {
+		_, err = roundTrip(conn, &wire.Command{Cmd: "PING"})
+		conn.Close()
+	}
+	assert.NotNil(t, err)
+
+	// Nor do they hold back the shutdown.
+	start := time.Now()
+	<-shutdown()
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTLSCertificateReload(t *testing.T) {
+	ca, files, tlsPort := setupTLS(t, "no")
+	srv, _, shutdown := startOwnServer(t)
+	defer func() { <-shutdown() }()
+
+	serial := func(conn *tls.Conn) int64 {
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	before, err := dialTLS(ca, tlsPort)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer before.Close()
+	assert.Equal(t, int64(2), serial(before))
+
+	// The new connections get the renewed certificate, while the
+	// established ones are left as is.
+	newTestCert(t, ca, 5, true).write(t, files.cert, files.key)
+	assert.Nil(t, srv.ReloadTLS())
+	after, err := dialTLS(ca, tlsPort)
+	if assert.Nil(t, err) {
+		assert.Equal(t, int64(5), serial(after))
+		after.Close()
+	}
+	res := fireConn(t, before, &wire.Command{Cmd: "PING"})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+	// A reload failing leaves the previous certificate in use.
+	assert.Nil(t, os.WriteFile(files.key, []byte("not a key"), 0o600))
+	assert.NotNil(t, srv.ReloadTLS())
+	after, err = dialTLS(ca, tlsPort)
+	if assert.Nil(t, err) {
+		assert.Equal(t, int64(5), serial(after))
+		after.Close()
+	}
+}
+
+func TestTLSInvalidConfig(t *testing.T) {
+	setupTLS(t, "no")
+	config.Config.TLSKeyFile = ""
+	_, shutdown := runOwnServer(t)
+	err := <-shutdown()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "tls-cert-file and tls-key-file are required to accept TLS connections", err.Error())
+	}
+
+	setupTLS(t, "always")
+	_, shutdown = runOwnServer(t)
+	err = <-shutdown()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid tls-auth-clients 'always', must be yes, optional or no", err.Error())
+	}
+}
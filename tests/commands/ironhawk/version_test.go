@@ -0,0 +1,153 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func extractValueVERSION(res *wire.Result) interface{} {
+	return res.GetINCRRes().Value
+}
+
+func TestVERSION(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:           "VERSION of a non-existent key",
+			commands:       []string{"VERSION nokey"},
+			expected:       []interface{}{0},
+			valueExtractor: []ValueExtractorFn{extractValueVERSION},
+		},
+		{
+			name:           "IFVERSION 0 only creates the key",
+			commands:       []string{"SET k1 v1 IFVERSION 0", "SET k1 v2 IFVERSION 0", "GET k1"},
+			expected:       []interface{}{"OK", errors.New("precondition failed"), "v1"},
+			valueExtractor: []ValueExtractorFn{extractValueSET, nil, extractValueGET},
+		},
+		{
+			name:     "VERSION with wrong number of arguments",
+			commands: []string{"VERSION", "VERSION k1 k2"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'VERSION' command"),
+				errors.New("wrong number of arguments for 'VERSION' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil},
+		},
+		{
+			name:     "IFVERSION with an invalid version",
+			commands: []string{"SET k1 v1 IFVERSION abc"},
+			expected: []interface{}{
+				errors.New("invalid value for a parameter in 'SET' command for IFVERSION parameter"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestVERSIONConditionalWrites(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	fire := func(args ...string) *wire.Result {
+		return client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}
+	version := func(key string) string {
+		return strconv.FormatInt(fire("VERSION", key).GetINCRRes().Value, 10)
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		setup [][]string
+		write func(v string) []string
+		// mutate modifies the key so that the version read before it is stale
+		mutate []string
+	}{
+		{
+			name:   "SET",
+			key:    "vs",
+			setup:  [][]string{{"SET", "vs", "1"}},
+			write:  func(v string) []string { return []string{"SET", "vs", "3", "IFVERSION", v} },
+			mutate: []string{"INCR", "vs"},
+		},
+		{
+			name:   "HSET",
+			key:    "vh",
+			setup:  [][]string{{"HSET", "vh", "f1", "v1"}},
+			write:  func(v string) []string { return []string{"HSET", "vh", "IFVERSION", v, "f1", "v3"} },
+			mutate: []string{"HSET", "vh", "f1", "v2"},
+		},
+		{
+			name:   "ZADD",
+			key:    "vz",
+			setup:  [][]string{{"ZADD", "vz", "1", "m1"}},
+			write:  func(v string) []string { return []string{"ZADD", "vz", "IFVERSION", v, "3", "m1"} },
+			mutate: []string{"ZADD", "vz", "2", "m1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fire("DEL", tc.key)
+			for _, args := range tc.setup {
+				assert.Equal(t, wire.Status_OK, fire(args...).Status)
+			}
+
+			v1 := version(tc.key)
+			assert.NotEqual(t, "0", v1)
+			assert.Equal(t, wire.Status_OK, fire(tc.mutate...).Status)
+
+			v2 := version(tc.key)
+			assert.NotEqual(t, v1, v2)
+
+			res := fire(tc.write(v1)...)
+			assert.Equal(t, wire.Status_ERR, res.Status)
+			assert.Equal(t, "precondition failed", res.Message)
+			assert.Equal(t, v2, version(tc.key))
+
+			res = fire(tc.write(v2)...)
+			assert.Equal(t, wire.Status_OK, res.Status)
+			assert.NotEqual(t, v2, version(tc.key))
+		})
+	}
+}
+
+func TestVERSIONConcurrentWrites(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	client.Fire(&wire.Command{Cmd: "SET", Args: []string{"vc", "0"}})
+	v := strconv.FormatInt(client.Fire(&wire.Command{Cmd: "VERSION", Args: []string{"vc"}}).GetINCRRes().Value, 10)
+
+	// Out of the writes conditioned on the same version, only one succeeds.
+	const writers = 16
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := getLocalConnection()
+			defer c.Close()
+			res := c.Fire(&wire.Command{Cmd: "SET", Args: []string{"vc", strconv.Itoa(i), "IFVERSION", v}})
+			if res.Status == wire.Status_OK {
+				succeeded.Add(1)
+			} else {
+				assert.Equal(t, "precondition failed", res.Message)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), succeeded.Load())
+}
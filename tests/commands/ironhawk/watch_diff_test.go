@@ -0,0 +1,109 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWATCHDiff(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+
+	t.Run("ZRANGE.WATCH sends the changed members", func(t *testing.T) {
+		watcher := getLocalWatchWire(uniqueClientID("diff-watcher"))
+		defer watcher.Close()
+		key := uniqueClientID("board")
+
+		writer.Fire(&wire.Command{Cmd: "ZADD", Args: []string{key, "10", "alice", "20", "bob"}})
+		assert.Nil(t, watcher.Send(&wire.Command{Cmd: "ZRANGE.WATCH", Args: []string{key, "0", "10", "DIFF"}}))
+		res := receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		assert.Equal(t, 2, len(res.GetZRANGERes().Elements))
+
+		writer.Fire(&wire.Command{Cmd: "ZADD", Args: []string{key, "30", "charlie", "25", "alice"}})
+		res = receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		assert.True(t, strings.HasSuffix(res.Message, " diff"), res.Message)
+		if assert.NotNil(t, res.GetKEYSRes(), res.String()) {
+			assert.Equal(t, []string{"+", "alice", "25", "+", "charlie", "30"}, res.GetKEYSRes().Keys)
+		}
+
+		writer.Fire(&wire.Command{Cmd: "ZREM", Args: []string{key, "bob"}})
+		res = receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		if assert.NotNil(t, res.GetKEYSRes(), res.String()) {
+			assert.Equal(t, []string{"-", "bob", ""}, res.GetKEYSRes().Keys)
+		}
+	})
+
+	t.Run("HGETALL.WATCH sends the changed fields", func(t *testing.T) {
+		watcher := getLocalWatchWire(uniqueClientID("diff-watcher"))
+		defer watcher.Close()
+		key := uniqueClientID("profile")
+
+		writer.Fire(&wire.Command{Cmd: "HSET", Args: []string{key, "name", "alice"}})
+		assert.Nil(t, watcher.Send(&wire.Command{Cmd: "HGETALL.WATCH", Args: []string{key, "DIFF"}}))
+		res := receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		assert.Equal(t, 1, len(res.GetHGETALLRes().Elements))
+
+		writer.Fire(&wire.Command{Cmd: "HSET", Args: []string{key, "city", "paris"}})
+		res = receiveWatch(watcher, time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		assert.True(t, strings.HasSuffix(res.Message, " diff"), res.Message)
+		if assert.NotNil(t, res.GetKEYSRes(), res.String()) {
+			assert.Equal(t, []string{"+", "city", "paris"}, res.GetKEYSRes().Keys)
+		}
+	})
+
+	t.Run("invalid DIFF options", func(t *testing.T) {
+		res := writer.Fire(&wire.Command{Cmd: "GET.WATCH", Args: []string{"k", "DIFF"}})
+		assert.Equal(t, wire.Status_ERR, res.Status)
+		assert.Equal(t, "DIFF is not supported by GET.WATCH", res.Message)
+
+		res = writer.Fire(&wire.Command{Cmd: "ZRANGE.WATCH", Args: []string{"k", "0", "1", "DIFF", "THROTTLE", "100"}})
+		assert.Equal(t, wire.Status_ERR, res.Status)
+		assert.Equal(t, "DIFF cannot be combined with THROTTLE", res.Message)
+	})
+
+	t.Run("the last result counts toward the index memory", func(t *testing.T) {
+		watcher := getLocalWatchWire(uniqueClientID("diff-watcher"))
+		defer watcher.Close()
+		key := uniqueClientID("blob")
+
+		writer.Fire(&wire.Command{Cmd: "HSET", Args: []string{key, "data", strings.Repeat("x", 64*1024)}})
+		before := watchStat(t, writer, "index_memory_bytes")
+		assert.Nil(t, watcher.Send(&wire.Command{Cmd: "HGETALL.WATCH", Args: []string{key, "DIFF"}}))
+		assert.NotNil(t, receiveWatch(watcher, time.Second))
+		assert.GreaterOrEqual(t, watchStat(t, writer, "index_memory_bytes")-before, int64(60*1024))
+	})
+
+	t.Run("a key named DIFF", func(t *testing.T) {
+		writer.Fire(&wire.Command{Cmd: "DEL", Args: []string{"DIFF"}})
+		res := writer.Fire(&wire.Command{Cmd: "HGETALL.WATCH", Args: []string{"DIFF"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+
+		res = writer.Fire(&wire.Command{Cmd: "SET", Args: []string{"DIFF", "v"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		res = writer.Fire(&wire.Command{Cmd: "GET.WATCH", Args: []string{"DIFF"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		assert.Equal(t, "v", res.GetGETRes().Value)
+	})
+}
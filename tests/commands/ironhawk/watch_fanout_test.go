@@ -0,0 +1,61 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWATCHSlowWatcher(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	watcher := getLocalWatchWire(uniqueClientID("slow-watcher"))
+	defer watcher.Close()
+
+	writer.Fire(&wire.Command{Cmd: "FLUSHDB"})
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "GET.WATCH", Args: []string{"blob"}}))
+	seq := watchSeq(t, receiveWatch(watcher, time.Second))
+
+	// The watcher does not read its notifications while the values are
+	// written, which would fill up its socket if they were sent by the writer.
+	const n = 64
+	value := func(i int) string {
+		return fmt.Sprintf("%03d", i) + strings.Repeat("x", 256*1024)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= n; i++ {
+			res := writer.Fire(&wire.Command{Cmd: "SET", Args: []string{"blob", value(i)}})
+			assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the writer was held back by the watcher")
+	}
+
+	// The notifications of the key are received in the order of its updates,
+	// each carrying the state of the key once it has been updated, hence
+	// possibly the value of a later update.
+	last := ""
+	for i := 1; i <= n; i++ {
+		res := receiveWatch(watcher, 5*time.Second)
+		if !assert.NotNil(t, res) {
+			return
+		}
+		assert.Equal(t, seq+uint64(i), watchSeq(t, res))
+		v := res.GetGETRes().Value[:3]
+		assert.GreaterOrEqual(t, v, last)
+		last = v
+	}
+	assert.Equal(t, fmt.Sprintf("%03d", n), last)
+}
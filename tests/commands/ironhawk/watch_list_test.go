@@ -0,0 +1,95 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// watchList returns the number of clients subscribed to each fingerprint
+// listed by WATCH.LIST, by command.
+func watchList(t *testing.T, client *dicedb.Client) map[string]int {
+	t.Helper()
+	res := client.Fire(&wire.Command{Cmd: "WATCH.LIST"})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	list := res.GetKEYSRes().Keys
+	assert.Equal(t, 0, len(list)%3)
+
+	subscriptions := map[string]int{}
+	for i := 0; i+2 < len(list); i += 3 {
+		n, err := strconv.Atoi(list[i+2])
+		assert.Nil(t, err)
+		subscriptions[list[i+1]] = n
+	}
+	return subscriptions
+}
+
+func TestWATCHLIST(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "WATCH.LIST with arguments",
+			commands: []string{"WATCH.LIST k"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'WATCH.LIST' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestWATCHLISTSubscriptions(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	first := getLocalWatchWire(uniqueClientID("listed-watcher"))
+	defer first.Close()
+	second := getLocalWatchWire(uniqueClientID("listed-watcher"))
+	defer second.Close()
+
+	key := uniqueClientID("listed")
+	watch := &wire.Command{Cmd: "GET.WATCH", Args: []string{key}}
+	for _, w := range []*dicedb.ClientWire{first, second} {
+		assert.Nil(t, w.Send(watch))
+		assert.NotNil(t, receiveWatch(w, time.Second))
+	}
+	res := client.Fire(&wire.Command{Cmd: "KEYS.WATCH", Args: []string{key + ":*"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	fp := res.Fingerprint64
+
+	subscriptions := watchList(t, client)
+	assert.Equal(t, 2, subscriptions["GET.WATCH "+key])
+	assert.Equal(t, 1, subscriptions["KEYS.WATCH "+key+":*"])
+
+	t.Run("unwatched subscriptions are removed", func(t *testing.T) {
+		res := client.Fire(&wire.Command{Cmd: "UNWATCH", Args: []string{strconv.FormatUint(fp, 10)}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		for _, w := range []*dicedb.ClientWire{first, second} {
+			fp := watchFingerprint(t, client, key)
+			assert.Nil(t, w.Send(&wire.Command{Cmd: "UNWATCH", Args: []string{strconv.FormatUint(fp, 10)}}))
+			assert.NotNil(t, receiveWatch(w, time.Second))
+		}
+
+		subscriptions := watchList(t, client)
+		assert.NotContains(t, subscriptions, "GET.WATCH "+key)
+		assert.NotContains(t, subscriptions, "KEYS.WATCH "+key+":*")
+	})
+}
+
+// watchFingerprint returns the fingerprint of GET.WATCH on the key.
+func watchFingerprint(t *testing.T, client *dicedb.Client, key string) uint64 {
+	t.Helper()
+	res := client.Fire(&wire.Command{Cmd: "GET", Args: []string{key}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	return res.Fingerprint64
+}
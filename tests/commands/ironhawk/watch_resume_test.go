@@ -0,0 +1,116 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dice/config"
+	"github.com/dicedb/dice/internal/server/ironhawk"
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// uniqueClientID returns a client id not used by the previous test runs,
+// whose subscriptions may still be kept by the server.
+func uniqueClientID(prefix string) string {
+	return prefix + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// watchSeq returns the sequence number carried by the watch notification.
+func watchSeq(t *testing.T, res *wire.Result) uint64 {
+	t.Helper()
+	if !assert.NotNil(t, res) {
+		return 0
+	}
+	// The notification message is left as is, the sequence number
+	// being carried in a field of its own.
+	assert.NotContains(t, res.Message, "seq=")
+	seq, ok := ironhawk.WatchSeq(res)
+	assert.True(t, ok, "the notification carries no sequence number")
+	return seq
+}
+
+func TestWATCHResume(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	clientID := uniqueClientID("resuming-watcher")
+
+	incr := func() {
+		res := writer.Fire(&wire.Command{Cmd: "INCR", Args: []string{"visits"}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	}
+	writer.Fire(&wire.Command{Cmd: "FLUSHDB"})
+
+	watcher := getLocalWatchWire(clientID)
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "GET.WATCH", Args: []string{"visits"}}))
+	res := receiveWatch(watcher, time.Second)
+	seq := watchSeq(t, res)
+	assert.Equal(t, "", res.GetGETRes().Value)
+
+	incr()
+	res = receiveWatch(watcher, time.Second)
+	assert.Equal(t, seq+1, watchSeq(t, res))
+	assert.Equal(t, "1", res.GetGETRes().Value)
+
+	// The updates made while the client is disconnected are not sent, and
+	// the latest state is sent once it reconnects with the same client id.
+	watcher.Close()
+	time.Sleep(100 * time.Millisecond)
+	incr()
+	incr()
+
+	watcher = getLocalWatchWire(clientID)
+	defer watcher.Close()
+	res = receiveWatch(watcher, time.Second)
+	assert.Equal(t, seq+3, watchSeq(t, res))
+	assert.Equal(t, "3", res.GetGETRes().Value)
+
+	incr()
+	res = receiveWatch(watcher, time.Second)
+	assert.Equal(t, seq+4, watchSeq(t, res))
+	assert.Equal(t, "4", res.GetGETRes().Value)
+}
+
+func TestWATCHWithoutResumeGrace(t *testing.T) {
+	defer func(ms int) { config.Config.WatchResumeGraceMs = ms }(config.Config.WatchResumeGraceMs)
+	config.Config.WatchResumeGraceMs = 0
+	port, shutdown := runOwnServer(t)
+	defer func() { <-shutdown() }()
+	clientID := uniqueClientID("graceless-watcher")
+
+	handshake := func(mode string) *dicedb.ClientWire {
+		w, err := dicedb.NewClientWire(32*1024*1024, "localhost", port)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := fireWire(t, w, &wire.Command{Cmd: "HANDSHAKE", Args: []string{clientID, mode}})
+		assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+		return w
+	}
+	writer, err := dicedb.NewClient("localhost", port)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer writer.Close()
+
+	watcher := handshake("watch")
+	defer watcher.Close()
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "GET.WATCH", Args: []string{"visits"}}))
+	assert.NotNil(t, receiveWatch(watcher, time.Second))
+
+	// Closing the command connection of the client leaves the
+	// subscriptions of its watch connection as they are.
+	handshake("command").Close()
+	time.Sleep(100 * time.Millisecond)
+	res := writer.Fire(&wire.Command{Cmd: "INCR", Args: []string{"visits"}})
+	assert.Equal(t, wire.Status_OK, res.Status, res.Message)
+	res = receiveWatch(watcher, time.Second)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "1", res.GetGETRes().Value)
+	}
+}
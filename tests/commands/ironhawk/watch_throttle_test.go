@@ -0,0 +1,106 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dicedb/dicedb-go"
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveWatch returns the next result received on the watch connection,
+// or nil if none is received within the timeout.
+func receiveWatch(w *dicedb.ClientWire, timeout time.Duration) *wire.Result {
+	ch := make(chan *wire.Result, 1)
+	go func() {
+		res, err := w.Receive()
+		if err != nil {
+			res = nil
+		}
+		ch <- res
+	}()
+	select {
+	case res := <-ch:
+		return res
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+// watchStat returns the value of the statistic reported by WATCH.STATS.
+func watchStat(t *testing.T, client *dicedb.Client, name string) int64 {
+	t.Helper()
+	stats := client.Fire(&wire.Command{Cmd: "WATCH.STATS"}).GetKEYSRes().Keys
+	for i := 0; i+1 < len(stats); i += 2 {
+		if stats[i] == name {
+			v, err := strconv.ParseInt(stats[i+1], 10, 64)
+			assert.Nil(t, err)
+			return v
+		}
+	}
+	t.Fatalf("statistic %s not found in %v", name, stats)
+	return 0
+}
+
+func TestWATCHTHROTTLE(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "THROTTLE with invalid intervals",
+			commands: []string{"GET.WATCH k THROTTLE abc", "GET.WATCH k THROTTLE -1s", "WATCH.STATS k"},
+			expected: []interface{}{
+				errors.New("invalid THROTTLE interval 'abc'"),
+				errors.New("THROTTLE interval is negative"),
+				errors.New("wrong number of arguments for 'WATCH.STATS' command"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, nil},
+		},
+		{
+			name:           "THROTTLE among the keys of the command",
+			commands:       []string{"SET THROTTLE a", "SET 5 b", "MGET.WATCH k THROTTLE 5"},
+			expected:       []interface{}{"OK", "OK", []string{"", "a", "b"}},
+			valueExtractor: []ValueExtractorFn{extractValueSET, extractValueSET, extractValueLRANGE},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestWATCHTHROTTLENotifications(t *testing.T) {
+	writer := getLocalConnection()
+	defer writer.Close()
+	watcher := getLocalWatchWire(uniqueClientID("throttled-watcher"))
+	defer watcher.Close()
+
+	writer.Fire(&wire.Command{Cmd: "FLUSHDB"})
+	throttled := watchStat(t, writer, "throttled_subscriptions")
+	coalesced := watchStat(t, writer, "notifications_coalesced")
+
+	assert.Nil(t, watcher.Send(&wire.Command{Cmd: "GET.WATCH", Args: []string{"counter", "THROTTLE", "300ms"}}))
+	res := receiveWatch(watcher, time.Second)
+	assert.NotNil(t, res)
+	assert.Equal(t, "", res.GetGETRes().Value)
+	assert.Equal(t, throttled+1, watchStat(t, writer, "throttled_subscriptions"))
+
+	for i := 0; i < 10; i++ {
+		writer.Fire(&wire.Command{Cmd: "INCR", Args: []string{"counter"}})
+	}
+
+	// The first update is sent right away, and the following ones are
+	// coalesced into the latest one once the interval elapses.
+	res = receiveWatch(watcher, time.Second)
+	assert.NotNil(t, res)
+	assert.Equal(t, "1", res.GetGETRes().Value)
+	res = receiveWatch(watcher, time.Second)
+	assert.NotNil(t, res)
+	assert.Equal(t, "10", res.GetGETRes().Value)
+	assert.Nil(t, receiveWatch(watcher, 500*time.Millisecond))
+	assert.Equal(t, coalesced+8, watchStat(t, writer, "notifications_coalesced"))
+}
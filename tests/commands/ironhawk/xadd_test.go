@@ -0,0 +1,167 @@
+// Copyright (c) 2022-present, DiceDB contributors
+// All rights reserved. Licensed under the BSD 3-Clause License. See LICENSE file in the project root for full license information.
+
+package ironhawk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dicedb/dicedb-go/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXADD(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name:     "XADD with explicit IDs and XRANGE",
+			commands: []string{"XADD s1 1-1 f1 v1", "XADD s1 2-0 f2 v2 f3 v3", "XLEN s1", "XRANGE s1 - +", "XREVRANGE s1 + - COUNT 1"},
+			expected: []interface{}{"1-1", "2-0", 2,
+				[]string{`["1-1","f1","v1"]`, `["2-0","f2","v2","f3","v3"]`},
+				[]string{`["2-0","f2","v2","f3","v3"]`}},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueLPUSH,
+				extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:           "XADD with partial IDs and exclusive ranges",
+			commands:       []string{"XADD s2 5-* f v", "XADD s2 5-* f v", "XRANGE s2 (5-0 +", "XRANGE s2 5 5"},
+			expected:       []interface{}{"5-0", "5-1", []string{`["5-1","f","v"]`}, []string{`["5-0","f","v"]`, `["5-1","f","v"]`}},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueLRANGE, extractValueLRANGE},
+		},
+		{
+			name:           "XADD trims the stream with MAXLEN and MINID",
+			commands:       []string{"XADD s3 1-0 f v", "XADD s3 2-0 f v", "XADD s3 MAXLEN 2 3-0 f v", "XLEN s3", "XADD s3 MINID 3 4-0 f v", "XRANGE s3 - +"},
+			expected:       []interface{}{"1-0", "2-0", "3-0", 2, "4-0", []string{`["3-0","f","v"]`, `["4-0","f","v"]`}},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueLPOP, extractValueLPUSH, extractValueLPOP, extractValueLRANGE},
+		},
+		{
+			name:           "XADD with NOMKSTREAM does not create the stream",
+			commands:       []string{"XADD s4 NOMKSTREAM 1-0 f v", "XLEN s4"},
+			expected:       []interface{}{"", 0},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPUSH},
+		},
+		{
+			name:     "XADD with invalid arguments",
+			commands: []string{"XADD s5 1-0 f", "XADD s5 0-0 f v", "XADD s5 2-0 f v", "XADD s5 1-0 f v", "SET k5 v", "XADD k5 * f v"},
+			expected: []interface{}{
+				errors.New("wrong number of arguments for 'XADD' command"),
+				errors.New("the ID specified in XADD must be greater than 0-0"),
+				"2-0",
+				errors.New("the ID specified in XADD is equal or smaller than the target stream top item"),
+				"OK",
+				errors.New("wrongtype operation against a key holding the wrong kind of value"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, nil, extractValueLPOP, nil, extractValueSET, nil},
+		},
+		{
+			name:           "XREAD returns the entries after the IDs",
+			commands:       []string{"XADD s6 1-0 f v", "XADD s7 1-0 g w", "XREAD COUNT 5 STREAMS s6 s7 0 0", "XREAD STREAMS s6 1-0"},
+			expected:       []interface{}{"1-0", "1-0", []string{`["s6","1-0","f","v"]`, `["s7","1-0","g","w"]`}, []string{}},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueLRANGE, extractValueLRANGE},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestXREADGROUP(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+
+	testCases := []TestCase{
+		{
+			name: "consumer groups deliver every entry once and track pending entries",
+			commands: []string{
+				"XADD g1 1-0 f a", "XADD g1 2-0 f b", "XGROUP CREATE g1 grp 0",
+				"XREADGROUP GROUP grp c1 COUNT 1 STREAMS g1 >",
+				"XREADGROUP GROUP grp c2 STREAMS g1 >",
+				"XREADGROUP GROUP grp c1 STREAMS g1 >",
+				"XPENDING g1 grp",
+				"XACK g1 grp 1-0 9-0",
+				"XREADGROUP GROUP grp c2 STREAMS g1 0",
+			},
+			expected: []interface{}{"1-0", "2-0", "OK",
+				[]string{`["g1","1-0","f","a"]`},
+				[]string{`["g1","2-0","f","b"]`},
+				[]string{},
+				[]string{"2", "1-0", "2-0", "c1", "1", "c2", "1"},
+				1,
+				[]string{`["g1","2-0","f","b"]`},
+			},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueMULTI,
+				extractValueLRANGE, extractValueLRANGE, extractValueLRANGE, extractValueLRANGE,
+				extractValueLPUSH, extractValueLRANGE},
+		},
+		{
+			name: "XCLAIM and XAUTOCLAIM transfer pending entries",
+			commands: []string{
+				"XADD g2 1-0 f a", "XADD g2 2-0 f b", "XGROUP CREATE g2 grp 0",
+				"XREADGROUP GROUP grp c1 STREAMS g2 >",
+				"XCLAIM g2 grp c2 0 1-0 JUSTID",
+				"XAUTOCLAIM g2 grp c3 0 0 COUNT 1",
+				"XPENDING g2 grp - + 10 c3",
+			},
+			expected: []interface{}{"1-0", "2-0", "OK",
+				[]string{`["g2","1-0","f","a"]`, `["g2","2-0","f","b"]`},
+				[]string{"1-0"},
+				[]string{"2-0", `["1-0","f","a"]`},
+				1,
+			},
+			valueExtractor: []ValueExtractorFn{extractValueLPOP, extractValueLPOP, extractValueMULTI,
+				extractValueLRANGE, extractValueLRANGE, extractValueLRANGE,
+				func(res *wire.Result) interface{} { return int64(len(res.GetKEYSRes().Keys)) }},
+		},
+		{
+			name: "consumer groups with invalid arguments",
+			commands: []string{
+				"XGROUP CREATE g3 grp 0", "XGROUP CREATE g3 grp $ MKSTREAM", "XGROUP CREATE g3 grp $",
+				"XREADGROUP GROUP nogrp c1 STREAMS g3 >", "XREADGROUP STREAMS g3 >",
+			},
+			expected: []interface{}{
+				errors.New("the XGROUP subcommand requires the key to exist, use MKSTREAM to create the stream"),
+				"OK",
+				errors.New("consumer group name already exists"),
+				errors.New("no such key 'g3' or consumer group 'nogrp'"),
+				errors.New("missing GROUP option for XREADGROUP"),
+			},
+			valueExtractor: []ValueExtractorFn{nil, extractValueMULTI, nil, nil, nil},
+		},
+	}
+	runTestcases(t, client, testCases)
+}
+
+func TestXREADBlocking(t *testing.T) {
+	client := getLocalConnection()
+	defer client.Close()
+	fire := func(args ...string) *wire.Result {
+		return client.Fire(&wire.Command{Cmd: args[0], Args: args[1:]})
+	}
+	fire("FLUSHDB")
+
+	t.Run("XREAD is woken up by XADD", func(t *testing.T) {
+		c1, ch1 := fireAsync(t, "XREAD", "BLOCK", "0", "STREAMS", "bs1", "$")
+		defer c1.Close()
+		c2, ch2 := fireAsync(t, "XREAD", "BLOCK", "0", "STREAMS", "bs1", "$")
+		defer c2.Close()
+
+		fire("XADD", "bs1", "1-0", "f", "v")
+		assert.Equal(t, []string{`["bs1","1-0","f","v"]`}, receiveResult(t, ch1).GetKEYSRes().Keys)
+		assert.Equal(t, []string{`["bs1","1-0","f","v"]`}, receiveResult(t, ch2).GetKEYSRes().Keys)
+	})
+
+	t.Run("XREADGROUP is woken up by XADD", func(t *testing.T) {
+		fire("XGROUP", "CREATE", "bs2", "grp", "$", "MKSTREAM")
+		c1, ch := fireAsync(t, "XREADGROUP", "GROUP", "grp", "c1", "BLOCK", "0", "STREAMS", "bs2", ">")
+		defer c1.Close()
+
+		fire("XADD", "bs2", "1-0", "f", "v")
+		assert.Equal(t, []string{`["bs2","1-0","f","v"]`}, receiveResult(t, ch).GetKEYSRes().Keys)
+		assert.Equal(t, []string{"1", "1-0", "1-0", "c1", "1"}, fire("XPENDING", "bs2", "grp").GetKEYSRes().Keys)
+	})
+
+	t.Run("XREAD returns an empty list once the timeout expires", func(t *testing.T) {
+		assert.Equal(t, 0, len(fire("XREAD", "BLOCK", "100", "STREAMS", "bs3", "$").GetKEYSRes().Keys))
+	})
+}
@@ -121,6 +121,23 @@ func TestZADD(t *testing.T) {
 			},
 			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueZADD, extractValueZADD},
 		},
+		{
+			name: "Call ZADD with IFEQ",
+			commands: []string{
+				"ZADD keyifeq 10 member1",
+				"ZADD keyifeq IFEQ 10 20 member1",
+				"ZADD keyifeq IFEQ 10 30 member1",
+				"ZADD keyifeq IFEQ 10 30 member2",
+				"ZADD keyifeq IFEQ 20 30 member1 40 member2",
+			},
+			expected: []interface{}{
+				1, 0,
+				errors.New("precondition failed"),
+				errors.New("precondition failed"),
+				errors.New("invalid syntax for 'ZADD' command"),
+			},
+			valueExtractor: []ValueExtractorFn{extractValueZADD, extractValueZADD, nil, nil, nil},
+		},
 	}
 	runTestcases(t, client, testCases)
 }